@@ -15,6 +15,7 @@
 package awsemfexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awsemfexporter"
 
 import (
+	"math"
 	"time"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
@@ -51,6 +52,7 @@ type dataPoint struct {
 // dataPoints is a wrapper interface for:
 //   - pmetric.NumberDataPointSlice
 //   - pmetric.HistogramDataPointSlice
+//   - pmetric.ExponentialHistogramDataPointSlice
 //   - pmetric.SummaryDataPointSlice
 type dataPoints interface {
 	Len() int
@@ -96,6 +98,12 @@ type histogramDataPointSlice struct {
 	pmetric.HistogramDataPointSlice
 }
 
+// exponentialHistogramDataPointSlice is a wrapper for pmetric.ExponentialHistogramDataPointSlice
+type exponentialHistogramDataPointSlice struct {
+	instrumentationLibraryName string
+	pmetric.ExponentialHistogramDataPointSlice
+}
+
 // summaryDataPointSlice is a wrapper for pmetric.SummaryDataPointSlice
 type summaryDataPointSlice struct {
 	instrumentationLibraryName string
@@ -162,6 +170,146 @@ func (dps histogramDataPointSlice) At(i int) (dataPoint, bool) {
 	}, true
 }
 
+// At retrieves the ExponentialHistogramDataPoint at the given index. The buckets are converted to
+// values, the midpoints of the buckets, and counts, ordered by value. Empty buckets are dropped.
+func (dps exponentialHistogramDataPointSlice) At(i int) (dataPoint, bool) {
+	metric := dps.ExponentialHistogramDataPointSlice.At(i)
+	labels := createLabels(metric.Attributes(), dps.instrumentationLibraryName)
+	timestamp := unixNanoToMilliseconds(metric.Timestamp())
+
+	base := math.Exp2(math.Exp2(-float64(metric.Scale())))
+	negative := metric.Negative()
+	positive := metric.Positive()
+	size := negative.BucketCounts().Len() + positive.BucketCounts().Len() + 1
+	histogram := &cWMetricHistogram{
+		Values: make([]float64, 0, size),
+		Counts: make([]float64, 0, size),
+		Count:  metric.Count(),
+		Sum:    metric.Sum(),
+	}
+	histogram.Min, histogram.Max = exponentialHistogramBucketsMinMax(metric, base)
+	if metric.HasMin() {
+		histogram.Min = metric.Min()
+	}
+	if metric.HasMax() {
+		histogram.Max = metric.Max()
+	}
+
+	// Negative buckets, from the lowest value to the highest one
+	for j := negative.BucketCounts().Len() - 1; j >= 0; j-- {
+		if count := negative.BucketCounts().At(j); count > 0 {
+			histogram.Values = append(histogram.Values, -bucketMidpoint(base, int(negative.Offset())+j))
+			histogram.Counts = append(histogram.Counts, float64(count))
+		}
+	}
+	if metric.ZeroCount() > 0 {
+		histogram.Values = append(histogram.Values, 0)
+		histogram.Counts = append(histogram.Counts, float64(metric.ZeroCount()))
+	}
+	for j := 0; j < positive.BucketCounts().Len(); j++ {
+		if count := positive.BucketCounts().At(j); count > 0 {
+			histogram.Values = append(histogram.Values, bucketMidpoint(base, int(positive.Offset())+j))
+			histogram.Counts = append(histogram.Counts, float64(count))
+		}
+	}
+
+	return dataPoint{
+		value:       histogram,
+		labels:      labels,
+		timestampMs: timestamp,
+	}, true
+}
+
+// exponentialHistogramBucketsMinMax estimates the min and max of an exponential histogram datapoint
+// without them from the bounds of its lowest and highest non-empty buckets. They are 0 when the
+// datapoint has no non-empty bucket.
+func exponentialHistogramBucketsMinMax(dp pmetric.ExponentialHistogramDataPoint, base float64) (float64, float64) {
+	negativeFirst, negativeLast := nonEmptyBuckets(dp.Negative().BucketCounts())
+	positiveFirst, positiveLast := nonEmptyBuckets(dp.Positive().BucketCounts())
+	negativeOffset := int(dp.Negative().Offset())
+	positiveOffset := int(dp.Positive().Offset())
+
+	// The negative bucket j covers [-base^(offset+j+1), -base^(offset+j)), and the positive one
+	// (base^(offset+j), base^(offset+j+1)].
+	var min, max float64
+	switch {
+	case negativeLast >= 0:
+		min = -math.Pow(base, float64(negativeOffset+negativeLast+1))
+	case dp.ZeroCount() > 0:
+		min = 0
+	case positiveFirst >= 0:
+		min = math.Pow(base, float64(positiveOffset+positiveFirst))
+	}
+	switch {
+	case positiveLast >= 0:
+		max = math.Pow(base, float64(positiveOffset+positiveLast+1))
+	case dp.ZeroCount() > 0:
+		max = 0
+	case negativeFirst >= 0:
+		max = -math.Pow(base, float64(negativeOffset+negativeFirst))
+	}
+	return min, max
+}
+
+// nonEmptyBuckets returns the indexes of the first and last non-empty buckets, or -1 when they are all empty.
+func nonEmptyBuckets(counts pcommon.UInt64Slice) (int, int) {
+	first, last := -1, -1
+	for j := 0; j < counts.Len(); j++ {
+		if counts.At(j) > 0 {
+			if first < 0 {
+				first = j
+			}
+			last = j
+		}
+	}
+	return first, last
+}
+
+// bucketMidpoint returns the midpoint of the absolute values of the exponential histogram
+// bucket at the given index, which covers (base^index, base^(index+1)].
+func bucketMidpoint(base float64, index int) float64 {
+	lower := math.Pow(base, float64(index))
+	return (lower + lower*base) / 2
+}
+
+// split splits the histogram into histograms of at most maxValues values. The count,
+// min and max of each part are those of its values, and the sum of each part is estimated
+// from its values, except for the last part which keeps the remainder of the sum.
+func (h *cWMetricHistogram) split(maxValues int) []*cWMetricHistogram {
+	if len(h.Values) <= maxValues {
+		return []*cWMetricHistogram{h}
+	}
+
+	var parts []*cWMetricHistogram
+	remainingSum := h.Sum
+	for start := 0; start < len(h.Values); start += maxValues {
+		end := start + maxValues
+		if end > len(h.Values) {
+			end = len(h.Values)
+		}
+		part := &cWMetricHistogram{
+			Values: h.Values[start:end],
+			Counts: h.Counts[start:end],
+			Min:    h.Values[start],
+			Max:    h.Values[end-1],
+		}
+		for j := range part.Values {
+			part.Count += uint64(part.Counts[j])
+			part.Sum += part.Values[j] * part.Counts[j]
+		}
+		if start == 0 {
+			part.Min = h.Min
+		}
+		if end == len(h.Values) {
+			part.Max = h.Max
+			part.Sum = remainingSum
+		}
+		remainingSum -= part.Sum
+		parts = append(parts, part)
+	}
+	return parts
+}
+
 // At retrieves the SummaryDataPoint at the given index.
 func (dps summaryDataPointSlice) At(i int) (dataPoint, bool) {
 	metric := dps.SummaryDataPointSlice.At(i)
@@ -249,6 +397,12 @@ func getDataPoints(pmd pmetric.Metric, metadata cWMetricMetadata, logger *zap.Lo
 			metadata.instrumentationLibraryName,
 			metric.DataPoints(),
 		}
+	case pmetric.MetricTypeExponentialHistogram:
+		metric := pmd.ExponentialHistogram()
+		dps = exponentialHistogramDataPointSlice{
+			metadata.instrumentationLibraryName,
+			metric.DataPoints(),
+		}
 	case pmetric.MetricTypeSummary:
 		metric := pmd.Summary()
 		// For summaries coming from the prometheus receiver, the sum and count are cumulative, whereas for summaries
@@ -489,6 +489,149 @@ func TestHistogramDataPointSliceAtWithoutMinMax(t *testing.T) {
 	assert.Equal(t, expectedDP, dp)
 }
 
+func TestExponentialHistogramDataPointSliceAtBucketsMinMax(t *testing.T) {
+	tests := []struct {
+		name        string
+		zeroCount   uint64
+		negative    []uint64
+		positive    []uint64
+		expectedMin float64
+		expectedMax float64
+	}{
+		{
+			name:        "negative and positive buckets",
+			zeroCount:   1,
+			negative:    []uint64{1, 0},
+			positive:    []uint64{2, 0, 2},
+			expectedMin: -4,
+			expectedMax: 16,
+		},
+		{
+			name:        "zero bucket only",
+			zeroCount:   3,
+			negative:    []uint64{0},
+			positive:    []uint64{0},
+			expectedMin: 0,
+			expectedMax: 0,
+		},
+		{
+			name:        "positive buckets only",
+			positive:    []uint64{0, 1, 1},
+			expectedMin: 4,
+			expectedMax: 16,
+		},
+		{
+			name:        "negative buckets only",
+			negative:    []uint64{0, 1, 1},
+			expectedMin: -16,
+			expectedMax: -4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testDPS := pmetric.NewExponentialHistogramDataPointSlice()
+			testDP := testDPS.AppendEmpty()
+			testDP.SetScale(0)
+			testDP.SetZeroCount(tt.zeroCount)
+			// Buckets from (2, 4] and [-4, -2)
+			testDP.Negative().SetOffset(1)
+			testDP.Negative().BucketCounts().FromRaw(tt.negative)
+			testDP.Positive().SetOffset(1)
+			testDP.Positive().BucketCounts().FromRaw(tt.positive)
+
+			dp, _ := exponentialHistogramDataPointSlice{"cloudwatch-otel", testDPS}.At(0)
+			assert.Equal(t, tt.expectedMin, dp.value.(*cWMetricHistogram).Min)
+			assert.Equal(t, tt.expectedMax, dp.value.(*cWMetricHistogram).Max)
+		})
+	}
+}
+
+func TestExponentialHistogramDataPointSliceAt(t *testing.T) {
+	instrLibName := "cloudwatch-otel"
+
+	testDPS := pmetric.NewExponentialHistogramDataPointSlice()
+	testDP := testDPS.AppendEmpty()
+	testDP.SetCount(uint64(6))
+	testDP.SetSum(25.5)
+	testDP.SetMin(-1.5)
+	testDP.SetMax(13)
+	testDP.SetScale(0)
+	testDP.SetZeroCount(1)
+	// (1, 2]
+	testDP.Negative().BucketCounts().FromRaw([]uint64{1})
+	// (2, 4], (4, 8] and (8, 16]
+	testDP.Positive().SetOffset(1)
+	testDP.Positive().BucketCounts().FromRaw([]uint64{2, 0, 2})
+	testDP.Attributes().PutStr("label1", "value1")
+
+	dps := exponentialHistogramDataPointSlice{
+		instrLibName,
+		testDPS,
+	}
+
+	expectedDP := dataPoint{
+		value: &cWMetricHistogram{
+			Values: []float64{-1.5, 0, 3, 12},
+			Counts: []float64{1, 1, 2, 2},
+			Sum:    25.5,
+			Count:  6,
+			Min:    -1.5,
+			Max:    13,
+		},
+		labels: map[string]string{
+			oTellibDimensionKey: instrLibName,
+			"label1":            "value1",
+		},
+	}
+
+	assert.Equal(t, 1, dps.Len())
+	dp, retained := dps.At(0)
+	assert.True(t, retained)
+	assert.Equal(t, expectedDP, dp)
+}
+
+func TestCWMetricHistogramSplit(t *testing.T) {
+	histogram := &cWMetricHistogram{
+		Values: []float64{1, 2, 3, 4, 5},
+		Counts: []float64{1, 1, 2, 1, 3},
+		Sum:    30,
+		Count:  8,
+		Min:    0.5,
+		Max:    5.5,
+	}
+
+	assert.Equal(t, []*cWMetricHistogram{histogram}, histogram.split(5))
+
+	expected := []*cWMetricHistogram{
+		{
+			Values: []float64{1, 2},
+			Counts: []float64{1, 1},
+			Sum:    3,
+			Count:  2,
+			Min:    0.5,
+			Max:    2,
+		},
+		{
+			Values: []float64{3, 4},
+			Counts: []float64{2, 1},
+			Sum:    10,
+			Count:  3,
+			Min:    3,
+			Max:    4,
+		},
+		{
+			Values: []float64{5},
+			Counts: []float64{3},
+			Sum:    17,
+			Count:  3,
+			Min:    5,
+			Max:    5.5,
+		},
+	}
+	assert.Equal(t, expected, histogram.split(2))
+}
+
 func TestSummaryDataPointSliceAt(t *testing.T) {
 	setupDataPointCache()
 
@@ -70,33 +70,46 @@ func addToGroupedMetric(pmd pmetric.Metric, groupedMetrics map[interface{}]*grou
 			}
 		}
 
-		metric := &metricInfo{
-			value: dp.value,
-			unit:  translateUnit(pmd, descriptor),
-		}
-
 		if dp.timestampMs > 0 {
 			metadata.timestampMs = dp.timestampMs
 		}
 
-		// Extra params to use when grouping metrics
-		groupKey := groupedMetricKey(metadata.groupedMetricMetadata, labels)
-		if _, ok := groupedMetrics[groupKey]; ok {
-			// if metricName already exists in metrics map, print warning log
-			if _, ok := groupedMetrics[groupKey].metrics[metricName]; ok {
-				logger.Warn(
-					"Duplicate metric found",
-					zap.String("Name", metricName),
-					zap.Any("Labels", labels),
-				)
-			} else {
-				groupedMetrics[groupKey].metrics[metricName] = metric
+		// CloudWatch accepts at most 100 values per metric data point, so the bigger
+		// histograms are split across several EMF events.
+		values := []interface{}{dp.value}
+		if histogram, ok := dp.value.(*cWMetricHistogram); ok {
+			values = values[:0]
+			for _, part := range histogram.split(maxValuesPerDatum) {
+				values = append(values, part)
+			}
+		}
+
+		for batchIndex, value := range values {
+			metric := &metricInfo{
+				value: value,
+				unit:  translateUnit(pmd, descriptor),
 			}
-		} else {
-			groupedMetrics[groupKey] = &groupedMetric{
-				labels:   labels,
-				metrics:  map[string]*metricInfo{(metricName): metric},
-				metadata: metadata,
+
+			// Extra params to use when grouping metrics
+			metadata.batchIndex = batchIndex
+			groupKey := groupedMetricKey(metadata.groupedMetricMetadata, labels)
+			if _, ok := groupedMetrics[groupKey]; ok {
+				// if metricName already exists in metrics map, print warning log
+				if _, ok := groupedMetrics[groupKey].metrics[metricName]; ok {
+					logger.Warn(
+						"Duplicate metric found",
+						zap.String("Name", metricName),
+						zap.Any("Labels", labels),
+					)
+				} else {
+					groupedMetrics[groupKey].metrics[metricName] = metric
+				}
+			} else {
+				groupedMetrics[groupKey] = &groupedMetric{
+					labels:   labels,
+					metrics:  map[string]*metricInfo{(metricName): metric},
+					metadata: metadata,
+				}
 			}
 		}
 	}
@@ -17,6 +17,7 @@ package awsemfexporter // import "github.com/open-telemetry/opentelemetry-collec
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"regexp"
 	"sort"
 	"strings"
@@ -37,6 +38,10 @@ type MetricDeclaration struct {
 	// (Optional) List of label matchers that define matching rules to filter against
 	// the labels of incoming metrics.
 	LabelMatchers []*LabelMatcher `mapstructure:"label_matchers"`
+	// (Optional) StorageResolution is the storage resolution in seconds of the metrics matching
+	// this metric declaration rule: 1 for high-resolution metrics, or 60 for standard resolution
+	// metrics. (Default: 60)
+	StorageResolution int `mapstructure:"storage_resolution"`
 
 	// metricRegexList is a list of compiled regexes for metric name selectors.
 	metricRegexList []*regexp.Regexp
@@ -84,6 +89,10 @@ func (m *MetricDeclaration) init(logger *zap.Logger) (err error) {
 		return errors.New("invalid metric declaration: no metric name selectors defined")
 	}
 
+	if m.StorageResolution != 0 && m.StorageResolution != highResolution && m.StorageResolution != standardResolution {
+		return fmt.Errorf("invalid metric declaration: storage_resolution must be %d or %d", highResolution, standardResolution)
+	}
+
 	// Filter out duplicate dimension sets and those with more than 10 elements
 	validDims := make([][]string, 0, len(m.Dimensions))
 	seen := make(map[string]bool, len(m.Dimensions))
@@ -317,6 +317,24 @@ func TestMetricDeclarationInit(t *testing.T) {
 		assert.EqualError(t, err, "invalid metric declaration: no metric name selectors defined")
 	})
 
+	// Test storage resolution validation
+	t.Run("storage resolution", func(t *testing.T) {
+		for _, resolution := range []int{0, 1, 60} {
+			m := &MetricDeclaration{
+				MetricNameSelectors: []string{"foo"},
+				StorageResolution:   resolution,
+			}
+			assert.Nil(t, m.init(logger))
+		}
+
+		m := &MetricDeclaration{
+			MetricNameSelectors: []string{"foo"},
+			StorageResolution:   10,
+		}
+		err := m.init(logger)
+		assert.EqualError(t, err, "invalid metric declaration: storage_resolution must be 1 or 60")
+	})
+
 	// Test initialization of label matchers
 	t.Run("initialization of label matchers", func(t *testing.T) {
 		m := &MetricDeclaration{
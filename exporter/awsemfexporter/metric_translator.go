@@ -36,6 +36,13 @@ const (
 	zeroAndSingleDimensionRollup = "ZeroAndSingleDimensionRollup"
 	singleDimensionRollupOnly    = "SingleDimensionRollupOnly"
 
+	// Storage resolutions of the CloudWatch metrics, in seconds
+	highResolution     = 1
+	standardResolution = 60
+
+	// maxValuesPerDatum is the maximum number of values of a CloudWatch metric data point.
+	maxValuesPerDatum = 100
+
 	prometheusReceiver        = "prometheus"
 	attributeReceiver         = "receiver"
 	fieldPrometheusMetricType = "prom_metric_type"
@@ -58,7 +65,7 @@ type cWMetrics struct {
 type cWMeasurement struct {
 	Namespace  string
 	Dimensions [][]string
-	Metrics    []map[string]string
+	Metrics    []map[string]interface{}
 }
 
 type cWMetricStats struct {
@@ -68,11 +75,24 @@ type cWMetricStats struct {
 	Sum   float64
 }
 
+// cWMetricHistogram is the CloudWatch representation of a distribution, with the values
+// of its buckets and their counts.
+type cWMetricHistogram struct {
+	Values []float64
+	Counts []float64
+	Max    float64
+	Min    float64
+	Count  uint64
+	Sum    float64
+}
+
 type groupedMetricMetadata struct {
 	namespace   string
 	timestampMs int64
 	logGroup    string
 	logStream   string
+	// batchIndex separates the parts of a data point split across several EMF events.
+	batchIndex int
 }
 
 // cWMetricMetadata represents the metadata associated with a given CloudWatch metric
@@ -215,10 +235,10 @@ func groupedMetricToCWMeasurement(groupedMetric *groupedMetric, config *Config)
 	// Add on rolled-up dimensions
 	dimensions = append(dimensions, rollupDimensionArray...)
 
-	metrics := make([]map[string]string, len(groupedMetric.metrics))
+	metrics := make([]map[string]interface{}, len(groupedMetric.metrics))
 	idx = 0
 	for metricName, metricInfo := range groupedMetric.metrics {
-		metrics[idx] = map[string]string{
+		metrics[idx] = map[string]interface{}{
 			"Name": metricName,
 		}
 		if metricInfo.unit != "" {
@@ -265,7 +285,7 @@ func groupedMetricToCWMeasurementsWithFilters(groupedMetric *groupedMetric, conf
 	// Group metrics by matched metric declarations
 	type metricDeclarationGroup struct {
 		metricDeclIdxList []int
-		metrics           []map[string]string
+		metrics           []map[string]interface{}
 	}
 
 	metricDeclGroups := make(map[string]*metricDeclarationGroup)
@@ -286,19 +306,26 @@ func groupedMetricToCWMeasurementsWithFilters(groupedMetric *groupedMetric, conf
 			continue
 		}
 
-		metric := map[string]string{
+		metric := map[string]interface{}{
 			"Name": metricName,
 		}
 		if metricInfo.unit != "" {
 			metric["Unit"] = metricInfo.unit
 		}
+		// The metric is high-resolution if any of its matched metric declarations requests it
+		for _, i := range metricDeclIdx {
+			if metricDeclarations[i].StorageResolution == highResolution {
+				metric["StorageResolution"] = highResolution
+				break
+			}
+		}
 		metricDeclKey := fmt.Sprint(metricDeclIdx)
 		if group, ok := metricDeclGroups[metricDeclKey]; ok {
 			group.metrics = append(group.metrics, metric)
 		} else {
 			metricDeclGroups[metricDeclKey] = &metricDeclarationGroup{
 				metricDeclIdxList: metricDeclIdx,
-				metrics:           []map[string]string{metric},
+				metrics:           []map[string]interface{}{metric},
 			}
 		}
 	}
@@ -15,9 +15,11 @@
 package awsemfexporter
 
 import (
+	"fmt"
 	"os"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
 	"time"
 
@@ -339,11 +341,11 @@ func normalizeDimensionality(dims [][]string) [][]string {
 }
 
 // hashMetricSlice hashes a metrics slice for equality checking.
-func hashMetricSlice(metricSlice []map[string]string) []string {
+func hashMetricSlice(metricSlice []map[string]interface{}) []string {
 	// Convert to string for easier sorting
 	stringified := make([]string, len(metricSlice))
 	for i, v := range metricSlice {
-		stringified[i] = v["Name"] + "," + v["Unit"]
+		stringified[i] = fmt.Sprintf("%v,%v", v["Name"], v["Unit"])
 	}
 	// Sort across metrics for equality checking
 	sort.Strings(stringified)
@@ -568,7 +570,7 @@ func TestTranslateCWMetricToEMF(t *testing.T) {
 	cwMeasurement := cWMeasurement{
 		Namespace:  "test-emf",
 		Dimensions: [][]string{{oTellibDimensionKey}, {oTellibDimensionKey, "spanName"}},
-		Metrics: []map[string]string{{
+		Metrics: []map[string]interface{}{{
 			"Name": "spanCounter",
 			"Unit": "Count",
 		}},
@@ -598,6 +600,56 @@ func TestTranslateCWMetricToEMF(t *testing.T) {
 	assert.Equal(t, readFromFile("testdata/testTranslateCWMetricToEMF.json"), *inputLogEvent.InputLogEvent.Message, "Expect to be equal")
 }
 
+func TestTranslateExponentialHistogramToEMF(t *testing.T) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("cloudwatch-otel")
+	metric := sm.Metrics().AppendEmpty()
+	metric.SetName("latency")
+	metric.SetUnit("ms")
+	dp := metric.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	dp.SetTimestamp(pcommon.Timestamp(1596151098037 * int64(time.Millisecond)))
+	dp.Attributes().PutStr("service", "api")
+	dp.SetScale(4)
+	dp.SetZeroCount(1)
+	// 105 values, split across two EMF events
+	counts := make([]uint64, 104)
+	for i := range counts {
+		counts[i] = uint64(i%3 + 1)
+		dp.SetCount(dp.Count() + counts[i])
+	}
+	dp.SetCount(dp.Count() + dp.ZeroCount())
+	dp.Positive().BucketCounts().FromRaw(counts)
+	dp.SetSum(9000)
+	dp.SetMin(0)
+	dp.SetMax(95)
+
+	metricDeclaration := &MetricDeclaration{
+		Dimensions:          [][]string{{"service"}},
+		MetricNameSelectors: []string{"latency"},
+		StorageResolution:   1,
+	}
+	assert.NoError(t, metricDeclaration.init(zap.NewNop()))
+	config := &Config{
+		Namespace:          "test-emf",
+		MetricDeclarations: []*MetricDeclaration{metricDeclaration},
+		logger:             zap.NewNop(),
+	}
+
+	groupedMetrics := make(map[interface{}]*groupedMetric)
+	assert.NoError(t, newMetricTranslator(*config).translateOTelToGroupedMetric(rm, groupedMetrics, config))
+	assert.Len(t, groupedMetrics, 2)
+
+	var messages []string
+	for _, groupedMetric := range groupedMetrics {
+		event := translateCWMetricToEMF(translateGroupedMetricToCWMetric(groupedMetric, config), config)
+		messages = append(messages, *event.InputLogEvent.Message)
+	}
+	sort.Strings(messages)
+	assert.Equal(t, readFromFile("testdata/testTranslateExponentialHistogramToEMF.json"), strings.Join(messages, "\n")+"\n")
+}
+
 func TestTranslateGroupedMetricToCWMetric(t *testing.T) {
 	timestamp := int64(1596151098037)
 	namespace := "Namespace"
@@ -632,7 +684,7 @@ func TestTranslateGroupedMetricToCWMetric(t *testing.T) {
 					{
 						Namespace:  namespace,
 						Dimensions: [][]string{{"label1"}},
-						Metrics: []map[string]string{
+						Metrics: []map[string]interface{}{
 							{
 								"Name": "metric1",
 								"Unit": "Count",
@@ -677,7 +729,7 @@ func TestTranslateGroupedMetricToCWMetric(t *testing.T) {
 					{
 						Namespace:  namespace,
 						Dimensions: [][]string{{"label1"}},
-						Metrics: []map[string]string{
+						Metrics: []map[string]interface{}{
 							{
 								"Name": "metric1",
 								"Unit": "Count",
@@ -726,7 +778,7 @@ func TestTranslateGroupedMetricToCWMetric(t *testing.T) {
 					{
 						Namespace:  namespace,
 						Dimensions: [][]string{{"label1", "label2"}},
-						Metrics: []map[string]string{
+						Metrics: []map[string]interface{}{
 							{
 								"Name": "metric1",
 								"Unit": "Count",
@@ -801,7 +853,7 @@ func TestTranslateGroupedMetricToCWMetric(t *testing.T) {
 					{
 						Namespace:  namespace,
 						Dimensions: [][]string{{"label1"}},
-						Metrics: []map[string]string{
+						Metrics: []map[string]interface{}{
 							{
 								"Name": "metric1",
 								"Unit": "Count",
@@ -811,7 +863,7 @@ func TestTranslateGroupedMetricToCWMetric(t *testing.T) {
 					{
 						Namespace:  namespace,
 						Dimensions: [][]string{{"label1", "label2"}},
-						Metrics: []map[string]string{
+						Metrics: []map[string]interface{}{
 							{
 								"Name": "metric2",
 								"Unit": "Count",
@@ -885,7 +937,7 @@ func TestTranslateGroupedMetricToCWMetric(t *testing.T) {
 					{
 						Namespace:  namespace,
 						Dimensions: [][]string{{"label1"}},
-						Metrics: []map[string]string{
+						Metrics: []map[string]interface{}{
 							{
 								"Name": "metric1",
 								"Unit": "Count",
@@ -955,7 +1007,7 @@ func TestGroupedMetricToCWMeasurement(t *testing.T) {
 			cWMeasurement{
 				Namespace:  namespace,
 				Dimensions: [][]string{{"label1"}},
-				Metrics: []map[string]string{
+				Metrics: []map[string]interface{}{
 					{
 						"Name": "metric1",
 						"Unit": "Count",
@@ -995,7 +1047,7 @@ func TestGroupedMetricToCWMeasurement(t *testing.T) {
 			cWMeasurement{
 				Namespace:  namespace,
 				Dimensions: [][]string{{"label1", "label2"}},
-				Metrics: []map[string]string{
+				Metrics: []map[string]interface{}{
 					{
 						"Name": "metric1",
 						"Unit": "Count",
@@ -1034,7 +1086,7 @@ func TestGroupedMetricToCWMeasurement(t *testing.T) {
 			cWMeasurement{
 				Namespace:  namespace,
 				Dimensions: [][]string{{"label1"}},
-				Metrics: []map[string]string{
+				Metrics: []map[string]interface{}{
 					{
 						"Name": "metric1",
 						"Unit": "Count",
@@ -1079,7 +1131,7 @@ func TestGroupedMetricToCWMeasurement(t *testing.T) {
 					{"label2"},
 					{},
 				},
-				Metrics: []map[string]string{
+				Metrics: []map[string]interface{}{
 					{
 						"Name": "metric1",
 						"Unit": "Count",
@@ -1322,7 +1374,7 @@ func TestGroupedMetricToCWMeasurementsWithFilters(t *testing.T) {
 				{
 					Namespace:  namespace,
 					Dimensions: [][]string{{"a"}, {"a", "c"}},
-					Metrics: []map[string]string{
+					Metrics: []map[string]interface{}{
 						{
 							"Name": "metric1",
 							"Unit": "Count",
@@ -1359,7 +1411,7 @@ func TestGroupedMetricToCWMeasurementsWithFilters(t *testing.T) {
 				{
 					Namespace:  namespace,
 					Dimensions: [][]string{{"a"}, {"b"}, {"a", "c"}},
-					Metrics: []map[string]string{
+					Metrics: []map[string]interface{}{
 						{
 							"Name": "metric1",
 							"Unit": "Count",
@@ -1369,7 +1421,7 @@ func TestGroupedMetricToCWMeasurementsWithFilters(t *testing.T) {
 				{
 					Namespace:  namespace,
 					Dimensions: [][]string{{"a"}, {"b"}},
-					Metrics: []map[string]string{
+					Metrics: []map[string]interface{}{
 						{
 							"Name": "metric2",
 							"Unit": "Count",
@@ -1379,7 +1431,7 @@ func TestGroupedMetricToCWMeasurementsWithFilters(t *testing.T) {
 				{
 					Namespace:  namespace,
 					Dimensions: [][]string{{"a"}},
-					Metrics: []map[string]string{
+					Metrics: []map[string]interface{}{
 						{
 							"Name": "metric3",
 							"Unit": "Seconds",
@@ -1404,7 +1456,7 @@ func TestGroupedMetricToCWMeasurementsWithFilters(t *testing.T) {
 				{
 					Namespace:  namespace,
 					Dimensions: [][]string{{"a"}, {"b"}},
-					Metrics: []map[string]string{
+					Metrics: []map[string]interface{}{
 						{
 							"Name": "metric1",
 							"Unit": "Count",
@@ -1418,7 +1470,7 @@ func TestGroupedMetricToCWMeasurementsWithFilters(t *testing.T) {
 				{
 					Namespace:  namespace,
 					Dimensions: [][]string{{"a"}},
-					Metrics: []map[string]string{
+					Metrics: []map[string]interface{}{
 						{
 							"Name": "metric3",
 							"Unit": "Seconds",
@@ -1443,7 +1495,7 @@ func TestGroupedMetricToCWMeasurementsWithFilters(t *testing.T) {
 				{
 					Namespace:  namespace,
 					Dimensions: [][]string{{"a"}, {"b"}},
-					Metrics: []map[string]string{
+					Metrics: []map[string]interface{}{
 						{
 							"Name": "metric1",
 							"Unit": "Count",
@@ -1477,7 +1529,7 @@ func TestGroupedMetricToCWMeasurementsWithFilters(t *testing.T) {
 				{
 					Namespace:  namespace,
 					Dimensions: [][]string{{}},
-					Metrics: []map[string]string{
+					Metrics: []map[string]interface{}{
 						{
 							"Name": "metric1",
 							"Unit": "Count",
@@ -1518,7 +1570,7 @@ func TestGroupedMetricToCWMeasurementsWithFilters(t *testing.T) {
 				{
 					Namespace:  namespace,
 					Dimensions: [][]string{{"b"}},
-					Metrics: []map[string]string{
+					Metrics: []map[string]interface{}{
 						{
 							"Name": "metric1",
 							"Unit": "Count",
@@ -2310,7 +2362,7 @@ func BenchmarkTranslateCWMetricToEMF(b *testing.B) {
 	cwMeasurement := cWMeasurement{
 		Namespace:  "test-emf",
 		Dimensions: [][]string{{oTellibDimensionKey}, {oTellibDimensionKey, "spanName"}},
-		Metrics: []map[string]string{{
+		Metrics: []map[string]interface{}{{
 			"Name": "spanCounter",
 			"Unit": "Count",
 		}},
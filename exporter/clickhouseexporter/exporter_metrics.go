@@ -0,0 +1,446 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "github.com/ClickHouse/clickhouse-go/v2" // For register database driver.
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
+)
+
+type metricsExporter struct {
+	client *sql.DB
+	tables []*metricsTable
+
+	logger *zap.Logger
+	cfg    *Config
+}
+
+func newMetricsExporter(logger *zap.Logger, cfg *Config) (*metricsExporter, error) {
+
+	if err := createDatabase(cfg); err != nil {
+		return nil, err
+	}
+
+	client, err := newClickhouseClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := newMetricsTables(cfg)
+	if err = createMetricsTables(cfg, client, tables); err != nil {
+		return nil, err
+	}
+
+	return &metricsExporter{
+		client: client,
+		tables: tables,
+		logger: logger,
+		cfg:    cfg,
+	}, nil
+}
+
+// Shutdown will shutdown the exporter.
+func (e *metricsExporter) Shutdown(_ context.Context) error {
+	if e.client != nil {
+		return e.client.Close()
+	}
+	return nil
+}
+
+func (e *metricsExporter) pushMetricsData(ctx context.Context, md pmetric.Metrics) error {
+	start := time.Now()
+	rows := make(map[pmetric.MetricType][][]interface{}, len(e.tables))
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		metrics := md.ResourceMetrics().At(i)
+		res := metrics.Resource()
+		resAttr := metricAttributesToMap(res.Attributes())
+		var serviceName string
+		if v, ok := res.Attributes().Get(conventions.AttributeServiceName); ok {
+			serviceName = v.Str()
+		}
+		for j := 0; j < metrics.ScopeMetrics().Len(); j++ {
+			sm := metrics.ScopeMetrics().At(j)
+			scope := sm.Scope()
+			scopeAttr := metricAttributesToMap(scope.Attributes())
+			ms := sm.Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				common := func(attrs pcommon.Map, startTime, ts pcommon.Timestamp) []interface{} {
+					return []interface{}{
+						resAttr,
+						metrics.SchemaUrl(),
+						scope.Name(),
+						scope.Version(),
+						scopeAttr,
+						scope.DroppedAttributesCount(),
+						sm.SchemaUrl(),
+						serviceName,
+						m.Name(),
+						m.Description(),
+						m.Unit(),
+						metricAttributesToMap(attrs),
+						startTime.AsTime(),
+						ts.AsTime(),
+					}
+				}
+				rows[m.Type()] = append(rows[m.Type()], metricRows(m, common)...)
+			}
+		}
+	}
+
+	for _, table := range e.tables {
+		if len(rows[table.metricType]) == 0 {
+			continue
+		}
+		// The clickhouse driver sends one batch per transaction, so every table is inserted separately.
+		if err := doWithTx(ctx, e.client, func(tx *sql.Tx) error {
+			statement, err := tx.PrepareContext(ctx, table.insertSQL)
+			if err != nil {
+				return fmt.Errorf("PrepareContext:%w", err)
+			}
+			defer func() {
+				_ = statement.Close()
+			}()
+			for _, row := range rows[table.metricType] {
+				if _, err = statement.ExecContext(ctx, row...); err != nil {
+					return fmt.Errorf("ExecContext:%w", err)
+				}
+			}
+			return nil
+		}); err != nil {
+			return fmt.Errorf("insert %s: %w", table.name, err)
+		}
+	}
+	duration := time.Since(start)
+	e.logger.Info("insert metrics", zap.Int("records", md.DataPointCount()),
+		zap.String("cost", duration.String()))
+	return nil
+}
+
+// metricRows converts the data points of a metric into rows of its table.
+// common returns the leading values shared by all tables.
+func metricRows(m pmetric.Metric, common func(attrs pcommon.Map, startTime, ts pcommon.Timestamp) []interface{}) [][]interface{} {
+	var rows [][]interface{}
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			row := append(common(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp()),
+				numberValue(dp),
+				uint32(dp.Flags()),
+			)
+			rows = append(rows, append(row, convertExemplars(dp.Exemplars())...))
+		}
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			row := append(common(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp()),
+				numberValue(dp),
+				uint32(dp.Flags()),
+				int32(m.Sum().AggregationTemporality()),
+				m.Sum().IsMonotonic(),
+			)
+			rows = append(rows, append(row, convertExemplars(dp.Exemplars())...))
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			row := append(common(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp()),
+				dp.Count(),
+				dp.Sum(),
+				dp.BucketCounts().AsRaw(),
+				dp.ExplicitBounds().AsRaw(),
+				dp.Min(),
+				dp.Max(),
+				uint32(dp.Flags()),
+				int32(m.Histogram().AggregationTemporality()),
+			)
+			rows = append(rows, append(row, convertExemplars(dp.Exemplars())...))
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			row := append(common(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp()),
+				dp.Count(),
+				dp.Sum(),
+				dp.Scale(),
+				dp.ZeroCount(),
+				dp.Positive().Offset(),
+				dp.Positive().BucketCounts().AsRaw(),
+				dp.Negative().Offset(),
+				dp.Negative().BucketCounts().AsRaw(),
+				dp.Min(),
+				dp.Max(),
+				uint32(dp.Flags()),
+				int32(m.ExponentialHistogram().AggregationTemporality()),
+			)
+			rows = append(rows, append(row, convertExemplars(dp.Exemplars())...))
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			quantiles, values := convertQuantiles(dp.QuantileValues())
+			rows = append(rows, append(common(dp.Attributes(), dp.StartTimestamp(), dp.Timestamp()),
+				dp.Count(),
+				dp.Sum(),
+				quantiles,
+				values,
+				uint32(dp.Flags()),
+			))
+		}
+	}
+	return rows
+}
+
+func numberValue(dp pmetric.NumberDataPoint) float64 {
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		return float64(dp.IntValue())
+	case pmetric.NumberDataPointValueTypeDouble:
+		return dp.DoubleValue()
+	}
+	return 0
+}
+
+func convertExemplars(exemplars pmetric.ExemplarSlice) []interface{} {
+	var (
+		attrs    []map[string]string
+		times    []time.Time
+		values   []float64
+		spanIDs  []string
+		traceIDs []string
+	)
+	for i := 0; i < exemplars.Len(); i++ {
+		exemplar := exemplars.At(i)
+		attrs = append(attrs, metricAttributesToMap(exemplar.FilteredAttributes()))
+		times = append(times, exemplar.Timestamp().AsTime())
+		switch exemplar.ValueType() {
+		case pmetric.ExemplarValueTypeInt:
+			values = append(values, float64(exemplar.IntValue()))
+		case pmetric.ExemplarValueTypeDouble:
+			values = append(values, exemplar.DoubleValue())
+		default:
+			values = append(values, 0)
+		}
+		spanIDs = append(spanIDs, traceutil.SpanIDToHexOrEmptyString(exemplar.SpanID()))
+		traceIDs = append(traceIDs, traceutil.TraceIDToHexOrEmptyString(exemplar.TraceID()))
+	}
+	return []interface{}{attrs, times, values, spanIDs, traceIDs}
+}
+
+func convertQuantiles(quantileValues pmetric.SummaryDataPointValueAtQuantileSlice) ([]float64, []float64) {
+	var (
+		quantiles []float64
+		values    []float64
+	)
+	for i := 0; i < quantileValues.Len(); i++ {
+		quantileValue := quantileValues.At(i)
+		quantiles = append(quantiles, quantileValue.Quantile())
+		values = append(values, quantileValue.Value())
+	}
+	return quantiles, values
+}
+
+// metricAttributesToMap converts the attributes of metrics to strings, unlike attributesToMap
+// which only keeps the string attributes, as metric attributes are often numbers.
+func metricAttributesToMap(attributes pcommon.Map) map[string]string {
+	m := make(map[string]string, attributes.Len())
+	attributes.Range(func(k string, v pcommon.Value) bool {
+		m[k] = v.AsString()
+		return true
+	})
+	return m
+}
+
+// metricsTable is the table storing the data points of one metric type.
+type metricsTable struct {
+	metricType pmetric.MetricType
+	name       string
+	columns    string
+	insertSQL  string
+}
+
+const (
+	exemplarsInsertColumns            = "Exemplars.FilteredAttributes, Exemplars.TimeUnix, Exemplars.Value, Exemplars.SpanId, Exemplars.TraceId"
+	gaugeInsertColumns                = "Value, Flags, " + exemplarsInsertColumns
+	sumInsertColumns                  = "Value, Flags, AggTemp, IsMonotonic, " + exemplarsInsertColumns
+	histogramInsertColumns            = "Count, Sum, BucketCounts, ExplicitBounds, Min, Max, Flags, AggTemp, " + exemplarsInsertColumns
+	exponentialHistogramInsertColumns = "Count, Sum, Scale, ZeroCount, PositiveOffset, PositiveBucketCounts, NegativeOffset, NegativeBucketCounts, Min, Max, Flags, AggTemp, " + exemplarsInsertColumns
+	summaryInsertColumns              = "Count, Sum, ValueAtQuantiles.Quantile, ValueAtQuantiles.Value, Flags"
+)
+
+func newMetricsTables(cfg *Config) []*metricsTable {
+	tables := []*metricsTable{
+		{metricType: pmetric.MetricTypeGauge, name: cfg.MetricsTableName + "_gauge", columns: gaugeColumnsSQL},
+		{metricType: pmetric.MetricTypeSum, name: cfg.MetricsTableName + "_sum", columns: sumColumnsSQL},
+		{metricType: pmetric.MetricTypeHistogram, name: cfg.MetricsTableName + "_histogram", columns: histogramColumnsSQL},
+		{metricType: pmetric.MetricTypeExponentialHistogram, name: cfg.MetricsTableName + "_exponential_histogram", columns: exponentialHistogramColumnsSQL},
+		{metricType: pmetric.MetricTypeSummary, name: cfg.MetricsTableName + "_summary", columns: summaryColumnsSQL},
+	}
+	insertColumns := map[pmetric.MetricType]string{
+		pmetric.MetricTypeGauge:                gaugeInsertColumns,
+		pmetric.MetricTypeSum:                  sumInsertColumns,
+		pmetric.MetricTypeHistogram:            histogramInsertColumns,
+		pmetric.MetricTypeExponentialHistogram: exponentialHistogramInsertColumns,
+		pmetric.MetricTypeSummary:              summaryInsertColumns,
+	}
+	for _, table := range tables {
+		table.insertSQL = renderInsertMetricsSQL(table.name, insertColumns[table.metricType])
+	}
+	return tables
+}
+
+const (
+	// language=ClickHouse SQL
+	createMetricsTableSQL = `
+CREATE TABLE IF NOT EXISTS %s (
+     ResourceAttributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+     ResourceSchemaUrl String CODEC(ZSTD(1)),
+     ScopeName String CODEC(ZSTD(1)),
+     ScopeVersion String CODEC(ZSTD(1)),
+     ScopeAttributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+     ScopeDroppedAttrCount UInt32 CODEC(ZSTD(1)),
+     ScopeSchemaUrl String CODEC(ZSTD(1)),
+     ServiceName LowCardinality(String) CODEC(ZSTD(1)),
+     MetricName String CODEC(ZSTD(1)),
+     MetricDescription String CODEC(ZSTD(1)),
+     MetricUnit String CODEC(ZSTD(1)),
+     Attributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+     StartTimeUnix DateTime64(9) CODEC(Delta, ZSTD(1)),
+     TimeUnix DateTime64(9) CODEC(Delta, ZSTD(1)),
+%s
+     INDEX idx_res_attr_key mapKeys(ResourceAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_res_attr_value mapValues(ResourceAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_scope_attr_key mapKeys(ScopeAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_scope_attr_value mapValues(ScopeAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_attr_key mapKeys(Attributes) TYPE bloom_filter(0.01) GRANULARITY 1,
+     INDEX idx_attr_value mapValues(Attributes) TYPE bloom_filter(0.01) GRANULARITY 1
+) ENGINE MergeTree()
+%s
+PARTITION BY toDate(TimeUnix)
+ORDER BY (ServiceName, MetricName, toUnixTimestamp64Nano(TimeUnix))
+SETTINGS index_granularity=8192, ttl_only_drop_parts = 1;
+`
+	// language=ClickHouse SQL
+	exemplarsColumnsSQL = `
+     Exemplars Nested (
+         FilteredAttributes Map(LowCardinality(String), String),
+         TimeUnix DateTime64(9),
+         Value Float64,
+         SpanId String,
+         TraceId String
+     ) CODEC(ZSTD(1)),`
+	// language=ClickHouse SQL
+	gaugeColumnsSQL = `
+     Value Float64 CODEC(ZSTD(1)),
+     Flags UInt32 CODEC(ZSTD(1)),` + exemplarsColumnsSQL
+	// language=ClickHouse SQL
+	sumColumnsSQL = `
+     Value Float64 CODEC(ZSTD(1)),
+     Flags UInt32 CODEC(ZSTD(1)),
+     AggTemp Int32 CODEC(ZSTD(1)),
+     IsMonotonic Boolean CODEC(Delta, ZSTD(1)),` + exemplarsColumnsSQL
+	// language=ClickHouse SQL
+	histogramColumnsSQL = `
+     Count UInt64 CODEC(Delta, ZSTD(1)),
+     Sum Float64 CODEC(ZSTD(1)),
+     BucketCounts Array(UInt64) CODEC(ZSTD(1)),
+     ExplicitBounds Array(Float64) CODEC(ZSTD(1)),
+     Min Float64 CODEC(ZSTD(1)),
+     Max Float64 CODEC(ZSTD(1)),
+     Flags UInt32 CODEC(ZSTD(1)),
+     AggTemp Int32 CODEC(ZSTD(1)),` + exemplarsColumnsSQL
+	// language=ClickHouse SQL
+	exponentialHistogramColumnsSQL = `
+     Count UInt64 CODEC(Delta, ZSTD(1)),
+     Sum Float64 CODEC(ZSTD(1)),
+     Scale Int32 CODEC(ZSTD(1)),
+     ZeroCount UInt64 CODEC(ZSTD(1)),
+     PositiveOffset Int32 CODEC(ZSTD(1)),
+     PositiveBucketCounts Array(UInt64) CODEC(ZSTD(1)),
+     NegativeOffset Int32 CODEC(ZSTD(1)),
+     NegativeBucketCounts Array(UInt64) CODEC(ZSTD(1)),
+     Min Float64 CODEC(ZSTD(1)),
+     Max Float64 CODEC(ZSTD(1)),
+     Flags UInt32 CODEC(ZSTD(1)),
+     AggTemp Int32 CODEC(ZSTD(1)),` + exemplarsColumnsSQL
+	// language=ClickHouse SQL
+	summaryColumnsSQL = `
+     Count UInt64 CODEC(Delta, ZSTD(1)),
+     Sum Float64 CODEC(ZSTD(1)),
+     ValueAtQuantiles Nested (
+         Quantile Float64,
+         Value Float64
+     ) CODEC(ZSTD(1)),
+     Flags UInt32 CODEC(ZSTD(1)),`
+	// language=ClickHouse SQL
+	insertMetricsSQLTemplate = `INSERT INTO %s (
+                        ResourceAttributes,
+                        ResourceSchemaUrl,
+                        ScopeName,
+                        ScopeVersion,
+                        ScopeAttributes,
+                        ScopeDroppedAttrCount,
+                        ScopeSchemaUrl,
+                        ServiceName,
+                        MetricName,
+                        MetricDescription,
+                        MetricUnit,
+                        Attributes,
+                        StartTimeUnix,
+                        TimeUnix,
+                        %s
+                        ) VALUES (%s)`
+)
+
+func createMetricsTables(cfg *Config, db *sql.DB, tables []*metricsTable) error {
+	for _, table := range tables {
+		if _, err := db.Exec(renderCreateMetricsTableSQL(cfg, table)); err != nil {
+			return fmt.Errorf("exec create metrics table %s sql: %w", table.name, err)
+		}
+	}
+	return nil
+}
+
+func renderCreateMetricsTableSQL(cfg *Config, table *metricsTable) string {
+	var ttlExpr string
+	if cfg.TTLDays > 0 {
+		ttlExpr = fmt.Sprintf(`TTL toDateTime(TimeUnix) + toIntervalDay(%d)`, cfg.TTLDays)
+	}
+	return fmt.Sprintf(createMetricsTableSQL, table.name, strings.TrimPrefix(table.columns, "\n"), ttlExpr)
+}
+
+func renderInsertMetricsSQL(tableName string, columns string) string {
+	// 14 columns are shared by all metrics tables.
+	numColumns := 14 + strings.Count(columns, ",") + 1
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", numColumns), ",")
+	return fmt.Sprintf(insertMetricsSQLTemplate, tableName, columns, placeholders)
+}
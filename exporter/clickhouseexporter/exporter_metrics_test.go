@@ -0,0 +1,183 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestMetricsExporter_New(t *testing.T) {
+	var created []string
+	initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+		if strings.HasPrefix(strings.TrimSpace(query), "CREATE TABLE") {
+			created = append(created, query)
+		}
+		return nil
+	})
+
+	newTestMetricsExporter(t, defaultDSN, func(cfg *Config) {
+		cfg.MetricsTableName = "metrics"
+		cfg.TTLDays = 3
+	})
+
+	require.Len(t, created, 5)
+	for i, table := range []string{"metrics_gauge", "metrics_sum", "metrics_histogram", "metrics_exponential_histogram", "metrics_summary"} {
+		require.Contains(t, created[i], "CREATE TABLE IF NOT EXISTS "+table+" (")
+		require.Contains(t, created[i], "ENGINE MergeTree()")
+		require.Contains(t, created[i], "TTL toDateTime(TimeUnix) + toIntervalDay(3)")
+	}
+}
+
+func TestExporter_pushMetricsData(t *testing.T) {
+	t.Run("push success", func(t *testing.T) {
+		items := map[string]int{}
+		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+			if strings.HasPrefix(query, "INSERT") {
+				table := strings.Fields(query)[2]
+				t.Logf("%s, values:%+v", table, values)
+				items[table]++
+			}
+			return nil
+		})
+
+		exporter := newTestMetricsExporter(t, defaultDSN)
+		mustPushMetricsData(t, exporter, simpleMetrics(1))
+		mustPushMetricsData(t, exporter, simpleMetrics(2))
+
+		require.Equal(t, map[string]int{
+			"otel_metrics_gauge":                 3,
+			"otel_metrics_sum":                   3,
+			"otel_metrics_histogram":             3,
+			"otel_metrics_exponential_histogram": 3,
+			"otel_metrics_summary":               3,
+		}, items)
+	})
+	t.Run("values", func(t *testing.T) {
+		values := map[string][]driver.Value{}
+		initClickhouseTestServer(t, func(query string, v []driver.Value) error {
+			if strings.HasPrefix(query, "INSERT") {
+				values[strings.Fields(query)[2]] = v
+			}
+			return nil
+		})
+
+		exporter := newTestMetricsExporter(t, defaultDSN)
+		mustPushMetricsData(t, exporter, simpleMetrics(1))
+
+		gauge := values["otel_metrics_gauge"]
+		require.Equal(t, map[string]string{conventions.AttributeServiceName: "demo"}, gauge[0])
+		require.Equal(t, "demo", gauge[7])
+		require.Equal(t, "gauge", gauge[8])
+		require.Equal(t, map[string]string{"status": "200"}, gauge[11])
+		require.Equal(t, float64(42), gauge[14])
+
+		sum := values["otel_metrics_sum"]
+		require.Equal(t, 1.5, sum[14])
+		require.Equal(t, int32(pmetric.AggregationTemporalityCumulative), sum[16])
+		require.Equal(t, true, sum[17])
+		require.Equal(t, []float64{7}, sum[20])
+		require.Equal(t, []string{"0102030405060708"}, sum[21])
+		require.Equal(t, []map[string]string{{"sampled": "true"}}, sum[18])
+
+		summary := values["otel_metrics_summary"]
+		require.Equal(t, []float64{0.5, 0.99}, summary[16])
+		require.Equal(t, []float64{1, 9}, summary[17])
+	})
+}
+
+func newTestMetricsExporter(t *testing.T, dsn string, fns ...func(*Config)) *metricsExporter {
+	exporter, err := newMetricsExporter(zaptest.NewLogger(t), withTestExporterConfig(fns...)(dsn))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = exporter.Shutdown(context.TODO()) })
+	return exporter
+}
+
+func simpleMetrics(count int) pmetric.Metrics {
+	metrics := pmetric.NewMetrics()
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr(conventions.AttributeServiceName, "demo")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName("scope")
+	now := pcommon.NewTimestampFromTime(time.Now())
+	for i := 0; i < count; i++ {
+		m := sm.Metrics().AppendEmpty()
+		m.SetName("gauge")
+		dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+		dp.SetTimestamp(now)
+		dp.SetIntValue(42)
+		dp.Attributes().PutInt("status", 200)
+
+		m = sm.Metrics().AppendEmpty()
+		m.SetName("sum")
+		sum := m.SetEmptySum()
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		sum.SetIsMonotonic(true)
+		dp = sum.DataPoints().AppendEmpty()
+		dp.SetTimestamp(now)
+		dp.SetDoubleValue(1.5)
+		exemplar := dp.Exemplars().AppendEmpty()
+		exemplar.SetIntValue(7)
+		exemplar.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+		exemplar.FilteredAttributes().PutBool("sampled", true)
+
+		m = sm.Metrics().AppendEmpty()
+		m.SetName("histogram")
+		hdp := m.SetEmptyHistogram().DataPoints().AppendEmpty()
+		hdp.SetTimestamp(now)
+		hdp.SetCount(3)
+		hdp.SetSum(6)
+		hdp.ExplicitBounds().FromRaw([]float64{1, 2})
+		hdp.BucketCounts().FromRaw([]uint64{1, 1, 1})
+
+		m = sm.Metrics().AppendEmpty()
+		m.SetName("exponential_histogram")
+		edp := m.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+		edp.SetTimestamp(now)
+		edp.SetCount(2)
+		edp.SetScale(1)
+		edp.Positive().SetOffset(-1)
+		edp.Positive().BucketCounts().FromRaw([]uint64{1, 1})
+
+		m = sm.Metrics().AppendEmpty()
+		m.SetName("summary")
+		sdp := m.SetEmptySummary().DataPoints().AppendEmpty()
+		sdp.SetTimestamp(now)
+		sdp.SetCount(10)
+		sdp.SetSum(20)
+		q := sdp.QuantileValues().AppendEmpty()
+		q.SetQuantile(0.5)
+		q.SetValue(1)
+		q = sdp.QuantileValues().AppendEmpty()
+		q.SetQuantile(0.99)
+		q.SetValue(9)
+	}
+	return metrics
+}
+
+func mustPushMetricsData(t *testing.T, exporter *metricsExporter, md pmetric.Metrics) {
+	err := exporter.pushMetricsData(context.TODO(), md)
+	require.NoError(t, err)
+}
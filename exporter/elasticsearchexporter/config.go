@@ -53,11 +53,40 @@ type Config struct {
 	Index string `mapstructure:"index"`
 
 	// This setting is required when logging pipelines used.
+	//
+	// The index may contain `{resource.<key>}` and `{attributes.<key>}` placeholders,
+	// which are replaced by the attribute values of each log record.
 	LogsIndex string `mapstructure:"logs_index"`
 
+	// LogsIndexFallback is used when a placeholder of LogsIndex can not be resolved.
+	LogsIndexFallback string `mapstructure:"logs_index_fallback"`
+
 	// This setting is required when traces pipelines used.
+	//
+	// The index may contain placeholders, see LogsIndex.
 	TracesIndex string `mapstructure:"traces_index"`
 
+	// TracesIndexFallback is used when a placeholder of TracesIndex can not be resolved.
+	TracesIndexFallback string `mapstructure:"traces_index_fallback"`
+
+	// This setting is required when metrics pipelines used.
+	//
+	// The index may contain placeholders, see LogsIndex.
+	MetricsIndex string `mapstructure:"metrics_index"`
+
+	// MetricsIndexFallback is used when a placeholder of MetricsIndex can not be resolved.
+	MetricsIndexFallback string `mapstructure:"metrics_index_fallback"`
+
+	// IndexTimeSuffix configures a suffix appended to the index, formatted from the
+	// timestamp of each event in UTC. The value is a Go time layout, e.g. `2006.01.02`.
+	IndexTimeSuffix string `mapstructure:"index_time_suffix"`
+
+	// DataStream configures the data stream mode, where the index is computed from
+	// the `data_stream.*` attributes.
+	//
+	// https://www.elastic.co/guide/en/ecs/current/ecs-data_stream.html
+	DataStream DataStreamSettings `mapstructure:"data_stream"`
+
 	// Pipeline configures the ingest node pipeline name that should be used to process the
 	// events.
 	//
@@ -150,6 +179,21 @@ type RetrySettings struct {
 	MaxInterval time.Duration `mapstructure:"max_interval"`
 }
 
+// DataStreamSettings defines the data stream mode of the Elasticsearch exporter.
+// Events are sent to the `<type>-<dataset>-<namespace>` data stream, where the type
+// is `logs`, `traces` or `metrics`, and the dataset and namespace are read from the
+// `data_stream.dataset` and `data_stream.namespace` attributes of the event or its resource.
+type DataStreamSettings struct {
+	// Enabled enables the data stream mode. The index settings are ignored when enabled.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Dataset is used when the event has no `data_stream.dataset` attribute.
+	Dataset string `mapstructure:"dataset"`
+
+	// Namespace is used when the event has no `data_stream.namespace` attribute.
+	Namespace string `mapstructure:"namespace"`
+}
+
 type MappingsSettings struct {
 	// Mode configures the field mappings.
 	Mode string `mapstructure:"mode"`
@@ -177,6 +221,8 @@ const (
 var (
 	errConfigNoEndpoint    = errors.New("endpoints or cloudid must be specified")
 	errConfigEmptyEndpoint = errors.New("endpoints must not include empty entries")
+
+	errConfigDataStreamTimeSuffix = errors.New("index_time_suffix can not be used in data stream mode")
 )
 
 func (m MappingMode) String() string {
@@ -226,5 +272,23 @@ func (cfg *Config) Validate() error {
 		return fmt.Errorf("unknown mapping mode %v", cfg.Mapping.Mode)
 	}
 
+	for _, index := range []string{cfg.Index, cfg.LogsIndex, cfg.TracesIndex, cfg.MetricsIndex} {
+		if _, err := parseIndexTemplate(index); err != nil {
+			return fmt.Errorf("invalid index %q: %w", index, err)
+		}
+	}
+
+	if cfg.DataStream.Enabled {
+		if cfg.IndexTimeSuffix != "" {
+			return errConfigDataStreamTimeSuffix
+		}
+		if cfg.DataStream.Dataset == "" || sanitizeIndexName(cfg.DataStream.Dataset, true) != cfg.DataStream.Dataset {
+			return fmt.Errorf("invalid data_stream.dataset %q", cfg.DataStream.Dataset)
+		}
+		if cfg.DataStream.Namespace == "" || sanitizeIndexName(cfg.DataStream.Namespace, true) != cfg.DataStream.Namespace {
+			return fmt.Errorf("invalid data_stream.namespace %q", cfg.DataStream.Namespace)
+		}
+	}
+
 	return nil
 }
@@ -37,13 +37,21 @@ func TestLoad_DeprecatedIndexConfigOption(t *testing.T) {
 	require.NoError(t, component.UnmarshalExporterConfig(sub, cfg))
 
 	assert.Equal(t, cfg, &Config{
-		ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
-		Endpoints:        []string{"http://localhost:9200"},
-		CloudID:          "TRNMxjXlNJEt",
-		Index:            "my_log_index",
-		LogsIndex:        "logs-generic-default",
-		TracesIndex:      "traces-generic-default",
-		Pipeline:         "mypipeline",
+		ExporterSettings:     config.NewExporterSettings(component.NewID(typeStr)),
+		Endpoints:            []string{"http://localhost:9200"},
+		CloudID:              "TRNMxjXlNJEt",
+		Index:                "my_log_index",
+		LogsIndex:            "logs-generic-default",
+		LogsIndexFallback:    "logs-generic-default",
+		TracesIndex:          "traces-generic-default",
+		TracesIndexFallback:  "traces-generic-default",
+		MetricsIndex:         "metrics-generic-default",
+		MetricsIndexFallback: "metrics-generic-default",
+		Pipeline:             "mypipeline",
+		DataStream: DataStreamSettings{
+			Dataset:   "generic",
+			Namespace: "default",
+		},
 		HTTPClientSettings: HTTPClientSettings{
 			Authentication: AuthenticationSettings{
 				User:     "elastic",
@@ -95,13 +103,21 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(typeStr, "trace"),
 			expected: &Config{
-				ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
-				Endpoints:        []string{"https://elastic.example.com:9200"},
-				CloudID:          "TRNMxjXlNJEt",
-				Index:            "",
-				LogsIndex:        "logs-generic-default",
-				TracesIndex:      "trace_index",
-				Pipeline:         "mypipeline",
+				ExporterSettings:     config.NewExporterSettings(component.NewID(typeStr)),
+				Endpoints:            []string{"https://elastic.example.com:9200"},
+				CloudID:              "TRNMxjXlNJEt",
+				Index:                "",
+				LogsIndex:            "logs-generic-default",
+				LogsIndexFallback:    "logs-generic-default",
+				TracesIndex:          "trace_index",
+				TracesIndexFallback:  "traces-generic-default",
+				MetricsIndex:         "metrics-generic-default",
+				MetricsIndexFallback: "metrics-generic-default",
+				Pipeline:             "mypipeline",
+				DataStream: DataStreamSettings{
+					Dataset:   "generic",
+					Namespace: "default",
+				},
 				HTTPClientSettings: HTTPClientSettings{
 					Authentication: AuthenticationSettings{
 						User:     "elastic",
@@ -135,13 +151,21 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(typeStr, "log"),
 			expected: &Config{
-				ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
-				Endpoints:        []string{"http://localhost:9200"},
-				CloudID:          "TRNMxjXlNJEt",
-				Index:            "",
-				LogsIndex:        "my_log_index",
-				TracesIndex:      "traces-generic-default",
-				Pipeline:         "mypipeline",
+				ExporterSettings:     config.NewExporterSettings(component.NewID(typeStr)),
+				Endpoints:            []string{"http://localhost:9200"},
+				CloudID:              "TRNMxjXlNJEt",
+				Index:                "",
+				LogsIndex:            "my_log_index",
+				LogsIndexFallback:    "logs-generic-default",
+				TracesIndex:          "traces-generic-default",
+				TracesIndexFallback:  "traces-generic-default",
+				MetricsIndex:         "metrics-generic-default",
+				MetricsIndexFallback: "metrics-generic-default",
+				Pipeline:             "mypipeline",
+				DataStream: DataStreamSettings{
+					Dataset:   "generic",
+					Namespace: "default",
+				},
 				HTTPClientSettings: HTTPClientSettings{
 					Authentication: AuthenticationSettings{
 						User:     "elastic",
@@ -172,6 +196,27 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "routing"),
+			expected: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"https://elastic.example.com:9200"}
+				cfg.LogsIndex = "logs-{resource.service.name}-{attributes.env}"
+				cfg.LogsIndexFallback = "logs-unknown"
+				cfg.MetricsIndex = "metrics-{resource.team}"
+				cfg.IndexTimeSuffix = "2006.01.02"
+			}),
+		},
+		{
+			id: component.NewIDWithName(typeStr, "datastream"),
+			expected: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"https://elastic.example.com:9200"}
+				cfg.DataStream = DataStreamSettings{
+					Enabled:   true,
+					Dataset:   "otel",
+					Namespace: "production",
+				}
+			}),
+		},
 	}
 
 	for _, tt := range tests {
@@ -196,3 +241,44 @@ func withDefaultConfig(fns ...func(*Config)) *Config {
 	}
 	return cfg
 }
+
+func TestConfig_Validate(t *testing.T) {
+	tests := map[string]struct {
+		config *Config
+		err    string
+	}{
+		"unknown placeholder": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.LogsIndex = "logs-{service.name}"
+			}),
+			err: `invalid index "logs-{service.name}": unknown placeholder {service.name}`,
+		},
+		"unclosed placeholder": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.TracesIndex = "traces-{resource.service.name"
+			}),
+			err: errUnclosedPlaceholder.Error(),
+		},
+		"data stream with time suffix": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.DataStream.Enabled = true
+				cfg.IndexTimeSuffix = "2006.01.02"
+			}),
+			err: errConfigDataStreamTimeSuffix.Error(),
+		},
+		"invalid data stream dataset": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.DataStream.Enabled = true
+				cfg.DataStream.Dataset = "my-dataset"
+			}),
+			err: `invalid data_stream.dataset "my-dataset"`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tt.config.Endpoints = []string{"http://localhost:9200"}
+			assert.ErrorContains(t, tt.config.Validate(), tt.err)
+		})
+	}
+}
@@ -26,11 +26,13 @@ import (
 
 const (
 	// The value of "type" key in configuration.
-	typeStr            = "elasticsearch"
-	defaultLogsIndex   = "logs-generic-default"
-	defaultTracesIndex = "traces-generic-default"
+	typeStr             = "elasticsearch"
+	defaultLogsIndex    = "logs-generic-default"
+	defaultTracesIndex  = "traces-generic-default"
+	defaultMetricsIndex = "metrics-generic-default"
 	// The stability level of the exporter.
-	stability = component.StabilityLevelBeta
+	stability        = component.StabilityLevelBeta
+	metricsStability = component.StabilityLevelDevelopment
 )
 
 // NewFactory creates a factory for Elastic exporter.
@@ -40,6 +42,7 @@ func NewFactory() component.ExporterFactory {
 		createDefaultConfig,
 		component.WithLogsExporter(createLogsExporter, stability),
 		component.WithTracesExporter(createTracesExporter, stability),
+		component.WithMetricsExporter(createMetricsExporter, metricsStability),
 	)
 }
 
@@ -49,9 +52,17 @@ func createDefaultConfig() component.ExporterConfig {
 		HTTPClientSettings: HTTPClientSettings{
 			Timeout: 90 * time.Second,
 		},
-		Index:       "",
-		LogsIndex:   defaultLogsIndex,
-		TracesIndex: defaultTracesIndex,
+		Index:                "",
+		LogsIndex:            defaultLogsIndex,
+		LogsIndexFallback:    defaultLogsIndex,
+		TracesIndex:          defaultTracesIndex,
+		TracesIndexFallback:  defaultTracesIndex,
+		MetricsIndex:         defaultMetricsIndex,
+		MetricsIndexFallback: defaultMetricsIndex,
+		DataStream: DataStreamSettings{
+			Dataset:   "generic",
+			Namespace: "default",
+		},
 		Retry: RetrySettings{
 			Enabled:         true,
 			MaxRequests:     3,
@@ -103,3 +114,15 @@ func createTracesExporter(ctx context.Context,
 	return exporterhelper.NewTracesExporter(ctx, set, cfg, exporter.pushTraceData,
 		exporterhelper.WithShutdown(exporter.Shutdown))
 }
+
+func createMetricsExporter(ctx context.Context,
+	set component.ExporterCreateSettings,
+	cfg component.ExporterConfig) (component.MetricsExporter, error) {
+
+	exporter, err := newMetricsExporter(set.Logger, cfg.(*Config))
+	if err != nil {
+		return nil, fmt.Errorf("cannot configure Elasticsearch metrics exporter: %w", err)
+	}
+	return exporterhelper.NewMetricsExporter(ctx, set, cfg, exporter.pushMetricsData,
+		exporterhelper.WithShutdown(exporter.Shutdown))
+}
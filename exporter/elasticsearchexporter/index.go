@@ -0,0 +1,202 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	resourcePlaceholderPrefix   = "resource."
+	attributesPlaceholderPrefix = "attributes."
+
+	dataStreamTypeField      = "data_stream.type"
+	dataStreamDatasetField   = "data_stream.dataset"
+	dataStreamNamespaceField = "data_stream.namespace"
+)
+
+// dataStreamTypes are the data stream types accepted from the data_stream.type attribute.
+var dataStreamTypes = map[string]struct{}{
+	"logs":       {},
+	"metrics":    {},
+	"traces":     {},
+	"synthetics": {},
+}
+
+var errUnclosedPlaceholder = errors.New("unclosed placeholder")
+
+// indexTemplate is a parsed index name like `logs-{resource.service.name}`.
+// Placeholders are either `{resource.<key>}`, resolved from the resource attributes,
+// or `{attributes.<key>}`, resolved from the attributes of the log record, span or data point.
+type indexTemplate struct {
+	literals     []string
+	placeholders []string
+}
+
+func parseIndexTemplate(template string) (indexTemplate, error) {
+	var t indexTemplate
+	for {
+		start := strings.IndexByte(template, '{')
+		if start < 0 {
+			t.literals = append(t.literals, template)
+			return t, nil
+		}
+		end := strings.IndexByte(template[start:], '}')
+		if end < 0 {
+			return t, errUnclosedPlaceholder
+		}
+		placeholder := template[start+1 : start+end]
+		if !strings.HasPrefix(placeholder, resourcePlaceholderPrefix) && !strings.HasPrefix(placeholder, attributesPlaceholderPrefix) {
+			return t, fmt.Errorf("unknown placeholder {%s}, must start with %q or %q", placeholder, resourcePlaceholderPrefix, attributesPlaceholderPrefix)
+		}
+		t.literals = append(t.literals, template[:start])
+		t.placeholders = append(t.placeholders, placeholder)
+		template = template[start+end+1:]
+	}
+}
+
+// render returns the index name, and false if a placeholder could not be resolved.
+func (t indexTemplate) render(resource, attributes pcommon.Map) (string, bool) {
+	if len(t.placeholders) == 0 {
+		return t.literals[0], true
+	}
+	var sb strings.Builder
+	for i, placeholder := range t.placeholders {
+		sb.WriteString(t.literals[i])
+		var value pcommon.Value
+		var found bool
+		if key, ok := cutPrefix(placeholder, resourcePlaceholderPrefix); ok {
+			value, found = resource.Get(key)
+		} else if key, ok := cutPrefix(placeholder, attributesPlaceholderPrefix); ok {
+			value, found = attributes.Get(key)
+		}
+		if !found || value.AsString() == "" {
+			return "", false
+		}
+		sb.WriteString(sanitizeIndexName(value.AsString(), false))
+	}
+	sb.WriteString(t.literals[len(t.literals)-1])
+	return sb.String(), true
+}
+
+func cutPrefix(s, prefix string) (string, bool) {
+	if !strings.HasPrefix(s, prefix) {
+		return s, false
+	}
+	return s[len(prefix):], true
+}
+
+// sanitizeIndexName lower cases the value and replaces the characters Elasticsearch does not allow in
+// index names. Dashes are also replaced in data stream mode, as they separate the type, dataset and namespace.
+func sanitizeIndexName(value string, replaceDash bool) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '\\', '/', '*', '?', '"', '<', '>', '|', ' ', ',', '#', ':':
+			return '_'
+		case '-':
+			if replaceDash {
+				return '_'
+			}
+		}
+		return r
+	}, strings.ToLower(value))
+}
+
+// dataStream holds the data stream fields added to documents in data stream mode.
+type dataStream struct {
+	typ       string
+	dataset   string
+	namespace string
+}
+
+func (ds dataStream) enabled() bool {
+	return ds.typ != ""
+}
+
+// indexRouter computes the index of each document.
+type indexRouter struct {
+	template   indexTemplate
+	fallback   string
+	timeSuffix string
+
+	dataStream        bool
+	dataStreamType    string
+	dataStreamDefault DataStreamSettings
+}
+
+func newIndexRouter(cfg *Config, index, fallback, dataStreamType string) (*indexRouter, error) {
+	template, err := parseIndexTemplate(index)
+	if err != nil {
+		return nil, fmt.Errorf("invalid index %q: %w", index, err)
+	}
+	return &indexRouter{
+		template:          template,
+		fallback:          fallback,
+		timeSuffix:        cfg.IndexTimeSuffix,
+		dataStream:        cfg.DataStream.Enabled,
+		dataStreamType:    dataStreamType,
+		dataStreamDefault: cfg.DataStream,
+	}, nil
+}
+
+// route returns the index of a document, and the data stream fields to add to it in data stream mode.
+// The attributes of the log record, span or data point take precedence over the resource attributes
+// for the data stream fields. The type defaults to the signal of the document, and is only read
+// from the attributes when it is one of the data stream types.
+func (r *indexRouter) route(resource, attributes pcommon.Map, ts pcommon.Timestamp) (string, dataStream) {
+	if r.dataStream {
+		ds := dataStream{
+			typ:       lookupDataStreamType(resource, attributes, r.dataStreamType),
+			dataset:   lookupDataStreamField(dataStreamDatasetField, resource, attributes, r.dataStreamDefault.Dataset),
+			namespace: lookupDataStreamField(dataStreamNamespaceField, resource, attributes, r.dataStreamDefault.Namespace),
+		}
+		return fmt.Sprintf("%s-%s-%s", ds.typ, ds.dataset, ds.namespace), ds
+	}
+
+	index, ok := r.template.render(resource, attributes)
+	if !ok {
+		index = r.fallback
+	}
+	if r.timeSuffix != "" {
+		t := time.Now()
+		if ts != 0 {
+			t = ts.AsTime()
+		}
+		index += "-" + t.UTC().Format(r.timeSuffix)
+	}
+	return index, dataStream{}
+}
+
+func lookupDataStreamType(resource, attributes pcommon.Map, defaultValue string) string {
+	typ := lookupDataStreamField(dataStreamTypeField, resource, attributes, defaultValue)
+	if _, ok := dataStreamTypes[typ]; !ok {
+		return defaultValue
+	}
+	return typ
+}
+
+func lookupDataStreamField(key string, resource, attributes pcommon.Map, defaultValue string) string {
+	for _, m := range []pcommon.Map{attributes, resource} {
+		if v, ok := m.Get(key); ok && v.AsString() != "" {
+			return sanitizeIndexName(v.AsString(), true)
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestIndexRouter_Route(t *testing.T) {
+	ts := pcommon.NewTimestampFromTime(time.Date(2022, 11, 24, 23, 30, 0, 0, time.UTC))
+
+	tests := map[string]struct {
+		configure  func(*Config)
+		index      string
+		resource   map[string]interface{}
+		attributes map[string]interface{}
+		want       string
+		wantDS     dataStream
+	}{
+		"static index": {
+			index: "logs-generic-default",
+			want:  "logs-generic-default",
+		},
+		"template": {
+			index:      "logs-{resource.service.name}-{attributes.env}",
+			resource:   map[string]interface{}{"service.name": "Checkout API"},
+			attributes: map[string]interface{}{"env": "prod"},
+			want:       "logs-checkout_api-prod",
+		},
+		"non-string attribute": {
+			index:      "logs-{attributes.team_id}",
+			attributes: map[string]interface{}{"team_id": 42},
+			want:       "logs-42",
+		},
+		"fallback on missing attribute": {
+			index:    "logs-{resource.service.name}-{attributes.env}",
+			resource: map[string]interface{}{"service.name": "checkout"},
+			want:     "logs-fallback",
+		},
+		"time suffix from record timestamp": {
+			configure: func(cfg *Config) { cfg.IndexTimeSuffix = "2006.01.02" },
+			index:     "logs-{resource.service.name}",
+			resource:  map[string]interface{}{"service.name": "checkout"},
+			want:      "logs-checkout-2022.11.24",
+		},
+		"time suffix on fallback": {
+			configure: func(cfg *Config) { cfg.IndexTimeSuffix = "2006.01" },
+			index:     "logs-{resource.service.name}",
+			want:      "logs-fallback-2022.11",
+		},
+		"data stream defaults": {
+			configure: func(cfg *Config) { cfg.DataStream.Enabled = true },
+			index:     "ignored-{resource.service.name}",
+			want:      "logs-generic-default",
+			wantDS:    dataStream{typ: "logs", dataset: "generic", namespace: "default"},
+		},
+		"data stream from attributes": {
+			configure:  func(cfg *Config) { cfg.DataStream.Enabled = true },
+			resource:   map[string]interface{}{"data_stream.dataset": "nginx.access", "data_stream.namespace": "team-a"},
+			attributes: map[string]interface{}{"data_stream.namespace": "Team-B"},
+			want:       "logs-nginx.access-team_b",
+			wantDS:     dataStream{typ: "logs", dataset: "nginx.access", namespace: "team_b"},
+		},
+		"data stream type from attributes": {
+			configure:  func(cfg *Config) { cfg.DataStream.Enabled = true },
+			resource:   map[string]interface{}{"data_stream.type": "metrics"},
+			attributes: map[string]interface{}{"data_stream.type": "synthetics"},
+			want:       "synthetics-generic-default",
+			wantDS:     dataStream{typ: "synthetics", dataset: "generic", namespace: "default"},
+		},
+		"unknown data stream type": {
+			configure:  func(cfg *Config) { cfg.DataStream.Enabled = true },
+			attributes: map[string]interface{}{"data_stream.type": "secrets"},
+			want:       "logs-generic-default",
+			wantDS:     dataStream{typ: "logs", dataset: "generic", namespace: "default"},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := withDefaultConfig()
+			if tt.configure != nil {
+				tt.configure(cfg)
+			}
+			router, err := newIndexRouter(cfg, tt.index, "logs-fallback", "logs")
+			require.NoError(t, err)
+
+			resource := pcommon.NewMap()
+			require.NoError(t, resource.FromRaw(tt.resource))
+			attributes := pcommon.NewMap()
+			require.NoError(t, attributes.FromRaw(tt.attributes))
+
+			index, ds := router.route(resource, attributes, ts)
+			assert.Equal(t, tt.want, index)
+			assert.Equal(t, tt.wantDS, ds)
+		})
+	}
+}
+
+func TestParseIndexTemplate(t *testing.T) {
+	template, err := parseIndexTemplate("a-{resource.x}-{attributes.y}")
+	require.NoError(t, err)
+	assert.Equal(t, indexTemplate{
+		literals:     []string{"a-", "-", ""},
+		placeholders: []string{"resource.x", "attributes.y"},
+	}, template)
+
+	_, err = parseIndexTemplate("a-{x}")
+	assert.ErrorContains(t, err, "unknown placeholder {x}")
+
+	_, err = parseIndexTemplate("a-{resource.x")
+	assert.ErrorIs(t, err, errUnclosedPlaceholder)
+}
@@ -29,7 +29,7 @@ import (
 type elasticsearchLogsExporter struct {
 	logger *zap.Logger
 
-	index       string
+	router      *indexRouter
 	maxAttempts int
 
 	client      *esClientCurrent
@@ -68,11 +68,15 @@ func newLogsExporter(logger *zap.Logger, cfg *Config) (*elasticsearchLogsExporte
 	if cfg.Index != "" {
 		indexStr = cfg.Index
 	}
+	router, err := newIndexRouter(cfg, indexStr, cfg.LogsIndexFallback, "logs")
+	if err != nil {
+		return nil, err
+	}
 	esLogsExp := &elasticsearchLogsExporter{
 		logger:      logger,
 		client:      client,
 		bulkIndexer: bulkIndexer,
-		index:       indexStr,
+		router:      router,
 		maxAttempts: maxAttempts,
 		model:       model,
 	}
@@ -109,9 +113,14 @@ func (e *elasticsearchLogsExporter) pushLogsData(ctx context.Context, ld plog.Lo
 }
 
 func (e *elasticsearchLogsExporter) pushLogRecord(ctx context.Context, resource pcommon.Resource, record plog.LogRecord) error {
-	document, err := e.model.encodeLog(resource, record)
+	ts := record.Timestamp()
+	if ts == 0 {
+		ts = record.ObservedTimestamp()
+	}
+	index, ds := e.router.route(resource.Attributes(), record.Attributes(), ts)
+	document, err := e.model.encodeLog(resource, record, ds)
 	if err != nil {
 		return fmt.Errorf("Failed to encode log event: %w", err)
 	}
-	return pushDocuments(ctx, e.logger, e.index, document, e.bulkIndexer, e.maxAttempts)
+	return pushDocuments(ctx, e.logger, index, document, e.bulkIndexer, e.maxAttempts)
 }
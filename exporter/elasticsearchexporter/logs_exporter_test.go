@@ -26,6 +26,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
@@ -42,7 +43,8 @@ func TestExporter_New(t *testing.T) {
 		return func(t *testing.T, exporter *elasticsearchLogsExporter, err error) {
 			require.Nil(t, err)
 			require.NotNil(t, exporter)
-			require.EqualValues(t, index, exporter.index)
+			got, _ := exporter.router.route(pcommon.NewMap(), pcommon.NewMap(), 0)
+			require.EqualValues(t, index, got)
 		}
 	}
 
@@ -348,6 +350,7 @@ func withTestExporterConfig(fns ...func(*Config)) func(string) *Config {
 }
 
 func mustSend(t *testing.T, exporter *elasticsearchLogsExporter, contents string) {
-	err := pushDocuments(context.TODO(), zap.L(), exporter.index, []byte(contents), exporter.bulkIndexer, exporter.maxAttempts)
+	index, _ := exporter.router.route(pcommon.NewMap(), pcommon.NewMap(), 0)
+	err := pushDocuments(context.TODO(), zap.L(), index, []byte(contents), exporter.bulkIndexer, exporter.maxAttempts)
 	require.NoError(t, err)
 }
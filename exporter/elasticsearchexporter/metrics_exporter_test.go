@@ -0,0 +1,163 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"context"
+	"encoding/json"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestExporter_PushMetricsData(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on Windows, see https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/14759")
+	}
+	t.Run("publish with templated index", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestMetricsExporter(t, server.URL, func(cfg *Config) {
+			cfg.MetricsIndex = "metrics-{resource.service.name}"
+			cfg.IndexTimeSuffix = "2006.01.02"
+		})
+		require.NoError(t, exporter.pushMetricsData(context.TODO(), testMetrics()))
+
+		rec.WaitItems(5)
+		indices := map[string]int{}
+		docs := map[string]map[string]interface{}{}
+		for _, item := range rec.Items() {
+			indices[actionIndex(t, item)]++
+			var doc map[string]interface{}
+			require.NoError(t, json.Unmarshal(item.Document, &doc))
+			docs[doc["Name"].(string)] = doc
+		}
+		assert.Equal(t, map[string]int{"metrics-checkout-2022.11.24": 5}, indices)
+
+		assert.Equal(t, 42.0, docs["requests"]["Value"])
+		assert.Equal(t, "Cumulative", docs["requests"]["AggregationTemporality"])
+		assert.Equal(t, true, docs["requests"]["IsMonotonic"])
+		assert.Equal(t, "GET", docs["requests"]["Attributes.method"])
+		assert.Equal(t, "checkout", docs["requests"]["Resource.service.name"])
+		assert.Equal(t, 0.5, docs["load"]["Value"])
+		assert.Equal(t, []interface{}{1.0, 2.0, 3.0}, docs["latency"]["BucketCounts"])
+		assert.Equal(t, []interface{}{10.0, 100.0}, docs["latency"]["ExplicitBounds"])
+		assert.Equal(t, 3.0, docs["sizes"]["Scale"])
+		assert.Equal(t, []interface{}{4.0, 5.0}, docs["sizes"]["Positive.BucketCounts"])
+		assert.Equal(t, []interface{}{0.5, 0.99}, docs["durations"]["QuantileValues.Quantile"])
+	})
+
+	t.Run("publish to data stream", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestMetricsExporter(t, server.URL, func(cfg *Config) {
+			cfg.DataStream.Enabled = true
+		})
+		metrics := testMetrics()
+		metrics.ResourceMetrics().At(0).Resource().Attributes().PutStr("data_stream.dataset", "checkout")
+		require.NoError(t, exporter.pushMetricsData(context.TODO(), metrics))
+
+		rec.WaitItems(5)
+		for _, item := range rec.Items() {
+			assert.Equal(t, "metrics-checkout-default", actionIndex(t, item))
+			var doc map[string]interface{}
+			require.NoError(t, json.Unmarshal(item.Document, &doc))
+			assert.Equal(t, "metrics", doc["data_stream.type"])
+			assert.Equal(t, "checkout", doc["data_stream.dataset"])
+			assert.Equal(t, "default", doc["data_stream.namespace"])
+		}
+	})
+}
+
+func actionIndex(t *testing.T, item itemRequest) string {
+	var action map[string]struct {
+		Index string `json:"_index"`
+	}
+	require.NoError(t, json.Unmarshal(item.Action, &action))
+	return action["create"].Index
+}
+
+func testMetrics() pmetric.Metrics {
+	ts := pcommon.NewTimestampFromTime(time.Date(2022, 11, 24, 12, 0, 0, 0, time.UTC))
+	metrics := pmetric.NewMetrics()
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+	m := ms.AppendEmpty()
+	m.SetName("requests")
+	sum := m.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sum.SetIsMonotonic(true)
+	dp := sum.DataPoints().AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(42)
+	dp.Attributes().PutStr("method", "GET")
+
+	m = ms.AppendEmpty()
+	m.SetName("load")
+	dp = m.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(0.5)
+
+	m = ms.AppendEmpty()
+	m.SetName("latency")
+	hdp := m.SetEmptyHistogram().DataPoints().AppendEmpty()
+	hdp.SetTimestamp(ts)
+	hdp.SetCount(6)
+	hdp.BucketCounts().FromRaw([]uint64{1, 2, 3})
+	hdp.ExplicitBounds().FromRaw([]float64{10, 100})
+
+	m = ms.AppendEmpty()
+	m.SetName("sizes")
+	edp := m.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	edp.SetTimestamp(ts)
+	edp.SetScale(3)
+	edp.Positive().BucketCounts().FromRaw([]uint64{4, 5})
+
+	m = ms.AppendEmpty()
+	m.SetName("durations")
+	sdp := m.SetEmptySummary().DataPoints().AppendEmpty()
+	sdp.SetTimestamp(ts)
+	q := sdp.QuantileValues().AppendEmpty()
+	q.SetQuantile(0.5)
+	q = sdp.QuantileValues().AppendEmpty()
+	q.SetQuantile(0.99)
+	return metrics
+}
+
+func newTestMetricsExporter(t *testing.T, url string, fns ...func(*Config)) *elasticsearchMetricsExporter {
+	exporter, err := newMetricsExporter(zaptest.NewLogger(t), withTestTracesExporterConfig(fns...)(url))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, exporter.Shutdown(context.TODO()))
+	})
+	return exporter
+}
@@ -20,6 +20,7 @@ import (
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter/internal/objmodel"
@@ -27,8 +28,16 @@ import (
 )
 
 type mappingModel interface {
-	encodeLog(pcommon.Resource, plog.LogRecord) ([]byte, error)
-	encodeSpan(pcommon.Resource, ptrace.Span) ([]byte, error)
+	encodeLog(pcommon.Resource, plog.LogRecord, dataStream) ([]byte, error)
+	encodeSpan(pcommon.Resource, ptrace.Span, dataStream) ([]byte, error)
+	encodeDataPoint(pcommon.Resource, pmetric.Metric, dataPoint, dataStream) ([]byte, error)
+}
+
+// dataPoint is implemented by the data points of all metric types.
+type dataPoint interface {
+	Attributes() pcommon.Map
+	StartTimestamp() pcommon.Timestamp
+	Timestamp() pcommon.Timestamp
 }
 
 // encodeModel tries to keep the event as close to the original open telemetry semantics as is.
@@ -48,7 +57,7 @@ const (
 	attributeField = "attribute"
 )
 
-func (m *encodeModel) encodeLog(resource pcommon.Resource, record plog.LogRecord) ([]byte, error) {
+func (m *encodeModel) encodeLog(resource pcommon.Resource, record plog.LogRecord, ds dataStream) ([]byte, error) {
 	var document objmodel.Document
 	document.AddTimestamp("@timestamp", record.Timestamp()) // We use @timestamp in order to ensure that we can index if the default data stream logs template is used.
 	document.AddTraceID("TraceId", record.TraceID())
@@ -60,18 +69,10 @@ func (m *encodeModel) encodeLog(resource pcommon.Resource, record plog.LogRecord
 	document.AddAttributes("Attributes", record.Attributes())
 	document.AddAttributes("Resource", resource.Attributes())
 
-	if m.dedup {
-		document.Dedup()
-	} else if m.dedot {
-		document.Sort()
-	}
-
-	var buf bytes.Buffer
-	err := document.Serialize(&buf, m.dedot)
-	return buf.Bytes(), err
+	return m.serialize(document, ds)
 }
 
-func (m *encodeModel) encodeSpan(resource pcommon.Resource, span ptrace.Span) ([]byte, error) {
+func (m *encodeModel) encodeSpan(resource pcommon.Resource, span ptrace.Span, ds dataStream) ([]byte, error) {
 	var document objmodel.Document
 	document.AddTimestamp("@timestamp", span.StartTimestamp()) // We use @timestamp in order to ensure that we can index if the default data stream logs template is used.
 	document.AddTimestamp("EndTimestamp", span.EndTimestamp())
@@ -85,6 +86,88 @@ func (m *encodeModel) encodeSpan(resource pcommon.Resource, span ptrace.Span) ([
 	document.AddAttributes("Attributes", span.Attributes())
 	document.AddAttributes("Resource", resource.Attributes())
 
+	return m.serialize(document, ds)
+}
+
+func (m *encodeModel) encodeDataPoint(resource pcommon.Resource, metric pmetric.Metric, dp dataPoint, ds dataStream) ([]byte, error) {
+	var document objmodel.Document
+	document.AddTimestamp("@timestamp", dp.Timestamp()) // We use @timestamp in order to ensure that we can index if the default data stream metrics template is used.
+	document.AddTimestamp("StartTimestamp", dp.StartTimestamp())
+	document.AddString("Name", metric.Name())
+	document.AddString("Description", metric.Description())
+	document.AddString("Unit", metric.Unit())
+	document.AddString("Type", metric.Type().String())
+
+	switch dp := dp.(type) {
+	case pmetric.NumberDataPoint:
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			document.AddInt("Value", dp.IntValue())
+		case pmetric.NumberDataPointValueTypeDouble:
+			document.Add("Value", objmodel.DoubleValue(dp.DoubleValue()))
+		}
+		if metric.Type() == pmetric.MetricTypeSum {
+			document.AddString("AggregationTemporality", metric.Sum().AggregationTemporality().String())
+			document.Add("IsMonotonic", objmodel.BoolValue(metric.Sum().IsMonotonic()))
+		}
+	case pmetric.HistogramDataPoint:
+		document.AddInt("Count", int64(dp.Count()))
+		if dp.HasSum() {
+			document.Add("Sum", objmodel.DoubleValue(dp.Sum()))
+		}
+		if dp.HasMin() {
+			document.Add("Min", objmodel.DoubleValue(dp.Min()))
+		}
+		if dp.HasMax() {
+			document.Add("Max", objmodel.DoubleValue(dp.Max()))
+		}
+		document.Add("BucketCounts", uintsValue(dp.BucketCounts()))
+		document.Add("ExplicitBounds", doublesValue(dp.ExplicitBounds()))
+		document.AddString("AggregationTemporality", metric.Histogram().AggregationTemporality().String())
+	case pmetric.ExponentialHistogramDataPoint:
+		document.AddInt("Count", int64(dp.Count()))
+		if dp.HasSum() {
+			document.Add("Sum", objmodel.DoubleValue(dp.Sum()))
+		}
+		if dp.HasMin() {
+			document.Add("Min", objmodel.DoubleValue(dp.Min()))
+		}
+		if dp.HasMax() {
+			document.Add("Max", objmodel.DoubleValue(dp.Max()))
+		}
+		document.AddInt("Scale", int64(dp.Scale()))
+		document.AddInt("ZeroCount", int64(dp.ZeroCount()))
+		document.AddInt("Positive.Offset", int64(dp.Positive().Offset()))
+		document.Add("Positive.BucketCounts", uintsValue(dp.Positive().BucketCounts()))
+		document.AddInt("Negative.Offset", int64(dp.Negative().Offset()))
+		document.Add("Negative.BucketCounts", uintsValue(dp.Negative().BucketCounts()))
+		document.AddString("AggregationTemporality", metric.ExponentialHistogram().AggregationTemporality().String())
+	case pmetric.SummaryDataPoint:
+		document.AddInt("Count", int64(dp.Count()))
+		document.Add("Sum", objmodel.DoubleValue(dp.Sum()))
+		quantiles := make([]objmodel.Value, 0, dp.QuantileValues().Len())
+		values := make([]objmodel.Value, 0, dp.QuantileValues().Len())
+		for i := 0; i < dp.QuantileValues().Len(); i++ {
+			qv := dp.QuantileValues().At(i)
+			quantiles = append(quantiles, objmodel.DoubleValue(qv.Quantile()))
+			values = append(values, objmodel.DoubleValue(qv.Value()))
+		}
+		document.Add("QuantileValues.Quantile", objmodel.ArrValue(quantiles...))
+		document.Add("QuantileValues.Value", objmodel.ArrValue(values...))
+	}
+	document.AddAttributes("Attributes", dp.Attributes())
+	document.AddAttributes("Resource", resource.Attributes())
+
+	return m.serialize(document, ds)
+}
+
+func (m *encodeModel) serialize(document objmodel.Document, ds dataStream) ([]byte, error) {
+	if ds.enabled() {
+		document.AddString(dataStreamTypeField, ds.typ)
+		document.AddString(dataStreamDatasetField, ds.dataset)
+		document.AddString(dataStreamNamespaceField, ds.namespace)
+	}
+
 	if m.dedup {
 		document.Dedup()
 	} else if m.dedot {
@@ -96,6 +179,22 @@ func (m *encodeModel) encodeSpan(resource pcommon.Resource, span ptrace.Span) ([
 	return buf.Bytes(), err
 }
 
+func uintsValue(s pcommon.UInt64Slice) objmodel.Value {
+	values := make([]objmodel.Value, 0, s.Len())
+	for i := 0; i < s.Len(); i++ {
+		values = append(values, objmodel.IntValue(int64(s.At(i))))
+	}
+	return objmodel.ArrValue(values...)
+}
+
+func doublesValue(s pcommon.Float64Slice) objmodel.Value {
+	values := make([]objmodel.Value, 0, s.Len())
+	for i := 0; i < s.Len(); i++ {
+		values = append(values, objmodel.DoubleValue(s.At(i)))
+	}
+	return objmodel.ArrValue(values...)
+}
+
 func spanLinksToString(spanLinkSlice ptrace.SpanLinkSlice) string {
 	linkArray := make([]map[string]interface{}, 0, spanLinkSlice.Len())
 	for i := 0; i < spanLinkSlice.Len(); i++ {
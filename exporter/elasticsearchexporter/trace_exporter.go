@@ -29,7 +29,7 @@ import (
 type elasticsearchTracesExporter struct {
 	logger *zap.Logger
 
-	index       string
+	router      *indexRouter
 	maxAttempts int
 
 	client      *esClientCurrent
@@ -60,12 +60,17 @@ func newTracesExporter(logger *zap.Logger, cfg *Config) (*elasticsearchTracesExp
 	// TODO: Apply encoding and field mapping settings.
 	model := &encodeModel{dedup: true, dedot: false}
 
+	router, err := newIndexRouter(cfg, cfg.TracesIndex, cfg.TracesIndexFallback, "traces")
+	if err != nil {
+		return nil, err
+	}
+
 	return &elasticsearchTracesExporter{
 		logger:      logger,
 		client:      client,
 		bulkIndexer: bulkIndexer,
 
-		index:       cfg.TracesIndex,
+		router:      router,
 		maxAttempts: maxAttempts,
 		model:       model,
 	}, nil
@@ -102,9 +107,10 @@ func (e *elasticsearchTracesExporter) pushTraceData(
 }
 
 func (e *elasticsearchTracesExporter) pushTraceRecord(ctx context.Context, resource pcommon.Resource, span ptrace.Span) error {
-	document, err := e.model.encodeSpan(resource, span)
+	index, ds := e.router.route(resource.Attributes(), span.Attributes(), span.StartTimestamp())
+	document, err := e.model.encodeSpan(resource, span, ds)
 	if err != nil {
 		return fmt.Errorf("Failed to encode trace record: %w", err)
 	}
-	return pushDocuments(ctx, e.logger, e.index, document, e.bulkIndexer, e.maxAttempts)
+	return pushDocuments(ctx, e.logger, index, document, e.bulkIndexer, e.maxAttempts)
 }
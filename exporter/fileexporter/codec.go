@@ -14,7 +14,13 @@
 
 package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
 
-import "github.com/klauspost/compress/zstd"
+import (
+	"bytes"
+	"compress/gzip"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+)
 
 // compressFunc defines how to compress encoded telemetry data.
 type compressFunc func(src []byte) []byte
@@ -22,7 +28,9 @@ type compressFunc func(src []byte) []byte
 var encoder, _ = zstd.NewWriter(nil)
 
 var encoders = map[string]compressFunc{
-	compressionZSTD: zstdCompress,
+	compressionZSTD:   zstdCompress,
+	compressionGZIP:   gzipCompress,
+	compressionSnappy: snappyCompress,
 }
 
 func buildCompressor(compression string) compressFunc {
@@ -37,6 +45,21 @@ func zstdCompress(src []byte) []byte {
 	return encoder.EncodeAll(src, make([]byte, 0, len(src)))
 }
 
+// gzipCompress compress a buffer with gzip
+func gzipCompress(src []byte) []byte {
+	var buf bytes.Buffer
+	// Writing to a bytes.Buffer never fails.
+	zw := gzip.NewWriter(&buf)
+	_, _ = zw.Write(src)
+	_ = zw.Close()
+	return buf.Bytes()
+}
+
+// snappyCompress compress a buffer with the snappy block format
+func snappyCompress(src []byte) []byte {
+	return snappy.Encode(nil, src)
+}
+
 // noneCompress return src
 func noneCompress(src []byte) []byte {
 	return src
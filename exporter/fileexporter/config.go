@@ -16,6 +16,8 @@ package fileexporter // import "github.com/open-telemetry/opentelemetry-collecto
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -32,6 +34,8 @@ type Config struct {
 	config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 
 	// Path of the file to write to. Path is relative to current directory.
+	// When GroupBy is enabled, it is a template containing {resource.<key>}
+	// placeholders and optionally %Y, %m, %d and %H time directives.
 	Path string `mapstructure:"path"`
 
 	// Rotation defines an option about rotation of telemetry files
@@ -44,8 +48,40 @@ type Config struct {
 	FormatType string `mapstructure:"format"`
 
 	// Compression Codec used to export telemetry data
-	// Supported compression algorithms:`zstd`
+	// Supported compression algorithms: `zstd`, `gzip` and `snappy`
 	Compression string `mapstructure:"compression"`
+
+	// Framing is how the encoded objects are delimited in the files.
+	// Options:
+	// - "" [default]: JSON objects are written one per line, unless compressed,
+	//   and other objects are preceded by their length.
+	// - record: every object is preceded by a record header holding its signal
+	//   and its length, so that the signals of a file can be told apart.
+	Framing string `mapstructure:"framing"`
+
+	// FlushInterval is the interval at which buffered telemetry is flushed to the files.
+	// The default of 0 disables buffering and every batch is written immediately.
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+
+	// GroupBy splits telemetry into one file per value of some resource attributes.
+	GroupBy GroupBy `mapstructure:"group_by"`
+}
+
+// GroupBy configures how telemetry is grouped into files by resource attributes.
+type GroupBy struct {
+	// ResourceAttributes are the resource attributes telemetry is grouped by.
+	// Grouping is enabled when they are set, and Path must then contain a
+	// {resource.<key>} placeholder for each of them, and for none other.
+	ResourceAttributes []string `mapstructure:"resource_attributes"`
+
+	// MaxOpenFiles is the maximum number of files kept open at the same time.
+	// The least recently written file is closed when a new one has to be opened.
+	// It defaults to 100.
+	MaxOpenFiles int `mapstructure:"max_open_files"`
+
+	// MissingValue replaces {resource.<key>} placeholders whose attribute is
+	// missing or empty. It defaults to "unknown".
+	MissingValue string `mapstructure:"missing_value"`
 }
 
 // Rotation an option to rolling log files
@@ -81,12 +117,58 @@ func (cfg *Config) Validate() error {
 	if cfg.FormatType != formatTypeJSON && cfg.FormatType != formatTypeProto {
 		return errors.New("format type is not supported")
 	}
-	if cfg.Compression != "" && cfg.Compression != compressionZSTD {
+	if _, ok := encoders[cfg.Compression]; cfg.Compression != "" && !ok {
 		return errors.New("compression is not supported")
 	}
+	if cfg.Framing != "" && cfg.Framing != framingRecord {
+		return fmt.Errorf("framing %q is not supported", cfg.Framing)
+	}
+	if cfg.FlushInterval < 0 {
+		return errors.New("flush_interval must be non-negative")
+	}
+	if cfg.GroupBy.MaxOpenFiles <= 0 {
+		return errors.New("group_by.max_open_files must be positive")
+	}
+	if cfg.GroupBy.MissingValue == "" {
+		return errors.New("group_by.missing_value must be non-empty")
+	}
+	if cfg.GroupBy.enabled() {
+		if _, err := cfg.GroupBy.pathTemplate(cfg.Path); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// enabled reports whether telemetry is grouped into several files, in which
+// case the path is a template.
+func (g *GroupBy) enabled() bool {
+	return len(g.ResourceAttributes) > 0
+}
+
+// pathTemplate parses the path, which must have a placeholder for each of the
+// grouped resource attributes and for none other.
+func (g *GroupBy) pathTemplate(path string) (*pathTemplate, error) {
+	tmpl, err := parsePathTemplate(path, g.MissingValue)
+	if err != nil {
+		return nil, err
+	}
+	keys := tmpl.resourceKeys()
+	grouped := make(map[string]struct{}, len(g.ResourceAttributes))
+	for _, attr := range g.ResourceAttributes {
+		if _, ok := keys[attr]; !ok {
+			return nil, fmt.Errorf("path must contain a {resource.%s} placeholder for the group_by resource attribute %q", attr, attr)
+		}
+		grouped[attr] = struct{}{}
+	}
+	for _, part := range tmpl.parts {
+		if _, ok := grouped[part.resourceKey]; part.resourceKey != "" && !ok {
+			return nil, fmt.Errorf("path placeholder {resource.%s} is not a group_by resource attribute", part.resourceKey)
+		}
+	}
+	return tmpl, nil
+}
+
 // Unmarshal a confmap.Conf into the config struct.
 func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
 	if componentParser == nil {
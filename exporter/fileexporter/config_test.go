@@ -17,6 +17,7 @@ package fileexporter
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -40,6 +41,7 @@ func TestLoadConfig(t *testing.T) {
 			id: component.NewIDWithName(typeStr, "2"),
 			expected: &Config{
 				ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
+				GroupBy:          defaultGroupBy(),
 				Path:             "./filename.json",
 				Rotation: &Rotation{
 					MaxMegabytes: 10,
@@ -54,6 +56,7 @@ func TestLoadConfig(t *testing.T) {
 			id: component.NewIDWithName(typeStr, "3"),
 			expected: &Config{
 				ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
+				GroupBy:          defaultGroupBy(),
 				Path:             "./filename",
 				Rotation: &Rotation{
 					MaxMegabytes: 10,
@@ -63,12 +66,14 @@ func TestLoadConfig(t *testing.T) {
 				},
 				FormatType:  formatTypeProto,
 				Compression: compressionZSTD,
+				Framing:     framingRecord,
 			},
 		},
 		{
 			id: component.NewIDWithName(typeStr, "rotation_with_default_settings"),
 			expected: &Config{
 				ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
+				GroupBy:          defaultGroupBy(),
 				Path:             "./foo",
 				FormatType:       formatTypeJSON,
 				Rotation: &Rotation{
@@ -80,6 +85,7 @@ func TestLoadConfig(t *testing.T) {
 			id: component.NewIDWithName(typeStr, "rotation_with_custom_settings"),
 			expected: &Config{
 				ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
+				GroupBy:          defaultGroupBy(),
 				Path:             "./foo",
 				Rotation: &Rotation{
 					MaxMegabytes: 1234,
@@ -88,6 +94,53 @@ func TestLoadConfig(t *testing.T) {
 				FormatType: formatTypeJSON,
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "template"),
+			expected: &Config{
+				ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
+				Path:             "./otel/{resource.service.name}/%Y-%m-%d.json",
+				FormatType:       formatTypeJSON,
+				FlushInterval:    5 * time.Second,
+				GroupBy: GroupBy{
+					ResourceAttributes: []string{"service.name"},
+					MaxOpenFiles:       10,
+					MissingValue:       "other",
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(typeStr, "literal_path"),
+			expected: &Config{
+				ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
+				Path:             "./otel/{resource.service.name}/%Y-%m-%d.json",
+				FormatType:       formatTypeJSON,
+				GroupBy:          defaultGroupBy(),
+			},
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "template_error"),
+			errorMessage: "path contains an unclosed '{' placeholder",
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "missing_placeholder_error"),
+			errorMessage: `path must contain a {resource.host.name} placeholder for the group_by resource attribute "host.name"`,
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "ungrouped_placeholder_error"),
+			errorMessage: "path placeholder {resource.host.name} is not a group_by resource attribute",
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "max_open_files_error"),
+			errorMessage: "group_by.max_open_files must be positive",
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "flush_interval_error"),
+			errorMessage: "flush_interval must be non-negative",
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "framing_error"),
+			errorMessage: `framing "lines" is not supported`,
+		},
 		{
 			id:           component.NewIDWithName(typeStr, "compression_error"),
 			errorMessage: "compression is not supported",
@@ -121,3 +174,10 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func defaultGroupBy() GroupBy {
+	return GroupBy{
+		MaxOpenFiles: defaultMaxOpenFiles,
+		MissingValue: defaultMissingValue,
+	}
+}
@@ -18,9 +18,12 @@ import (
 	"context"
 	"io"
 	"os"
+	"path/filepath"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
 	"gopkg.in/natefinch/lumberjack.v2"
 
@@ -36,12 +39,22 @@ const (
 	// the number of old log files to retain
 	defaultMaxBackups = 100
 
+	// the number of files kept open when the path is a template
+	defaultMaxOpenFiles = 100
+	// the value of resource attribute placeholders without attribute
+	defaultMissingValue = "unknown"
+
 	// the format of encoded telemetry data
 	formatTypeJSON  = "json"
 	formatTypeProto = "proto"
 
 	// the type of compression codec
-	compressionZSTD = "zstd"
+	compressionZSTD   = "zstd"
+	compressionGZIP   = "gzip"
+	compressionSnappy = "snappy"
+
+	// the framing of encoded objects: each object is preceded by a record header
+	framingRecord = "record"
 )
 
 // NewFactory creates a factory for OTLP exporter.
@@ -59,6 +72,10 @@ func createDefaultConfig() component.ExporterConfig {
 		ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
 		FormatType:       formatTypeJSON,
 		Rotation:         &Rotation{MaxBackups: defaultMaxBackups},
+		GroupBy: GroupBy{
+			MaxOpenFiles: defaultMaxOpenFiles,
+			MissingValue: defaultMissingValue,
+		},
 	}
 }
 
@@ -67,27 +84,15 @@ func createTracesExporter(
 	set component.ExporterCreateSettings,
 	cfg component.ExporterConfig,
 ) (component.TracesExporter, error) {
-	conf := cfg.(*Config)
-	writer, err := buildFileWriter(conf)
+	fe, err := getOrCreateExporter(cfg.(*Config), set)
 	if err != nil {
 		return nil, err
 	}
-	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return &fileExporter{
-			path:            conf.Path,
-			formatType:      conf.FormatType,
-			file:            writer,
-			tracesMarshaler: tracesMarshalers[conf.FormatType],
-			exporter:        buildExportFunc(conf),
-			compression:     conf.Compression,
-			compressor:      buildCompressor(conf.Compression),
-		}
-	})
 	return exporterhelper.NewTracesExporter(
 		ctx,
 		set,
 		cfg,
-		fe.Unwrap().(*fileExporter).ConsumeTraces,
+		fe.Unwrap().(consumer.Traces).ConsumeTraces,
 		exporterhelper.WithStart(fe.Start),
 		exporterhelper.WithShutdown(fe.Shutdown),
 	)
@@ -98,27 +103,15 @@ func createMetricsExporter(
 	set component.ExporterCreateSettings,
 	cfg component.ExporterConfig,
 ) (component.MetricsExporter, error) {
-	conf := cfg.(*Config)
-	writer, err := buildFileWriter(conf)
+	fe, err := getOrCreateExporter(cfg.(*Config), set)
 	if err != nil {
 		return nil, err
 	}
-	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return &fileExporter{
-			path:             conf.Path,
-			formatType:       conf.FormatType,
-			file:             writer,
-			metricsMarshaler: metricsMarshalers[conf.FormatType],
-			exporter:         buildExportFunc(conf),
-			compression:      conf.Compression,
-			compressor:       buildCompressor(conf.Compression),
-		}
-	})
 	return exporterhelper.NewMetricsExporter(
 		ctx,
 		set,
 		cfg,
-		fe.Unwrap().(*fileExporter).ConsumeMetrics,
+		fe.Unwrap().(consumer.Metrics).ConsumeMetrics,
 		exporterhelper.WithStart(fe.Start),
 		exporterhelper.WithShutdown(fe.Shutdown),
 	)
@@ -129,43 +122,82 @@ func createLogsExporter(
 	set component.ExporterCreateSettings,
 	cfg component.ExporterConfig,
 ) (component.LogsExporter, error) {
-	conf := cfg.(*Config)
-	writer, err := buildFileWriter(conf)
+	fe, err := getOrCreateExporter(cfg.(*Config), set)
 	if err != nil {
 		return nil, err
 	}
-	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return &fileExporter{
-			path:          conf.Path,
-			formatType:    conf.FormatType,
-			file:          writer,
-			logsMarshaler: logsMarshalers[conf.FormatType],
-			exporter:      buildExportFunc(conf),
-			compression:   conf.Compression,
-			compressor:    buildCompressor(conf.Compression),
-		}
-	})
 	return exporterhelper.NewLogsExporter(
 		ctx,
 		set,
 		cfg,
-		fe.Unwrap().(*fileExporter).ConsumeLogs,
+		fe.Unwrap().(consumer.Logs).ConsumeLogs,
 		exporterhelper.WithStart(fe.Start),
 		exporterhelper.WithShutdown(fe.Shutdown),
 	)
 }
 
+// getOrCreateExporter returns the exporter shared by all the signals of the configuration.
+func getOrCreateExporter(conf *Config, set component.ExporterCreateSettings) (*sharedcomponent.SharedComponent, error) {
+	if conf.GroupBy.enabled() {
+		path, err := conf.GroupBy.pathTemplate(conf.Path)
+		if err != nil {
+			return nil, err
+		}
+		var ge *groupingFileExporter
+		if ge, err = newGroupingFileExporter(conf, path, set.Logger); err != nil {
+			return nil, err
+		}
+		return exporters.GetOrAdd(conf, func() component.Component {
+			return ge
+		}), nil
+	}
+
+	writer, err := buildFileWriter(conf)
+	if err != nil {
+		return nil, err
+	}
+	return exporters.GetOrAdd(conf, func() component.Component {
+		return newFileExporter(conf, writer, set.Logger)
+	}), nil
+}
+
 func buildFileWriter(cfg *Config) (io.WriteCloser, error) {
-	if cfg.Rotation == nil {
-		return os.OpenFile(cfg.Path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	return newFileWriter(cfg.Path, cfg.Rotation, cfg.FlushInterval, false)
+}
+
+// newFileWriter opens the file at path. Files rendered from a path template
+// are appended to, since they may be reopened after being closed.
+func newFileWriter(path string, rotation *Rotation, flushInterval time.Duration, appendOnly bool) (io.WriteCloser, error) {
+	var writer io.WriteCloser
+	switch {
+	case rotation != nil:
+		writer = &lumberjack.Logger{
+			Filename:   path,
+			MaxSize:    rotation.MaxMegabytes,
+			MaxAge:     rotation.MaxDays,
+			MaxBackups: rotation.MaxBackups,
+			LocalTime:  rotation.LocalTime,
+		}
+	case appendOnly:
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return nil, err
+		}
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+		if err != nil {
+			return nil, err
+		}
+		writer = file
+	default:
+		file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			return nil, err
+		}
+		writer = file
+	}
+	if flushInterval > 0 {
+		return newBufferedWriteCloser(writer), nil
 	}
-	return &lumberjack.Logger{
-		Filename:   cfg.Path,
-		MaxSize:    cfg.Rotation.MaxMegabytes,
-		MaxAge:     cfg.Rotation.MaxDays,
-		MaxBackups: cfg.Rotation.MaxBackups,
-		LocalTime:  cfg.Rotation.LocalTime,
-	}, nil
+	return writer, nil
 }
 
 // This is the map of already created File exporters for particular configurations.
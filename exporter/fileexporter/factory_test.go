@@ -18,6 +18,7 @@ import (
 	"context"
 	"io"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -100,6 +101,36 @@ func TestCreateLogsExporter(t *testing.T) {
 	require.NotNil(t, exp)
 }
 
+func TestCreateLogsExporterWithPathTemplate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = filepath.Join(t.TempDir(), "{resource.service.name}.json")
+	cfg.GroupBy.ResourceAttributes = []string{"service.name"}
+	exp, err := createLogsExporter(
+		context.Background(),
+		componenttest.NewNopExporterCreateSettings(),
+		cfg)
+	require.NoError(t, err)
+	require.NotNil(t, exp)
+	fe := exporters.GetOrAdd(cfg, nil)
+	assert.IsType(t, &groupingFileExporter{}, fe.Unwrap())
+	require.NoError(t, exp.Shutdown(context.Background()))
+}
+
+func TestCreateLogsExporterWithLiteralPath(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = filepath.Join(t.TempDir(), "{resource.service.name}-%Y.json")
+	exp, err := createLogsExporter(
+		context.Background(),
+		componenttest.NewNopExporterCreateSettings(),
+		cfg)
+	require.NoError(t, err)
+	require.NotNil(t, exp)
+	fe := exporters.GetOrAdd(cfg, nil)
+	require.IsType(t, &fileExporter{}, fe.Unwrap())
+	assert.Equal(t, cfg.Path, fe.Unwrap().(*fileExporter).path)
+	require.NoError(t, exp.Shutdown(context.Background()))
+}
+
 func TestCreateLogsExporterError(t *testing.T) {
 	cfg := &Config{
 		ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
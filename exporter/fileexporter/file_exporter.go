@@ -15,16 +15,19 @@
 package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
 
 import (
+	"bufio"
 	"context"
 	"encoding/binary"
 	"io"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
 )
 
 // Marshaler configuration used for marhsaling Protobuf
@@ -41,6 +44,17 @@ var logsMarshalers = map[string]plog.Marshaler{
 	formatTypeProto: &plog.ProtoMarshaler{},
 }
 
+// The types of the records, written in the record headers when the records are framed.
+const (
+	recordTypeTraces byte = iota + 1
+	recordTypeMetrics
+	recordTypeLogs
+
+	// a record header is the type of the record followed by its length, as a
+	// 4 bytes big endian unsigned integer
+	recordHeaderSize = 5
+)
+
 // exportFunc defines how to export encoded telemetry data.
 type exportFunc func(e *fileExporter, buf []byte) error
 
@@ -59,6 +73,29 @@ type fileExporter struct {
 
 	formatType string
 	exporter   exportFunc
+	framing    string
+
+	logger        *zap.Logger
+	flushInterval time.Duration
+	stopFlusher   func()
+}
+
+// newFileExporter creates a fileExporter writing any signal to the given writer.
+func newFileExporter(conf *Config, writer io.WriteCloser, logger *zap.Logger) *fileExporter {
+	return &fileExporter{
+		path:             conf.Path,
+		formatType:       conf.FormatType,
+		file:             writer,
+		tracesMarshaler:  tracesMarshalers[conf.FormatType],
+		metricsMarshaler: metricsMarshalers[conf.FormatType],
+		logsMarshaler:    logsMarshalers[conf.FormatType],
+		exporter:         buildExportFunc(conf),
+		framing:          conf.Framing,
+		compression:      conf.Compression,
+		compressor:       buildCompressor(conf.Compression),
+		logger:           logger,
+		flushInterval:    conf.FlushInterval,
+	}
 }
 
 func (e *fileExporter) Capabilities() consumer.Capabilities {
@@ -71,7 +108,7 @@ func (e *fileExporter) ConsumeTraces(_ context.Context, td ptrace.Traces) error
 		return err
 	}
 	buf = e.compressor(buf)
-	return e.exporter(e, buf)
+	return e.export(recordTypeTraces, buf)
 }
 
 func (e *fileExporter) ConsumeMetrics(_ context.Context, md pmetric.Metrics) error {
@@ -80,7 +117,7 @@ func (e *fileExporter) ConsumeMetrics(_ context.Context, md pmetric.Metrics) err
 		return err
 	}
 	buf = e.compressor(buf)
-	return e.exporter(e, buf)
+	return e.export(recordTypeMetrics, buf)
 }
 
 func (e *fileExporter) ConsumeLogs(_ context.Context, ld plog.Logs) error {
@@ -89,6 +126,14 @@ func (e *fileExporter) ConsumeLogs(_ context.Context, ld plog.Logs) error {
 		return err
 	}
 	buf = e.compressor(buf)
+	return e.export(recordTypeLogs, buf)
+}
+
+// export writes an encoded object, preceded by a record header when the records are framed.
+func (e *fileExporter) export(recordType byte, buf []byte) error {
+	if e.framing == framingRecord {
+		return exportMessageAsRecord(e, recordType, buf)
+	}
 	return e.exporter(e, buf)
 }
 
@@ -120,15 +165,93 @@ func exportMessageAsBuffer(e *fileExporter, buf []byte) error {
 	return nil
 }
 
+func exportMessageAsRecord(e *fileExporter, recordType byte, buf []byte) error {
+	// Ensure only one write operation happens at a time.
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	data := make([]byte, recordHeaderSize, recordHeaderSize+len(buf))
+	data[0] = recordType
+	binary.BigEndian.PutUint32(data[1:], uint32(len(buf)))
+	data = append(data, buf...)
+	_, err := e.file.Write(data)
+	return err
+}
+
+// flush writes the buffered telemetry, if any, to the file.
+func (e *fileExporter) flush() error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	if bw, ok := e.file.(*bufferedWriteCloser); ok {
+		return bw.Flush()
+	}
+	return nil
+}
+
 func (e *fileExporter) Start(context.Context, component.Host) error {
+	if e.flushInterval > 0 {
+		e.stopFlusher = startFlusher(e.flushInterval, func() {
+			if err := e.flush(); err != nil {
+				e.logger.Error("Failed to flush telemetry to file", zap.String("path", e.path), zap.Error(err))
+			}
+		})
+	}
 	return nil
 }
 
 // Shutdown stops the exporter and is invoked during shutdown.
 func (e *fileExporter) Shutdown(context.Context) error {
+	if e.stopFlusher != nil {
+		e.stopFlusher()
+	}
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
 	return e.file.Close()
 }
 
+// startFlusher calls flush every interval until the returned function is called.
+func startFlusher(interval time.Duration, flush func()) func() {
+	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			select {
+			case <-ticker.C:
+				flush()
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		ticker.Stop()
+		close(done)
+		<-stopped
+	}
+}
+
+// bufferedWriteCloser buffers the writes to the wrapped file until Flush or Close is called.
+type bufferedWriteCloser struct {
+	*bufio.Writer
+	wrapped io.WriteCloser
+}
+
+func newBufferedWriteCloser(wrapped io.WriteCloser) *bufferedWriteCloser {
+	return &bufferedWriteCloser{
+		Writer:  bufio.NewWriter(wrapped),
+		wrapped: wrapped,
+	}
+}
+
+func (bw *bufferedWriteCloser) Close() error {
+	if err := bw.Flush(); err != nil {
+		_ = bw.wrapped.Close()
+		return err
+	}
+	return bw.wrapped.Close()
+}
+
 func buildExportFunc(cfg *Config) func(e *fileExporter, buf []byte) error {
 	if cfg.FormatType == formatTypeProto {
 		return exportMessageAsBuffer
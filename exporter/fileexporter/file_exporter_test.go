@@ -15,6 +15,8 @@ package fileexporter
 
 import (
 	"bufio"
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/binary"
 	"errors"
@@ -22,7 +24,9 @@ import (
 	"os"
 	"sync"
 	"testing"
+	"time"
 
+	"github.com/golang/snappy"
 	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -30,14 +34,28 @@ import (
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
 	"gopkg.in/natefinch/lumberjack.v2"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
 )
 
 func buildUnCompressor(compressor string) func([]byte) ([]byte, error) {
-	if compressor == compressionZSTD {
+	switch compressor {
+	case compressionZSTD:
 		return decompress
+	case compressionGZIP:
+		return func(src []byte) ([]byte, error) {
+			zr, err := gzip.NewReader(bytes.NewReader(src))
+			if err != nil {
+				return nil, err
+			}
+			return io.ReadAll(zr)
+		}
+	case compressionSnappy:
+		return func(src []byte) ([]byte, error) {
+			return snappy.Decode(nil, src)
+		}
 	}
 	return func(src []byte) ([]byte, error) {
 		return src, nil
@@ -95,6 +113,28 @@ func TestFileTracesExporter(t *testing.T) {
 				unmarshaler: &ptrace.ProtoUnmarshaler{},
 			},
 		},
+		{
+			name: "json: gzip compression configuration",
+			args: args{
+				conf: &Config{
+					Path:        tempFileName(t),
+					FormatType:  "json",
+					Compression: compressionGZIP,
+				},
+				unmarshaler: &ptrace.JSONUnmarshaler{},
+			},
+		},
+		{
+			name: "Proto: snappy compression configuration",
+			args: args{
+				conf: &Config{
+					Path:        tempFileName(t),
+					FormatType:  "proto",
+					Compression: compressionSnappy,
+				},
+				unmarshaler: &ptrace.ProtoUnmarshaler{},
+			},
+		},
 		{
 			name: "Proto: compression configuration--rotation",
 			args: args{
@@ -229,6 +269,28 @@ func TestFileMetricsExporter(t *testing.T) {
 				unmarshaler: &pmetric.ProtoUnmarshaler{},
 			},
 		},
+		{
+			name: "json: gzip compression configuration",
+			args: args{
+				conf: &Config{
+					Path:        tempFileName(t),
+					FormatType:  "json",
+					Compression: compressionGZIP,
+				},
+				unmarshaler: &pmetric.JSONUnmarshaler{},
+			},
+		},
+		{
+			name: "Proto: snappy compression configuration",
+			args: args{
+				conf: &Config{
+					Path:        tempFileName(t),
+					FormatType:  "proto",
+					Compression: compressionSnappy,
+				},
+				unmarshaler: &pmetric.ProtoUnmarshaler{},
+			},
+		},
 		{
 			name: "Proto: compression configuration--rotation",
 			args: args{
@@ -365,6 +427,28 @@ func TestFileLogsExporter(t *testing.T) {
 				unmarshaler: &plog.ProtoUnmarshaler{},
 			},
 		},
+		{
+			name: "json: gzip compression configuration",
+			args: args{
+				conf: &Config{
+					Path:        tempFileName(t),
+					FormatType:  "json",
+					Compression: compressionGZIP,
+				},
+				unmarshaler: &plog.JSONUnmarshaler{},
+			},
+		},
+		{
+			name: "Proto: snappy compression configuration",
+			args: args{
+				conf: &Config{
+					Path:        tempFileName(t),
+					FormatType:  "proto",
+					Compression: compressionSnappy,
+				},
+				unmarshaler: &plog.ProtoUnmarshaler{},
+			},
+		},
 		{
 			name: "Proto: compression configuration--rotation",
 			args: args{
@@ -603,3 +687,79 @@ func TestConcurrentlyCompress(t *testing.T) {
 	assert.NoError(t, err)
 	assert.EqualValues(t, ld, gotLd)
 }
+
+func TestFileExporterFlushInterval(t *testing.T) {
+	conf := &Config{
+		Path:          tempFileName(t),
+		FormatType:    formatTypeJSON,
+		FlushInterval: time.Hour,
+	}
+	writer, err := buildFileWriter(conf)
+	require.NoError(t, err)
+	require.IsType(t, &bufferedWriteCloser{}, writer)
+	fe := newFileExporter(conf, writer, zap.NewNop())
+	require.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+
+	ld := testdata.GenerateLogsTwoLogRecordsSameResource()
+	require.NoError(t, fe.ConsumeLogs(context.Background(), ld))
+	fi, err := os.Stat(conf.Path)
+	require.NoError(t, err)
+	assert.Zero(t, fi.Size())
+
+	// Shutdown flushes the buffered telemetry.
+	require.NoError(t, fe.Shutdown(context.Background()))
+	fi, err = os.Stat(conf.Path)
+	require.NoError(t, err)
+	assert.NotZero(t, fi.Size())
+}
+
+func TestFileExporterRecordFraming(t *testing.T) {
+	conf := &Config{
+		Path:        tempFileName(t),
+		FormatType:  formatTypeJSON,
+		Compression: compressionGZIP,
+		Framing:     framingRecord,
+	}
+	writer, err := buildFileWriter(conf)
+	require.NoError(t, err)
+	fe := newFileExporter(conf, writer, zap.NewNop())
+	require.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+
+	td := testdata.GenerateTracesTwoSpansSameResource()
+	md := testdata.GenerateMetricsTwoMetrics()
+	ld := testdata.GenerateLogsTwoLogRecordsSameResource()
+	require.NoError(t, fe.ConsumeTraces(context.Background(), td))
+	require.NoError(t, fe.ConsumeMetrics(context.Background(), md))
+	require.NoError(t, fe.ConsumeLogs(context.Background(), ld))
+	require.NoError(t, fe.Shutdown(context.Background()))
+
+	data, err := os.ReadFile(conf.Path)
+	require.NoError(t, err)
+	uncompress := buildUnCompressor(compressionGZIP)
+	var recordTypes []byte
+	for len(data) > 0 {
+		require.GreaterOrEqual(t, len(data), recordHeaderSize)
+		end := recordHeaderSize + int(binary.BigEndian.Uint32(data[1:recordHeaderSize]))
+		require.GreaterOrEqual(t, len(data), end)
+		buf, err := uncompress(data[recordHeaderSize:end])
+		require.NoError(t, err)
+
+		switch data[0] {
+		case recordTypeTraces:
+			got, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(buf)
+			require.NoError(t, err)
+			assert.Equal(t, td, got)
+		case recordTypeMetrics:
+			got, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(buf)
+			require.NoError(t, err)
+			assert.Equal(t, md, got)
+		case recordTypeLogs:
+			got, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(buf)
+			require.NoError(t, err)
+			assert.Equal(t, ld, got)
+		}
+		recordTypes = append(recordTypes, data[0])
+		data = data[end:]
+	}
+	assert.Equal(t, []byte{recordTypeTraces, recordTypeMetrics, recordTypeLogs}, recordTypes)
+}
@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/simplelru"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+// groupingFileExporter writes telemetry to the files rendered from a path template.
+// It keeps a bounded number of files open, each one with its own rotation.
+type groupingFileExporter struct {
+	conf   *Config
+	path   *pathTemplate
+	logger *zap.Logger
+
+	// mutex guards writers and serializes the writes to the files.
+	mutex   sync.Mutex
+	writers simplelru.LRUCache
+
+	stopFlusher func()
+	// now is overridden by tests.
+	now func() time.Time
+}
+
+func newGroupingFileExporter(conf *Config, path *pathTemplate, logger *zap.Logger) (*groupingFileExporter, error) {
+	e := &groupingFileExporter{
+		conf:   conf,
+		path:   path,
+		logger: logger,
+		now:    time.Now,
+	}
+	writers, err := simplelru.NewLRU(conf.GroupBy.MaxOpenFiles, func(key interface{}, value interface{}) {
+		if err := value.(*fileExporter).Shutdown(context.Background()); err != nil {
+			e.logger.Error("Failed to close file", zap.String("path", key.(string)), zap.Error(err))
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+	e.writers = writers
+	return e, nil
+}
+
+func (e *groupingFileExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *groupingFileExporter) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	now := e.now()
+	groups := make(map[string]ptrace.Traces)
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		path := e.path.render(rs.Resource(), now)
+		group, ok := groups[path]
+		if !ok {
+			group = ptrace.NewTraces()
+			groups[path] = group
+		}
+		rs.CopyTo(group.ResourceSpans().AppendEmpty())
+	}
+
+	var errs error
+	for path, group := range groups {
+		group := group
+		errs = multierr.Append(errs, e.consume(path, func(fe *fileExporter) error {
+			return fe.ConsumeTraces(ctx, group)
+		}))
+	}
+	return errs
+}
+
+func (e *groupingFileExporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	now := e.now()
+	groups := make(map[string]pmetric.Metrics)
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		path := e.path.render(rm.Resource(), now)
+		group, ok := groups[path]
+		if !ok {
+			group = pmetric.NewMetrics()
+			groups[path] = group
+		}
+		rm.CopyTo(group.ResourceMetrics().AppendEmpty())
+	}
+
+	var errs error
+	for path, group := range groups {
+		group := group
+		errs = multierr.Append(errs, e.consume(path, func(fe *fileExporter) error {
+			return fe.ConsumeMetrics(ctx, group)
+		}))
+	}
+	return errs
+}
+
+func (e *groupingFileExporter) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	now := e.now()
+	groups := make(map[string]plog.Logs)
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		path := e.path.render(rl.Resource(), now)
+		group, ok := groups[path]
+		if !ok {
+			group = plog.NewLogs()
+			groups[path] = group
+		}
+		rl.CopyTo(group.ResourceLogs().AppendEmpty())
+	}
+
+	var errs error
+	for path, group := range groups {
+		group := group
+		errs = multierr.Append(errs, e.consume(path, func(fe *fileExporter) error {
+			return fe.ConsumeLogs(ctx, group)
+		}))
+	}
+	return errs
+}
+
+// consume calls fn with the exporter of the given path, opening its file if needed.
+func (e *groupingFileExporter) consume(path string, fn func(fe *fileExporter) error) error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	if value, ok := e.writers.Get(path); ok {
+		return fn(value.(*fileExporter))
+	}
+	writer, err := newFileWriter(path, e.conf.Rotation, e.conf.FlushInterval, true)
+	if err != nil {
+		return err
+	}
+	fe := newFileExporter(e.conf, writer, e.logger)
+	fe.path = path
+	e.writers.Add(path, fe)
+	return fn(fe)
+}
+
+// flush writes the buffered telemetry of all open files.
+func (e *groupingFileExporter) flush() {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	for _, key := range e.writers.Keys() {
+		value, _ := e.writers.Peek(key)
+		fe := value.(*fileExporter)
+		if err := fe.flush(); err != nil {
+			e.logger.Error("Failed to flush telemetry to file", zap.String("path", fe.path), zap.Error(err))
+		}
+	}
+}
+
+func (e *groupingFileExporter) Start(context.Context, component.Host) error {
+	if e.conf.FlushInterval > 0 {
+		e.stopFlusher = startFlusher(e.conf.FlushInterval, e.flush)
+	}
+	return nil
+}
+
+// Shutdown closes all open files.
+func (e *groupingFileExporter) Shutdown(context.Context) error {
+	if e.stopFlusher != nil {
+		e.stopFlusher()
+	}
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	// Purge calls the eviction callback, which closes every file.
+	e.writers.Purge()
+	return nil
+}
@@ -0,0 +1,174 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func newTestGroupingExporter(t *testing.T, conf *Config) *groupingFileExporter {
+	tmpl, err := conf.GroupBy.pathTemplate(conf.Path)
+	require.NoError(t, err)
+	ge, err := newGroupingFileExporter(conf, tmpl, zap.NewNop())
+	require.NoError(t, err)
+	ge.now = func() time.Time {
+		return time.Date(2022, 11, 3, 14, 30, 0, 0, time.UTC)
+	}
+	return ge
+}
+
+func readJSONLines(t *testing.T, path string) [][]byte {
+	fi, err := os.Open(path)
+	require.NoError(t, err)
+	defer fi.Close()
+	var lines [][]byte
+	br := bufio.NewReader(fi)
+	for {
+		buf, isEnd, err := readJSONMessage(br)
+		require.NoError(t, err)
+		if isEnd {
+			return lines
+		}
+		lines = append(lines, append([]byte{}, buf...))
+	}
+}
+
+func TestGroupingFileExporterLogs(t *testing.T) {
+	dir := t.TempDir()
+	conf := createDefaultConfig().(*Config)
+	conf.Path = filepath.Join(dir, "{resource.service.name}", "%Y-%m-%d.json")
+	conf.GroupBy.ResourceAttributes = []string{"service.name"}
+	conf.Rotation = nil
+	ge := newTestGroupingExporter(t, conf)
+	require.NoError(t, ge.Start(context.Background(), componenttest.NewNopHost()))
+
+	ld := plog.NewLogs()
+	for _, service := range []string{"checkout", "cart", "checkout", ""} {
+		rl := ld.ResourceLogs().AppendEmpty()
+		if service != "" {
+			rl.Resource().Attributes().PutStr("service.name", service)
+		}
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("from " + service)
+	}
+	require.NoError(t, ge.ConsumeLogs(context.Background(), ld))
+	require.NoError(t, ge.ConsumeLogs(context.Background(), ld))
+	require.NoError(t, ge.Shutdown(context.Background()))
+
+	unmarshaler := &plog.JSONUnmarshaler{}
+	for service, resources := range map[string]int{"checkout": 2, "cart": 1, "unknown": 1} {
+		lines := readJSONLines(t, filepath.Join(dir, service, "2022-11-03.json"))
+		require.Len(t, lines, 2, service)
+		for _, line := range lines {
+			got, err := unmarshaler.UnmarshalLogs(line)
+			require.NoError(t, err)
+			assert.Equal(t, resources, got.ResourceLogs().Len(), service)
+		}
+	}
+}
+
+func TestGroupingFileExporterTracesAndMetrics(t *testing.T) {
+	dir := t.TempDir()
+	conf := createDefaultConfig().(*Config)
+	conf.Path = filepath.Join(dir, "{resource.service.name}.json")
+	conf.GroupBy.ResourceAttributes = []string{"service.name"}
+	conf.Rotation = nil
+	ge := newTestGroupingExporter(t, conf)
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("metric")
+
+	require.NoError(t, ge.ConsumeTraces(context.Background(), td))
+	require.NoError(t, ge.ConsumeMetrics(context.Background(), md))
+	require.NoError(t, ge.Shutdown(context.Background()))
+
+	lines := readJSONLines(t, filepath.Join(dir, "checkout.json"))
+	require.Len(t, lines, 2)
+	gotTraces, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(lines[0])
+	require.NoError(t, err)
+	assert.Equal(t, td, gotTraces)
+	gotMetrics, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(lines[1])
+	require.NoError(t, err)
+	assert.Equal(t, md, gotMetrics)
+}
+
+func TestGroupingFileExporterMaxOpenFiles(t *testing.T) {
+	dir := t.TempDir()
+	conf := createDefaultConfig().(*Config)
+	conf.Path = filepath.Join(dir, "{resource.service.name}.json")
+	conf.GroupBy.ResourceAttributes = []string{"service.name"}
+	conf.Rotation = nil
+	conf.GroupBy.MaxOpenFiles = 1
+	ge := newTestGroupingExporter(t, conf)
+
+	logsFor := func(service string) plog.Logs {
+		ld := plog.NewLogs()
+		rl := ld.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().PutStr("service.name", service)
+		rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+		return ld
+	}
+	// Writing to b closes a, which is appended to when reopened.
+	require.NoError(t, ge.ConsumeLogs(context.Background(), logsFor("a")))
+	require.NoError(t, ge.ConsumeLogs(context.Background(), logsFor("b")))
+	assert.Equal(t, 1, ge.writers.Len())
+	require.NoError(t, ge.ConsumeLogs(context.Background(), logsFor("a")))
+	require.NoError(t, ge.Shutdown(context.Background()))
+	assert.Equal(t, 0, ge.writers.Len())
+
+	assert.Len(t, readJSONLines(t, filepath.Join(dir, "a.json")), 2)
+	assert.Len(t, readJSONLines(t, filepath.Join(dir, "b.json")), 1)
+}
+
+func TestGroupingFileExporterFlushInterval(t *testing.T) {
+	dir := t.TempDir()
+	conf := createDefaultConfig().(*Config)
+	conf.Path = filepath.Join(dir, "{resource.service.name}.json")
+	conf.GroupBy.ResourceAttributes = []string{"service.name"}
+	conf.Rotation = nil
+	conf.FlushInterval = 10 * time.Millisecond
+	ge := newTestGroupingExporter(t, conf)
+	require.NoError(t, ge.Start(context.Background(), componenttest.NewNopHost()))
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	require.NoError(t, ge.ConsumeLogs(context.Background(), ld))
+
+	assert.Eventually(t, func() bool {
+		fi, err := os.Stat(filepath.Join(dir, "checkout.json"))
+		return err == nil && fi.Size() > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, ge.Shutdown(context.Background()))
+}
@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const resourcePlaceholderPrefix = "resource."
+
+var errUnclosedPlaceholder = errors.New("path contains an unclosed '{' placeholder")
+
+// timeDirectives maps the supported strftime directives to their Go time layout.
+var timeDirectives = map[byte]string{
+	'Y': "2006",
+	'm': "01",
+	'd': "02",
+	'H': "15",
+}
+
+// pathPart is either a literal, a time layout or a resource attribute key.
+type pathPart struct {
+	literal     string
+	timeLayout  string
+	resourceKey string
+}
+
+// pathTemplate is a file path that may contain {resource.<key>} placeholders
+// and %Y, %m, %d and %H time directives.
+type pathTemplate struct {
+	parts        []pathPart
+	missingValue string
+}
+
+func parsePathTemplate(path string, missingValue string) (*pathTemplate, error) {
+	tmpl := &pathTemplate{missingValue: missingValue}
+	var literal strings.Builder
+	flushLiteral := func() {
+		if literal.Len() > 0 {
+			tmpl.parts = append(tmpl.parts, pathPart{literal: literal.String()})
+			literal.Reset()
+		}
+	}
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; {
+		case c == '{':
+			end := strings.IndexByte(path[i:], '}')
+			if end < 0 {
+				return nil, errUnclosedPlaceholder
+			}
+			placeholder := path[i+1 : i+end]
+			if !strings.HasPrefix(placeholder, resourcePlaceholderPrefix) || len(placeholder) == len(resourcePlaceholderPrefix) {
+				return nil, fmt.Errorf("path placeholder %q is not supported, use {resource.<key>}", placeholder)
+			}
+			flushLiteral()
+			tmpl.parts = append(tmpl.parts, pathPart{resourceKey: placeholder[len(resourcePlaceholderPrefix):]})
+			i += end
+		case c == '%' && i+1 < len(path):
+			if path[i+1] == '%' {
+				literal.WriteByte('%')
+				i++
+				continue
+			}
+			layout, ok := timeDirectives[path[i+1]]
+			if !ok {
+				literal.WriteByte(c)
+				continue
+			}
+			flushLiteral()
+			tmpl.parts = append(tmpl.parts, pathPart{timeLayout: layout})
+			i++
+		default:
+			literal.WriteByte(c)
+		}
+	}
+	flushLiteral()
+	return tmpl, nil
+}
+
+// resourceKeys returns the keys of the resource attributes of the placeholders.
+func (t *pathTemplate) resourceKeys() map[string]struct{} {
+	keys := make(map[string]struct{})
+	for _, part := range t.parts {
+		if part.resourceKey != "" {
+			keys[part.resourceKey] = struct{}{}
+		}
+	}
+	return keys
+}
+
+// render returns the path for the given resource at the given time, which is
+// formatted in UTC.
+func (t *pathTemplate) render(resource pcommon.Resource, now time.Time) string {
+	now = now.UTC()
+	var b strings.Builder
+	for _, part := range t.parts {
+		switch {
+		case part.timeLayout != "":
+			b.WriteString(now.Format(part.timeLayout))
+		case part.resourceKey != "":
+			value := t.missingValue
+			if v, ok := resource.Attributes().Get(part.resourceKey); ok && v.AsString() != "" {
+				value = v.AsString()
+			}
+			b.WriteString(sanitizePathSegment(value))
+		default:
+			b.WriteString(part.literal)
+		}
+	}
+	return b.String()
+}
+
+// sanitizePathSegment prevents attribute values from escaping their directory.
+func sanitizePathSegment(value string) string {
+	if value == "." || value == ".." {
+		return "_"
+	}
+	return strings.NewReplacer("/", "_", `\`, "_", "\x00", "_").Replace(value)
+}
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestPathTemplate(t *testing.T) {
+	now := time.Date(2022, 11, 3, 14, 30, 0, 0, time.UTC)
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("service.name", "checkout")
+	resource.Attributes().PutStr("escape", "../etc/passwd")
+	resource.Attributes().PutInt("shard", 3)
+
+	tests := []struct {
+		name string
+		path string
+		keys []string
+		want string
+	}{
+		{
+			name: "static",
+			path: "./data/file.json",
+			want: "./data/file.json",
+		},
+		{
+			name: "unknown directives are kept",
+			path: "./data/100%.json",
+			want: "./data/100%.json",
+		},
+		{
+			name: "escaped percent",
+			path: "./data/%%Y.json",
+			want: "./data/%Y.json",
+		},
+		{
+			name: "resource and time",
+			path: "/var/log/otel/{resource.service.name}/%Y-%m-%d-%H.json",
+			keys: []string{"service.name"},
+			want: "/var/log/otel/checkout/2022-11-03-14.json",
+		},
+		{
+			name: "non string attribute",
+			path: "./shard-{resource.shard}.json",
+			keys: []string{"shard"},
+			want: "./shard-3.json",
+		},
+		{
+			name: "missing attribute",
+			path: "./{resource.host.name}.json",
+			keys: []string{"host.name"},
+			want: "./unknown.json",
+		},
+		{
+			name: "path separators are replaced",
+			path: "./{resource.escape}.json",
+			keys: []string{"escape"},
+			want: "./.._etc_passwd.json",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := parsePathTemplate(tt.path, defaultMissingValue)
+			require.NoError(t, err)
+			keys := make(map[string]struct{})
+			for _, key := range tt.keys {
+				keys[key] = struct{}{}
+			}
+			assert.Equal(t, keys, tmpl.resourceKeys())
+			assert.Equal(t, tt.want, tmpl.render(resource, now))
+		})
+	}
+}
+
+func TestPathTemplateErrors(t *testing.T) {
+	_, err := parsePathTemplate("./{resource.service.name", defaultMissingValue)
+	assert.ErrorIs(t, err, errUnclosedPlaceholder)
+
+	_, err = parsePathTemplate("./{attributes.service.name}.json", defaultMissingValue)
+	assert.EqualError(t, err, `path placeholder "attributes.service.name" is not supported, use {resource.<key>}`)
+
+	_, err = parsePathTemplate("./{resource.}.json", defaultMissingValue)
+	assert.Error(t, err)
+}
+
+func TestSanitizePathSegment(t *testing.T) {
+	assert.Equal(t, "_", sanitizePathSegment(".."))
+	assert.Equal(t, "_", sanitizePathSegment("."))
+	assert.Equal(t, "a_b_c", sanitizePathSegment(`a/b\c`))
+	assert.Equal(t, "checkout", sanitizePathSegment("checkout"))
+}
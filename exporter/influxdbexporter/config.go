@@ -15,6 +15,9 @@
 package influxdbexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/influxdbexporter"
 
 import (
+	"errors"
+	"fmt"
+
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -47,8 +50,30 @@ type Config struct {
 	// - telegraf-prometheus-v1
 	// - telegraf-prometheus-v2
 	MetricsSchema string `mapstructure:"metrics_schema"`
+
+	// IOx configures the schema of spans and logs written to InfluxDB 3 / IOx.
+	IOx IOxSettings `mapstructure:"iox"`
+}
+
+// IOxSettings defines the tags of the span and log tables written to InfluxDB 3 / IOx.
+type IOxSettings struct {
+	// Enabled writes the configured dimensions as tags of spans, span links and log
+	// records, instead of every resource attribute. The other tags are written as fields.
+	Enabled bool `mapstructure:"enabled"`
+	// SpanDimensions are the tags of the spans and span-links tables.
+	SpanDimensions []string `mapstructure:"span_dimensions"`
+	// LogRecordDimensions are the tags of the logs table.
+	LogRecordDimensions []string `mapstructure:"log_record_dimensions"`
 }
 
 func (cfg *Config) Validate() error {
+	if network, address, ok := socketEndpoint(cfg.HTTPClientSettings.Endpoint); ok && address == "" {
+		return fmt.Errorf("%s endpoint %q must have a host and port", network, cfg.HTTPClientSettings.Endpoint)
+	}
+	for _, dimension := range append(cfg.IOx.SpanDimensions, cfg.IOx.LogRecordDimensions...) {
+		if dimension == "" {
+			return errors.New("iox dimensions must not be empty")
+		}
+	}
 	return nil
 }
@@ -66,8 +66,41 @@ func TestLoadConfig(t *testing.T) {
 				Bucket:        "my-bucket",
 				Token:         "my-token",
 				MetricsSchema: "telegraf-prometheus-v2",
+				IOx: IOxSettings{
+					SpanDimensions:      []string{"service.name", "name"},
+					LogRecordDimensions: []string{"service.name"},
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(typeStr, "iox"),
+			expected: &Config{
+				ExporterSettings: config.NewExporterSettings(component.NewID(typeStr)),
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "https://us-east-1-1.aws.cloud2.influxdata.com",
+					Timeout:  5 * time.Second,
+					Headers:  map[string]string{"User-Agent": "OpenTelemetry -> Influx"},
+				},
+				QueueSettings: exporterhelper.NewDefaultQueueSettings(),
+				RetrySettings: exporterhelper.NewDefaultRetrySettings(),
+				Bucket:        "otel",
+				Token:         "my-token",
+				MetricsSchema: "telegraf-prometheus-v1",
+				IOx: IOxSettings{
+					Enabled:             true,
+					SpanDimensions:      []string{"service.name", "name", "http.method"},
+					LogRecordDimensions: []string{"service.name", "severity_text"},
+				},
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "udp"),
+			expected: func() component.ExporterConfig {
+				cfg := createDefaultConfig().(*Config)
+				cfg.HTTPClientSettings.Endpoint = "udp://localhost:8089"
+				return cfg
+			}(),
+		},
 	}
 
 	for _, tt := range tests {
@@ -84,3 +117,13 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTPClientSettings.Endpoint = "tcp://"
+	assert.EqualError(t, cfg.Validate(), `tcp endpoint "tcp://" must have a host and port`)
+
+	cfg = createDefaultConfig().(*Config)
+	cfg.IOx.SpanDimensions = []string{""}
+	assert.EqualError(t, cfg.Validate(), "iox dimensions must not be empty")
+}
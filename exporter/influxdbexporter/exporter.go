@@ -30,7 +30,7 @@ import (
 type tracesExporter struct {
 	logger    common.Logger
 	cfg       *Config
-	writer    *influxHTTPWriter
+	writer    influxWriter
 	converter *otel2influx.OtelTracesToLineProtocol
 	settings  component.TelemetrySettings
 }
@@ -60,7 +60,7 @@ func (e *tracesExporter) pushTraces(ctx context.Context, td ptrace.Traces) error
 // start starts the traces exporter
 func (e *tracesExporter) start(_ context.Context, host component.Host) (err error) {
 
-	writer, err := newInfluxHTTPWriter(e.logger, e.cfg, host, e.settings)
+	writer, err := newInfluxWriter(e.logger, e.cfg, host, e.settings)
 	if err != nil {
 		return err
 	}
@@ -69,10 +69,18 @@ func (e *tracesExporter) start(_ context.Context, host component.Host) (err erro
 	return nil
 }
 
+// shutdown closes the connection of the traces exporter
+func (e *tracesExporter) shutdown(context.Context) error {
+	if e.writer == nil {
+		return nil
+	}
+	return e.writer.close()
+}
+
 type metricsExporter struct {
 	logger    common.Logger
 	cfg       *Config
-	writer    *influxHTTPWriter
+	writer    influxWriter
 	converter *otel2influx.OtelMetricsToLineProtocol
 	settings  component.TelemetrySettings
 }
@@ -115,7 +123,7 @@ func (e *metricsExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) e
 // start starts the metrics exporter
 func (e *metricsExporter) start(_ context.Context, host component.Host) (err error) {
 
-	writer, err := newInfluxHTTPWriter(e.logger, e.cfg, host, e.settings)
+	writer, err := newInfluxWriter(e.logger, e.cfg, host, e.settings)
 	if err != nil {
 		return err
 	}
@@ -124,10 +132,18 @@ func (e *metricsExporter) start(_ context.Context, host component.Host) (err err
 	return nil
 }
 
+// shutdown closes the connection of the metrics exporter
+func (e *metricsExporter) shutdown(context.Context) error {
+	if e.writer == nil {
+		return nil
+	}
+	return e.writer.close()
+}
+
 type logsExporter struct {
 	logger    common.Logger
 	cfg       *Config
-	writer    *influxHTTPWriter
+	writer    influxWriter
 	converter *otel2influx.OtelLogsToLineProtocol
 	settings  component.TelemetrySettings
 }
@@ -156,7 +172,7 @@ func (e *logsExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
 
 // start starts the logs exporter
 func (e *logsExporter) start(_ context.Context, host component.Host) (err error) {
-	writer, err := newInfluxHTTPWriter(e.logger, e.cfg, host, e.settings)
+	writer, err := newInfluxWriter(e.logger, e.cfg, host, e.settings)
 	if err != nil {
 		return err
 	}
@@ -164,3 +180,11 @@ func (e *logsExporter) start(_ context.Context, host component.Host) (err error)
 
 	return nil
 }
+
+// shutdown closes the connection of the logs exporter
+func (e *logsExporter) shutdown(context.Context) error {
+	if e.writer == nil {
+		return nil
+	}
+	return e.writer.close()
+}
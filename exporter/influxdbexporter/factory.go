@@ -18,10 +18,12 @@ import (
 	"context"
 	"time"
 
+	"github.com/influxdata/influxdb-observability/common"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
 )
 
 // NewFactory creates a factory for Jaeger Thrift over HTTP exporter.
@@ -48,6 +50,7 @@ func createTraceExporter(ctx context.Context, set component.ExporterCreateSettin
 		exporterhelper.WithQueue(cfg.QueueSettings),
 		exporterhelper.WithRetry(cfg.RetrySettings),
 		exporterhelper.WithStart(exporter.start),
+		exporterhelper.WithShutdown(exporter.shutdown),
 	)
 }
 
@@ -67,6 +70,7 @@ func createMetricsExporter(ctx context.Context, set component.ExporterCreateSett
 		exporterhelper.WithQueue(cfg.QueueSettings),
 		exporterhelper.WithRetry(cfg.RetrySettings),
 		exporterhelper.WithStart(exporter.start),
+		exporterhelper.WithShutdown(exporter.shutdown),
 	)
 }
 
@@ -83,6 +87,7 @@ func createLogsExporter(ctx context.Context, set component.ExporterCreateSetting
 		exporterhelper.WithQueue(cfg.QueueSettings),
 		exporterhelper.WithRetry(cfg.RetrySettings),
 		exporterhelper.WithStart(exporter.start),
+		exporterhelper.WithShutdown(exporter.shutdown),
 	)
 }
 
@@ -98,5 +103,9 @@ func createDefaultConfig() component.ExporterConfig {
 		QueueSettings: exporterhelper.NewDefaultQueueSettings(),
 		RetrySettings: exporterhelper.NewDefaultRetrySettings(),
 		MetricsSchema: "telegraf-prometheus-v1",
+		IOx: IOxSettings{
+			SpanDimensions:      []string{conventions.AttributeServiceName, common.AttributeName},
+			LogRecordDimensions: []string{conventions.AttributeServiceName},
+		},
 	}
 }
@@ -11,6 +11,7 @@ require (
 	go.opentelemetry.io/collector/component v0.65.0
 	go.opentelemetry.io/collector/consumer v0.65.0
 	go.opentelemetry.io/collector/pdata v0.65.0
+	go.opentelemetry.io/collector/semconv v0.65.0
 	go.uber.org/zap v1.23.0
 )
 
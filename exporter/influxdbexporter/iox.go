@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/influxdbexporter"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/influxdata/influxdb-observability/common"
+)
+
+// identityTags are always written as tags by the IOx writer, since IOx keeps a
+// single row per table, tag set and timestamp.
+var identityTags = map[string]struct{}{
+	common.AttributeTraceID:       {},
+	common.AttributeSpanID:        {},
+	common.AttributeLinkedTraceID: {},
+	common.AttributeLinkedSpanID:  {},
+}
+
+// ioxWriter writes spans, span links and log records to InfluxDB 3 / IOx tables
+// whose tags are the configured dimensions, rather than every resource attribute.
+type ioxWriter struct {
+	influxWriter
+	spanDimensions      map[string]struct{}
+	logRecordDimensions map[string]struct{}
+}
+
+func newIOxWriter(writer influxWriter, settings IOxSettings) *ioxWriter {
+	return &ioxWriter{
+		influxWriter:        writer,
+		spanDimensions:      toSet(settings.SpanDimensions),
+		logRecordDimensions: toSet(settings.LogRecordDimensions),
+	}
+}
+
+func toSet(keys []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		set[key] = struct{}{}
+	}
+	return set
+}
+
+func (w *ioxWriter) newBatch() influxWriterBatch {
+	return &ioxWriterBatch{
+		influxWriterBatch: w.influxWriter.newBatch(),
+		w:                 w,
+	}
+}
+
+type ioxWriterBatch struct {
+	influxWriterBatch
+	w *ioxWriter
+}
+
+// WritePoint keeps the dimensions and identity tags of spans, span links and log records
+// as tags, and writes their other tags as fields. Metrics are written unchanged.
+func (b *ioxWriterBatch) WritePoint(ctx context.Context, measurement string, tags map[string]string, fields map[string]interface{}, ts time.Time, vType common.InfluxMetricValueType) error {
+	var dimensions map[string]struct{}
+	switch measurement {
+	case common.MeasurementSpans, common.MeasurementSpanLinks:
+		dimensions = b.w.spanDimensions
+	case common.MeasurementLogs:
+		dimensions = b.w.logRecordDimensions
+	default:
+		return b.influxWriterBatch.WritePoint(ctx, measurement, tags, fields, ts, vType)
+	}
+
+	ioxTags := make(map[string]string, len(dimensions)+len(identityTags))
+	ioxFields := make(map[string]interface{}, len(fields)+len(tags))
+	for k, v := range fields {
+		if _, ok := dimensions[k]; ok && v != nil {
+			ioxTags[k] = fmt.Sprint(v)
+		} else {
+			ioxFields[k] = v
+		}
+	}
+	for k, v := range tags {
+		_, isDimension := dimensions[k]
+		_, isIdentity := identityTags[k]
+		if isDimension || isIdentity {
+			ioxTags[k] = v
+		} else {
+			ioxFields[k] = v
+		}
+	}
+	return b.influxWriterBatch.WritePoint(ctx, measurement, ioxTags, ioxFields, ts, vType)
+}
@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/influxdbexporter"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/influxdata/influxdb-observability/common"
+	"github.com/influxdata/line-protocol/v2/lineprotocol"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+)
+
+// maxDatagramSize is the largest UDP payload over IPv4.
+const maxDatagramSize = 65507
+
+var errLineTooLong = errors.New("line protocol line does not fit in a UDP datagram")
+
+// socketEndpoint returns the network and address of tcp:// and udp:// endpoints.
+func socketEndpoint(endpoint string) (network string, address string, ok bool) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", "", false
+	}
+	switch u.Scheme {
+	case "tcp", "udp":
+		return u.Scheme, u.Host, true
+	}
+	return "", "", false
+}
+
+// influxSocketWriter writes raw line protocol to a TCP or UDP socket, as accepted
+// by the Telegraf socket_listener input plugin.
+type influxSocketWriter struct {
+	encoderPool sync.Pool
+	network     string
+	address     string
+	timeout     time.Duration
+
+	logger common.Logger
+
+	// mutex guards conn, which is opened on the first write and after errors.
+	mutex sync.Mutex
+	conn  net.Conn
+}
+
+func newInfluxSocketWriter(logger common.Logger, network string, address string, timeout time.Duration) *influxSocketWriter {
+	return &influxSocketWriter{
+		encoderPool: newEncoderPool(),
+		network:     network,
+		address:     address,
+		timeout:     timeout,
+		logger:      logger,
+	}
+}
+
+func (w *influxSocketWriter) newBatch() influxWriterBatch {
+	return &influxSocketWriterBatch{
+		lineProtocolBatch: lineProtocolBatch{
+			encoder: w.encoderPool.Get().(*lineprotocol.Encoder),
+			logger:  w.logger,
+		},
+		w: w,
+	}
+}
+
+// write sends the payload, reconnecting if needed. The connection is closed on
+// errors, so that the retry of the batch starts from a new connection.
+func (w *influxSocketWriter) write(ctx context.Context, payload []byte) error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.conn == nil {
+		dialer := net.Dialer{Timeout: w.timeout}
+		conn, err := dialer.DialContext(ctx, w.network, w.address)
+		if err != nil {
+			return err
+		}
+		w.conn = conn
+	}
+	if w.timeout > 0 {
+		if err := w.conn.SetWriteDeadline(time.Now().Add(w.timeout)); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if w.network == "udp" {
+		err = w.writeDatagrams(payload)
+	} else {
+		_, err = w.conn.Write(payload)
+	}
+	if err != nil {
+		_ = w.conn.Close()
+		w.conn = nil
+	}
+	return err
+}
+
+// writeDatagrams splits the payload in datagrams made of whole lines.
+func (w *influxSocketWriter) writeDatagrams(payload []byte) error {
+	for len(payload) > 0 {
+		size := len(payload)
+		if size > maxDatagramSize {
+			size = bytes.LastIndexByte(payload[:maxDatagramSize], '\n') + 1
+			if size == 0 {
+				return consumererror.NewPermanent(errLineTooLong)
+			}
+		}
+		if _, err := w.conn.Write(payload[:size]); err != nil {
+			return err
+		}
+		payload = payload[size:]
+	}
+	return nil
+}
+
+func (w *influxSocketWriter) close() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if w.conn == nil {
+		return nil
+	}
+	err := w.conn.Close()
+	w.conn = nil
+	return err
+}
+
+type influxSocketWriterBatch struct {
+	lineProtocolBatch
+	w *influxSocketWriter
+}
+
+func (b *influxSocketWriterBatch) flushAndClose(ctx context.Context) error {
+	if err := b.w.write(ctx, b.encoder.Bytes()); err != nil {
+		return err
+	}
+
+	b.encoder.Reset()
+	b.w.encoderPool.Put(b.encoder)
+
+	// Caller has a reference to this batch; don't let the caller keep references to its members.
+	b.encoder = nil
+	b.logger = nil
+	b.w = nil
+	return nil
+}
@@ -26,11 +26,49 @@ import (
 	"time"
 
 	"github.com/influxdata/influxdb-observability/common"
+	"github.com/influxdata/influxdb-observability/otel2influx"
 	"github.com/influxdata/line-protocol/v2/lineprotocol"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumererror"
 )
 
+// influxWriter creates the batches of line protocol points written by the exporters.
+type influxWriter interface {
+	newBatch() influxWriterBatch
+	close() error
+}
+
+// influxWriterBatch encodes the points of a batch and sends them on flushAndClose.
+type influxWriterBatch interface {
+	otel2influx.InfluxWriter
+	flushAndClose(ctx context.Context) error
+}
+
+func newInfluxWriter(logger common.Logger, config *Config, host component.Host, settings component.TelemetrySettings) (influxWriter, error) {
+	var writer influxWriter
+	var err error
+	if network, address, ok := socketEndpoint(config.HTTPClientSettings.Endpoint); ok {
+		writer = newInfluxSocketWriter(logger, network, address, config.HTTPClientSettings.Timeout)
+	} else if writer, err = newInfluxHTTPWriter(logger, config, host, settings); err != nil {
+		return nil, err
+	}
+	if config.IOx.Enabled {
+		writer = newIOxWriter(writer, config.IOx)
+	}
+	return writer, nil
+}
+
+func newEncoderPool() sync.Pool {
+	return sync.Pool{
+		New: func() interface{} {
+			e := new(lineprotocol.Encoder)
+			e.SetLax(false)
+			e.SetPrecision(lineprotocol.Nanosecond)
+			return e
+		},
+	}
+}
+
 type influxHTTPWriter struct {
 	encoderPool sync.Pool
 	httpClient  *http.Client
@@ -66,37 +104,36 @@ func newInfluxHTTPWriter(logger common.Logger, config *Config, host component.Ho
 	}
 
 	return &influxHTTPWriter{
-		encoderPool: sync.Pool{
-			New: func() interface{} {
-				e := new(lineprotocol.Encoder)
-				e.SetLax(false)
-				e.SetPrecision(lineprotocol.Nanosecond)
-				return e
-			},
-		},
-		httpClient: httpClient,
-		writeURL:   writeURL.String(),
-		logger:     logger,
+		encoderPool: newEncoderPool(),
+		httpClient:  httpClient,
+		writeURL:    writeURL.String(),
+		logger:      logger,
 	}, nil
 }
 
-func (w *influxHTTPWriter) newBatch() *influxHTTPWriterBatch {
+func (w *influxHTTPWriter) newBatch() influxWriterBatch {
 	return &influxHTTPWriterBatch{
-		w:       w,
-		encoder: w.encoderPool.Get().(*lineprotocol.Encoder),
-		logger:  w.logger,
+		lineProtocolBatch: lineProtocolBatch{
+			encoder: w.encoderPool.Get().(*lineprotocol.Encoder),
+			logger:  w.logger,
+		},
+		w: w,
 	}
 }
 
-type influxHTTPWriterBatch struct {
-	w       *influxHTTPWriter
+func (w *influxHTTPWriter) close() error {
+	return nil
+}
+
+// lineProtocolBatch encodes points to line protocol.
+type lineProtocolBatch struct {
 	encoder *lineprotocol.Encoder
 	logger  common.Logger
 }
 
 // WritePoint emits a set of line protocol attributes (metrics, tags, fields, timestamp)
 // to the internal line protocol buffer. This method implements otel2influx.InfluxWriter.
-func (b *influxHTTPWriterBatch) WritePoint(_ context.Context, measurement string, tags map[string]string, fields map[string]interface{}, ts time.Time, _ common.InfluxMetricValueType) error {
+func (b *lineProtocolBatch) WritePoint(_ context.Context, measurement string, tags map[string]string, fields map[string]interface{}, ts time.Time, _ common.InfluxMetricValueType) error {
 	b.encoder.StartLine(measurement)
 	for _, tag := range b.sortTags(tags) {
 		b.encoder.AddTag(tag.k, tag.v)
@@ -114,6 +151,11 @@ func (b *influxHTTPWriterBatch) WritePoint(_ context.Context, measurement string
 	return nil
 }
 
+type influxHTTPWriterBatch struct {
+	lineProtocolBatch
+	w *influxHTTPWriter
+}
+
 func (b *influxHTTPWriterBatch) flushAndClose(ctx context.Context) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, b.w.writeURL, bytes.NewReader(b.encoder.Bytes()))
 	if err != nil {
@@ -151,7 +193,7 @@ type tag struct {
 	k, v string
 }
 
-func (b *influxHTTPWriterBatch) sortTags(m map[string]string) []tag {
+func (b *lineProtocolBatch) sortTags(m map[string]string) []tag {
 	tags := make([]tag, 0, len(m))
 	for k, v := range m {
 		if k == "" {
@@ -166,7 +208,7 @@ func (b *influxHTTPWriterBatch) sortTags(m map[string]string) []tag {
 	return tags
 }
 
-func (b *influxHTTPWriterBatch) convertFields(m map[string]interface{}) (fields map[string]lineprotocol.Value) {
+func (b *lineProtocolBatch) convertFields(m map[string]interface{}) (fields map[string]lineprotocol.Value) {
 	fields = make(map[string]lineprotocol.Value, len(m))
 	for k, v := range m {
 		if k == "" {
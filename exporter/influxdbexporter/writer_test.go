@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbexporter
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/influxdb-observability/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+var testTime = time.Unix(1, 0)
+
+func TestSocketEndpoint(t *testing.T) {
+	network, address, ok := socketEndpoint("tcp://localhost:8094")
+	assert.True(t, ok)
+	assert.Equal(t, "tcp", network)
+	assert.Equal(t, "localhost:8094", address)
+
+	network, address, ok = socketEndpoint("udp://127.0.0.1:8089")
+	assert.True(t, ok)
+	assert.Equal(t, "udp", network)
+	assert.Equal(t, "127.0.0.1:8089", address)
+
+	_, _, ok = socketEndpoint("http://localhost:8086")
+	assert.False(t, ok)
+}
+
+func TestInfluxSocketWriterTCP(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	lines := make(chan string, 10)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.HTTPClientSettings.Endpoint = "tcp://" + listener.Addr().String()
+	writer, err := newInfluxWriter(newZapInfluxLogger(zap.NewNop()), cfg, componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	require.IsType(t, &influxSocketWriter{}, writer)
+
+	for i := 0; i < 2; i++ {
+		batch := writer.newBatch()
+		require.NoError(t, batch.WritePoint(context.Background(), "cpu", map[string]string{"host": "a"}, map[string]interface{}{"usage": 0.5}, testTime, common.InfluxMetricValueTypeGauge))
+		require.NoError(t, batch.flushAndClose(context.Background()))
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case line := <-lines:
+			assert.Equal(t, "cpu,host=a usage=0.5 1000000000", line)
+		case <-time.After(5 * time.Second):
+			t.Fatal("line not received")
+		}
+	}
+	assert.NoError(t, writer.close())
+}
+
+func TestInfluxSocketWriterUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	writer := newInfluxSocketWriter(newZapInfluxLogger(zap.NewNop()), "udp", conn.LocalAddr().String(), time.Second)
+	defer writer.close()
+
+	// Lines are never split across datagrams.
+	line := "logs body=\"" + strings.Repeat("x", maxDatagramSize/3) + "\" 1000000000\n"
+	require.NoError(t, writer.write(context.Background(), []byte(strings.Repeat(line, 3))))
+	buf := make([]byte, maxDatagramSize)
+	var received []string
+	for len(received) < 3 {
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		datagram := string(buf[:n])
+		assert.True(t, strings.HasSuffix(datagram, "\n"))
+		received = append(received, strings.SplitAfter(datagram, "\n")...)
+		received = received[:len(received)-1]
+	}
+	assert.Equal(t, []string{line, line, line}, received)
+
+	err = writer.write(context.Background(), []byte(strings.Repeat("x", maxDatagramSize+1)))
+	assert.ErrorIs(t, err, errLineTooLong)
+}
+
+// recordingWriter records the points written to its batches.
+type recordingWriter struct {
+	points []recordedPoint
+}
+
+type recordedPoint struct {
+	measurement string
+	tags        map[string]string
+	fields      map[string]interface{}
+}
+
+func (w *recordingWriter) newBatch() influxWriterBatch {
+	return w
+}
+
+func (w *recordingWriter) close() error {
+	return nil
+}
+
+func (w *recordingWriter) WritePoint(_ context.Context, measurement string, tags map[string]string, fields map[string]interface{}, _ time.Time, _ common.InfluxMetricValueType) error {
+	w.points = append(w.points, recordedPoint{measurement: measurement, tags: tags, fields: fields})
+	return nil
+}
+
+func (w *recordingWriter) flushAndClose(context.Context) error {
+	return nil
+}
+
+func TestIOxWriterTraces(t *testing.T) {
+	recorder := &recordingWriter{}
+	settings := createDefaultConfig().(*Config).IOx
+	settings.SpanDimensions = append(settings.SpanDimensions, "http.method")
+	exporter := newTracesExporter(createDefaultConfig().(*Config), componenttest.NewNopExporterCreateSettings())
+	exporter.writer = newIOxWriter(recorder, settings)
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	rs.Resource().Attributes().PutStr("host.name", "host-1")
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("GET /cart")
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetTraceID(pcommon.TraceID([16]byte{1}))
+	span.SetSpanID(pcommon.SpanID([8]byte{2}))
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(testTime))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(testTime.Add(time.Second)))
+	span.Attributes().PutStr("http.method", "GET")
+	span.Attributes().PutInt("http.status_code", 200)
+	require.NoError(t, exporter.pushTraces(context.Background(), td))
+
+	require.Len(t, recorder.points, 1)
+	point := recorder.points[0]
+	assert.Equal(t, common.MeasurementSpans, point.measurement)
+	assert.Equal(t, map[string]string{
+		"service.name": "checkout",
+		"name":         "GET /cart",
+		"http.method":  "GET",
+		"trace_id":     "01000000000000000000000000000000",
+		"span_id":      "0200000000000000",
+	}, point.tags)
+	assert.Equal(t, "host-1", point.fields["host.name"])
+	assert.Equal(t, "Server", point.fields["kind"])
+	assert.Equal(t, int64(200), point.fields["http.status_code"])
+	assert.NotContains(t, point.fields, "http.method")
+}
+
+func TestIOxWriterLogs(t *testing.T) {
+	recorder := &recordingWriter{}
+	exporter := newLogsExporter(createDefaultConfig().(*Config), componenttest.NewNopExporterCreateSettings())
+	exporter.writer = newIOxWriter(recorder, createDefaultConfig().(*Config).IOx)
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	rl.Resource().Attributes().PutStr("host.name", "host-1")
+	record := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	record.SetTimestamp(pcommon.NewTimestampFromTime(testTime))
+	record.Body().SetStr("hello")
+	require.NoError(t, exporter.pushLogs(context.Background(), ld))
+
+	require.Len(t, recorder.points, 1)
+	point := recorder.points[0]
+	assert.Equal(t, map[string]string{"service.name": "checkout"}, point.tags)
+	assert.Equal(t, "host-1", point.fields["host.name"])
+	assert.Equal(t, "hello", point.fields["body"])
+}
+
+func TestIOxWriterKeepsMetrics(t *testing.T) {
+	recorder := &recordingWriter{}
+	writer := newIOxWriter(recorder, IOxSettings{})
+	tags := map[string]string{"host.name": "host-1"}
+	fields := map[string]interface{}{"gauge": 1.0}
+	require.NoError(t, writer.newBatch().WritePoint(context.Background(), "cpu", tags, fields, testTime, common.InfluxMetricValueTypeGauge))
+	assert.Equal(t, []recordedPoint{{measurement: "cpu", tags: tags, fields: fields}}, recorder.points)
+}
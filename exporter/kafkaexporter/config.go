@@ -38,6 +38,15 @@ type Config struct {
 	// The name of the kafka topic to export to (default otlp_spans for traces, otlp_metrics for metrics)
 	Topic string `mapstructure:"topic"`
 
+	// The name of the resource attribute whose value is the kafka topic the data of the resource is exported to.
+	// The data of the resources without this attribute is exported to Topic.
+	TopicFromAttribute string `mapstructure:"topic_from_attribute"`
+
+	// Whether to key the trace messages by trace ID, so that all the spans of a trace are produced
+	// to the same partition. Only applies to the otlp_proto and otlp_json encodings, the jaeger
+	// encodings always key their messages by trace ID.
+	PartitionTracesByID bool `mapstructure:"partition_traces_by_id"`
+
 	// Encoding of messages (default "otlp_proto")
 	Encoding string `mapstructure:"encoding"`
 
@@ -57,9 +57,11 @@ func TestLoadConfig(t *testing.T) {
 					NumConsumers: 2,
 					QueueSize:    10,
 				},
-				Topic:    "spans",
-				Encoding: "otlp_proto",
-				Brokers:  []string{"foo:123", "bar:456"},
+				Topic:               "spans",
+				TopicFromAttribute:  "kafka.topic",
+				PartitionTracesByID: true,
+				Encoding:            "otlp_proto",
+				Brokers:             []string{"foo:123", "bar:456"},
 				Authentication: Authentication{
 					PlainText: &PlainTextConfig{
 						Username: "jdoe",
@@ -32,10 +32,11 @@ var errUnrecognizedEncoding = fmt.Errorf("unrecognized encoding")
 
 // kafkaTracesProducer uses sarama to produce trace messages to Kafka.
 type kafkaTracesProducer struct {
-	producer  sarama.SyncProducer
-	topic     string
-	marshaler TracesMarshaler
-	logger    *zap.Logger
+	producer           sarama.SyncProducer
+	topic              string
+	topicFromAttribute string
+	marshaler          TracesMarshaler
+	logger             *zap.Logger
 }
 
 type kafkaErrors struct {
@@ -48,11 +49,15 @@ func (ke kafkaErrors) Error() string {
 }
 
 func (e *kafkaTracesProducer) tracesPusher(_ context.Context, td ptrace.Traces) error {
-	messages, err := e.marshaler.Marshal(td, e.topic)
-	if err != nil {
-		return consumererror.NewPermanent(err)
+	var messages []*sarama.ProducerMessage
+	for topic, data := range tracesPerTopic(td, e.topicFromAttribute, e.topic) {
+		msgs, err := e.marshaler.Marshal(data, topic)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		messages = append(messages, msgs...)
 	}
-	err = e.producer.SendMessages(messages)
+	err := e.producer.SendMessages(messages)
 	if err != nil {
 		var prodErr sarama.ProducerErrors
 		if errors.As(err, &prodErr) {
@@ -71,18 +76,23 @@ func (e *kafkaTracesProducer) Close(context.Context) error {
 
 // kafkaMetricsProducer uses sarama to produce metrics messages to kafka
 type kafkaMetricsProducer struct {
-	producer  sarama.SyncProducer
-	topic     string
-	marshaler MetricsMarshaler
-	logger    *zap.Logger
+	producer           sarama.SyncProducer
+	topic              string
+	topicFromAttribute string
+	marshaler          MetricsMarshaler
+	logger             *zap.Logger
 }
 
 func (e *kafkaMetricsProducer) metricsDataPusher(_ context.Context, md pmetric.Metrics) error {
-	messages, err := e.marshaler.Marshal(md, e.topic)
-	if err != nil {
-		return consumererror.NewPermanent(err)
+	var messages []*sarama.ProducerMessage
+	for topic, data := range metricsPerTopic(md, e.topicFromAttribute, e.topic) {
+		msgs, err := e.marshaler.Marshal(data, topic)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		messages = append(messages, msgs...)
 	}
-	err = e.producer.SendMessages(messages)
+	err := e.producer.SendMessages(messages)
 	if err != nil {
 		var prodErr sarama.ProducerErrors
 		if errors.As(err, &prodErr) {
@@ -101,18 +111,23 @@ func (e *kafkaMetricsProducer) Close(context.Context) error {
 
 // kafkaLogsProducer uses sarama to produce logs messages to kafka
 type kafkaLogsProducer struct {
-	producer  sarama.SyncProducer
-	topic     string
-	marshaler LogsMarshaler
-	logger    *zap.Logger
+	producer           sarama.SyncProducer
+	topic              string
+	topicFromAttribute string
+	marshaler          LogsMarshaler
+	logger             *zap.Logger
 }
 
 func (e *kafkaLogsProducer) logsDataPusher(_ context.Context, ld plog.Logs) error {
-	messages, err := e.marshaler.Marshal(ld, e.topic)
-	if err != nil {
-		return consumererror.NewPermanent(err)
+	var messages []*sarama.ProducerMessage
+	for topic, data := range logsPerTopic(ld, e.topicFromAttribute, e.topic) {
+		msgs, err := e.marshaler.Marshal(data, topic)
+		if err != nil {
+			return consumererror.NewPermanent(err)
+		}
+		messages = append(messages, msgs...)
 	}
-	err = e.producer.SendMessages(messages)
+	err := e.producer.SendMessages(messages)
 	if err != nil {
 		var prodErr sarama.ProducerErrors
 		if errors.As(err, &prodErr) {
@@ -179,10 +194,11 @@ func newMetricsExporter(config Config, set component.ExporterCreateSettings, mar
 	}
 
 	return &kafkaMetricsProducer{
-		producer:  producer,
-		topic:     config.Topic,
-		marshaler: marshaler,
-		logger:    set.Logger,
+		producer:           producer,
+		topic:              config.Topic,
+		topicFromAttribute: config.TopicFromAttribute,
+		marshaler:          marshaler,
+		logger:             set.Logger,
 	}, nil
 
 }
@@ -193,15 +209,19 @@ func newTracesExporter(config Config, set component.ExporterCreateSettings, mars
 	if marshaler == nil {
 		return nil, errUnrecognizedEncoding
 	}
+	if partitioner, ok := marshaler.(traceIDPartitioner); ok && config.PartitionTracesByID {
+		marshaler = partitioner.partitionedByTrace()
+	}
 	producer, err := newSaramaProducer(config)
 	if err != nil {
 		return nil, err
 	}
 	return &kafkaTracesProducer{
-		producer:  producer,
-		topic:     config.Topic,
-		marshaler: marshaler,
-		logger:    set.Logger,
+		producer:           producer,
+		topic:              config.Topic,
+		topicFromAttribute: config.TopicFromAttribute,
+		marshaler:          marshaler,
+		logger:             set.Logger,
 	}, nil
 }
 
@@ -216,10 +236,11 @@ func newLogsExporter(config Config, set component.ExporterCreateSettings, marsha
 	}
 
 	return &kafkaLogsProducer{
-		producer:  producer,
-		topic:     config.Topic,
-		marshaler: marshaler,
-		logger:    set.Logger,
+		producer:           producer,
+		topic:              config.Topic,
+		topicFromAttribute: config.TopicFromAttribute,
+		marshaler:          marshaler,
+		logger:             set.Logger,
 	}, nil
 
 }
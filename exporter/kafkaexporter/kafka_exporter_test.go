@@ -151,6 +151,34 @@ func TestTracesPusher(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestTracesPusher_topicFromAttribute(t *testing.T) {
+	c := sarama.NewConfig()
+	producer := mocks.NewSyncProducer(t, c)
+	var topics []string
+	checker := func(msg *sarama.ProducerMessage) error {
+		topics = append(topics, msg.Topic)
+		return nil
+	}
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checker)
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(checker)
+
+	p := kafkaTracesProducer{
+		producer:           producer,
+		topic:              "spans",
+		topicFromAttribute: "kafka.topic",
+		marshaler:          newPdataTracesMarshaler(&ptrace.ProtoMarshaler{}, defaultEncoding),
+	}
+	t.Cleanup(func() {
+		require.NoError(t, p.Close(context.Background()))
+	})
+	td := testdata.GenerateTracesTwoSpansSameResource()
+	td.ResourceSpans().At(0).CopyTo(td.ResourceSpans().AppendEmpty())
+	td.ResourceSpans().At(1).Resource().Attributes().PutStr("kafka.topic", "custom")
+	err := p.tracesPusher(context.Background(), td)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"spans", "custom"}, topics)
+}
+
 func TestTracesPusher_err(t *testing.T) {
 	c := sarama.NewConfig()
 	producer := mocks.NewSyncProducer(t, c)
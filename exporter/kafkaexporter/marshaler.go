@@ -30,6 +30,12 @@ type TracesMarshaler interface {
 	Encoding() string
 }
 
+// traceIDPartitioner is implemented by the TracesMarshalers able to key their messages by trace ID.
+type traceIDPartitioner interface {
+	// partitionedByTrace returns a TracesMarshaler producing one message per trace, keyed by trace ID.
+	partitionedByTrace() TracesMarshaler
+}
+
 // MetricsMarshaler marshals metrics into Message array
 type MetricsMarshaler interface {
 	// Marshal serializes metrics into sarama's ProducerMessages
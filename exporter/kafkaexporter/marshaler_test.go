@@ -147,3 +147,37 @@ func TestOTLPTracesJsonMarshaling(t *testing.T) {
 
 	assert.Equal(t, expectedJSON, final, "Must match the expected value")
 }
+
+func TestOTLPTracesPartitionedByTraceID(t *testing.T) {
+	traces := ptrace.NewTraces()
+	spans := traces.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for i, traceID := range []pcommon.TraceID{{1}, {2}, {1}} {
+		span := spans.AppendEmpty()
+		span.SetTraceID(traceID)
+		span.SetSpanID([8]byte{byte(i + 1)})
+	}
+
+	marshaler, ok := tracesMarshalers()[defaultEncoding].(traceIDPartitioner)
+	require.True(t, ok, "Must be able to partition otlp traces by trace ID")
+
+	msgs, err := marshaler.partitionedByTrace().Marshal(traces, t.Name())
+	require.NoError(t, err)
+	require.Len(t, msgs, 2)
+
+	unmarshaler := &ptrace.ProtoUnmarshaler{}
+	spanCounts := map[string]int{}
+	for _, msg := range msgs {
+		assert.Equal(t, t.Name(), msg.Topic)
+		key, err := msg.Key.Encode()
+		require.NoError(t, err)
+		value, err := msg.Value.Encode()
+		require.NoError(t, err)
+		td, err := unmarshaler.UnmarshalTraces(value)
+		require.NoError(t, err)
+		spanCounts[string(key)] = td.SpanCount()
+	}
+	assert.Equal(t, map[string]int{
+		"01000000000000000000000000000000": 2,
+		"02000000000000000000000000000000": 1,
+	}, spanCounts)
+}
@@ -15,10 +15,14 @@
 package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
 
 import (
+	"encoding/hex"
+
 	"github.com/Shopify/sarama"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/batchpersignal"
 )
 
 type pdataLogsMarshaler struct {
@@ -80,21 +84,45 @@ func newPdataMetricsMarshaler(marshaler pmetric.Marshaler, encoding string) Metr
 }
 
 type pdataTracesMarshaler struct {
-	marshaler ptrace.Marshaler
-	encoding  string
+	marshaler            ptrace.Marshaler
+	encoding             string
+	partitionedByTraceID bool
 }
 
 func (p pdataTracesMarshaler) Marshal(td ptrace.Traces, topic string) ([]*sarama.ProducerMessage, error) {
-	bts, err := p.marshaler.MarshalTraces(td)
-	if err != nil {
-		return nil, err
+	if !p.partitionedByTraceID {
+		bts, err := p.marshaler.MarshalTraces(td)
+		if err != nil {
+			return nil, err
+		}
+		return []*sarama.ProducerMessage{
+			{
+				Topic: topic,
+				Value: sarama.ByteEncoder(bts),
+			},
+		}, nil
 	}
-	return []*sarama.ProducerMessage{
-		{
+
+	var messages []*sarama.ProducerMessage
+	for _, trace := range batchpersignal.SplitTraces(td) {
+		bts, err := p.marshaler.MarshalTraces(trace)
+		if err != nil {
+			return nil, err
+		}
+		// SplitTraces returns batches holding the spans of a single trace.
+		traceID := trace.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).TraceID()
+		messages = append(messages, &sarama.ProducerMessage{
 			Topic: topic,
 			Value: sarama.ByteEncoder(bts),
-		},
-	}, nil
+			Key:   sarama.ByteEncoder(hex.EncodeToString(traceID[:])),
+		})
+	}
+	return messages, nil
+}
+
+func (p pdataTracesMarshaler) partitionedByTrace() TracesMarshaler {
+	p.partitionedByTraceID = true
+	return p
 }
 
 func (p pdataTracesMarshaler) Encoding() string {
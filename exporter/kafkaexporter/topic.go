@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+
+import (
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// resourceTopic returns the value of the attribute of the resource, or defaultTopic
+// if the resource doesn't have this attribute.
+func resourceTopic(resource pcommon.Resource, attribute string, defaultTopic string) string {
+	if v, ok := resource.Attributes().Get(attribute); ok {
+		if topic := v.AsString(); topic != "" {
+			return topic
+		}
+	}
+	return defaultTopic
+}
+
+// tracesPerTopic groups the resource spans of td by the topic they are exported to.
+func tracesPerTopic(td ptrace.Traces, attribute string, defaultTopic string) map[string]ptrace.Traces {
+	if attribute == "" {
+		return map[string]ptrace.Traces{defaultTopic: td}
+	}
+	result := map[string]ptrace.Traces{}
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		topic := resourceTopic(rs.Resource(), attribute, defaultTopic)
+		traces, ok := result[topic]
+		if !ok {
+			traces = ptrace.NewTraces()
+			result[topic] = traces
+		}
+		rs.CopyTo(traces.ResourceSpans().AppendEmpty())
+	}
+	return result
+}
+
+// metricsPerTopic groups the resource metrics of md by the topic they are exported to.
+func metricsPerTopic(md pmetric.Metrics, attribute string, defaultTopic string) map[string]pmetric.Metrics {
+	if attribute == "" {
+		return map[string]pmetric.Metrics{defaultTopic: md}
+	}
+	result := map[string]pmetric.Metrics{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		topic := resourceTopic(rm.Resource(), attribute, defaultTopic)
+		metrics, ok := result[topic]
+		if !ok {
+			metrics = pmetric.NewMetrics()
+			result[topic] = metrics
+		}
+		rm.CopyTo(metrics.ResourceMetrics().AppendEmpty())
+	}
+	return result
+}
+
+// logsPerTopic groups the resource logs of ld by the topic they are exported to.
+func logsPerTopic(ld plog.Logs, attribute string, defaultTopic string) map[string]plog.Logs {
+	if attribute == "" {
+		return map[string]plog.Logs{defaultTopic: ld}
+	}
+	result := map[string]plog.Logs{}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		topic := resourceTopic(rl.Resource(), attribute, defaultTopic)
+		logs, ok := result[topic]
+		if !ok {
+			logs = plog.NewLogs()
+			result[topic] = logs
+		}
+		rl.CopyTo(logs.ResourceLogs().AppendEmpty())
+	}
+	return result
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+)
+
+func TestTracesPerTopic(t *testing.T) {
+	td := testdata.GenerateTracesTwoSpansSameResource()
+	assert.Equal(t, map[string]int{"spans": 2}, spansPerTopic(tracesPerTopic(td, "", "spans")))
+	assert.Equal(t, map[string]int{"spans": 2}, spansPerTopic(tracesPerTopic(td, "kafka.topic", "spans")))
+
+	td.ResourceSpans().At(0).Resource().Attributes().PutStr("kafka.topic", "custom")
+	td.ResourceSpans().At(0).CopyTo(td.ResourceSpans().AppendEmpty())
+	td.ResourceSpans().At(1).Resource().Attributes().PutStr("kafka.topic", "")
+	assert.Equal(t, map[string]int{"custom": 2, "spans": 2}, spansPerTopic(tracesPerTopic(td, "kafka.topic", "spans")))
+	assert.Equal(t, map[string]int{"spans": 4}, spansPerTopic(tracesPerTopic(td, "", "spans")))
+}
+
+func TestMetricsPerTopic(t *testing.T) {
+	md := testdata.GenerateMetricsTwoMetrics()
+	md.ResourceMetrics().At(0).CopyTo(md.ResourceMetrics().AppendEmpty())
+	md.ResourceMetrics().At(1).Resource().Attributes().PutStr("kafka.topic", "custom")
+
+	perTopic := metricsPerTopic(md, "kafka.topic", "metrics")
+	require.Len(t, perTopic, 2)
+	assert.Equal(t, 2, perTopic["metrics"].MetricCount())
+	assert.Equal(t, 2, perTopic["custom"].MetricCount())
+}
+
+func TestLogsPerTopic(t *testing.T) {
+	ld := testdata.GenerateLogsOneLogRecord()
+	ld.ResourceLogs().At(0).CopyTo(ld.ResourceLogs().AppendEmpty())
+	ld.ResourceLogs().At(1).Resource().Attributes().PutInt("kafka.topic", 42)
+
+	perTopic := logsPerTopic(ld, "kafka.topic", "logs")
+	require.Len(t, perTopic, 2)
+	assert.Equal(t, 1, perTopic["logs"].LogRecordCount())
+	assert.Equal(t, 1, perTopic["42"].LogRecordCount())
+}
+
+func spansPerTopic(perTopic map[string]ptrace.Traces) map[string]int {
+	counts := map[string]int{}
+	for topic, td := range perTopic {
+		counts[topic] = td.SpanCount()
+	}
+	return counts
+}
@@ -15,8 +15,10 @@
 package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
 
 import (
+	"errors"
 	"fmt"
 	"net/url"
+	"time"
 
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -50,6 +52,38 @@ type Config struct {
 	// Deprecated: [v0.57.0] use the attribute processor to add a `loki.tenant` hint.
 	// See this component's documentation for more information on how to specify the hint.
 	Tenant *Tenant `mapstructure:"tenant"`
+
+	// StructuredMetadata lists the resource and log record attributes sent as Loki structured
+	// metadata instead of being part of the log line. These attributes are never promoted to
+	// labels, even when a label hint names them.
+	StructuredMetadata []string `mapstructure:"structured_metadata"`
+
+	// StreamSharding splits the streams whose rate exceeds the desired rate into several streams.
+	StreamSharding StreamShardingSettings `mapstructure:"stream_sharding"`
+
+	// StreamLimit stops new streams once a tenant has too many active streams.
+	StreamLimit StreamLimitSettings `mapstructure:"stream_limit"`
+}
+
+// StreamShardingSettings defines when streams are sharded.
+type StreamShardingSettings struct {
+	// Enabled turns on the sharding of streams.
+	Enabled bool `mapstructure:"enabled"`
+
+	// DesiredRate is the rate in bytes per second above which the entries of a
+	// stream are spread over several shards.
+	DesiredRate int64 `mapstructure:"desired_rate"`
+}
+
+// StreamLimitSettings defines the cardinality guard of streams.
+type StreamLimitSettings struct {
+	// MaxStreams is the maximum number of active streams per tenant. The log records
+	// of new streams above this number are dropped. Zero means no limit.
+	MaxStreams int `mapstructure:"max_streams"`
+
+	// IdleTimeout is the duration after which a stream without log records is no
+	// longer active.
+	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
 }
 
 func (c *Config) Validate() error {
@@ -59,7 +93,11 @@ func (c *Config) Validate() error {
 
 	// further validation is needed only if we are in legacy mode
 	if !c.isLegacy() {
-		return nil
+		return c.validateStreams()
+	}
+
+	if len(c.StructuredMetadata) > 0 || c.StreamSharding.Enabled || c.StreamLimit.MaxStreams > 0 {
+		return errors.New("structured_metadata, stream_sharding and stream_limit can't be used with the deprecated settings")
 	}
 
 	if c.Tenant != nil {
@@ -79,6 +117,28 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+func (c *Config) validateStreams() error {
+	for _, attr := range c.StructuredMetadata {
+		if attr == "" {
+			return errors.New("structured_metadata must not contain empty attribute names")
+		}
+	}
+
+	if c.StreamSharding.Enabled && c.StreamSharding.DesiredRate <= 0 {
+		return errors.New("stream_sharding.desired_rate must be positive")
+	}
+
+	if c.StreamLimit.MaxStreams < 0 {
+		return errors.New("stream_limit.max_streams must be non-negative")
+	}
+
+	if c.StreamLimit.MaxStreams > 0 && c.StreamLimit.IdleTimeout <= 0 {
+		return errors.New("stream_limit.idle_timeout must be positive")
+	}
+
+	return nil
+}
+
 func (c *Config) isLegacy() bool {
 	if c.Format != nil && *c.Format == "body" {
 		return true
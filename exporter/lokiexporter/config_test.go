@@ -71,8 +71,31 @@ func TestLoadConfigNewExporter(t *testing.T) {
 					NumConsumers: 2,
 					QueueSize:    10,
 				},
+				StreamSharding: StreamShardingSettings{
+					DesiredRate: defaultShardDesiredRate,
+				},
+				StreamLimit: StreamLimitSettings{
+					IdleTimeout: defaultStreamIdleTimeout,
+				},
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "streams"),
+			expected: func() component.ExporterConfig {
+				cfg := createDefaultLegacyConfig().(*Config)
+				cfg.Endpoint = "https://loki:3100/loki/api/v1/push"
+				cfg.StructuredMetadata = []string{"trace_id", "user.id"}
+				cfg.StreamSharding = StreamShardingSettings{
+					Enabled:     true,
+					DesiredRate: 3 << 20,
+				}
+				cfg.StreamLimit = StreamLimitSettings{
+					MaxStreams:  1000,
+					IdleTimeout: 30 * time.Minute,
+				}
+				return cfg
+			}(),
+		},
 	}
 
 	for _, tt := range tests {
@@ -90,6 +113,55 @@ func TestLoadConfigNewExporter(t *testing.T) {
 	}
 }
 
+func TestConfigValidate(t *testing.T) {
+	testCases := []struct {
+		desc string
+		cfg  func(*Config)
+		err  string
+	}{
+		{
+			desc: "empty structured metadata attribute",
+			cfg:  func(cfg *Config) { cfg.StructuredMetadata = []string{"user.id", ""} },
+			err:  "structured_metadata must not contain empty attribute names",
+		},
+		{
+			desc: "sharding without desired rate",
+			cfg: func(cfg *Config) {
+				cfg.StreamSharding = StreamShardingSettings{Enabled: true}
+			},
+			err: "stream_sharding.desired_rate must be positive",
+		},
+		{
+			desc: "negative max streams",
+			cfg:  func(cfg *Config) { cfg.StreamLimit.MaxStreams = -1 },
+			err:  "stream_limit.max_streams must be non-negative",
+		},
+		{
+			desc: "stream limit without idle timeout",
+			cfg: func(cfg *Config) {
+				cfg.StreamLimit = StreamLimitSettings{MaxStreams: 10}
+			},
+			err: "stream_limit.idle_timeout must be positive",
+		},
+		{
+			desc: "stream limit with deprecated settings",
+			cfg: func(cfg *Config) {
+				cfg.StreamLimit.MaxStreams = 10
+				cfg.TenantID = stringp("acme")
+			},
+			err: "structured_metadata, stream_sharding and stream_limit can't be used with the deprecated settings",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			cfg := createDefaultLegacyConfig().(*Config)
+			cfg.Endpoint = "https://loki.example.com"
+			tC.cfg(cfg)
+			assert.EqualError(t, cfg.Validate(), tC.err)
+		})
+	}
+}
+
 func TestIsLegacy(t *testing.T) {
 	testCases := []struct {
 		desc    string
@@ -17,6 +17,7 @@ package lokiexporter // import "github.com/open-telemetry/opentelemetry-collecto
 import (
 	"context"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 )
 
@@ -28,6 +29,8 @@ const (
 
 // NewFactory creates a factory for the legacy Loki exporter.
 func NewFactory() component.ExporterFactory {
+	_ = view.Register(MetricViews()...)
+
 	return component.NewExporterFactory(
 		typeStr,
 		createDefaultLegacyConfig,
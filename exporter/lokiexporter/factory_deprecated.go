@@ -36,6 +36,12 @@ func createDefaultLegacyConfig() component.ExporterConfig {
 		},
 		RetrySettings: exporterhelper.NewDefaultRetrySettings(),
 		QueueSettings: exporterhelper.NewDefaultQueueSettings(),
+		StreamSharding: StreamShardingSettings{
+			DesiredRate: defaultShardDesiredRate,
+		},
+		StreamLimit: StreamLimitSettings{
+			IdleTimeout: defaultStreamIdleTimeout,
+		},
 	}
 }
 
@@ -73,6 +73,12 @@ func TestLoadConfig(t *testing.T) {
 					NumConsumers: 2,
 					QueueSize:    10,
 				},
+				StreamSharding: StreamShardingSettings{
+					DesiredRate: defaultShardDesiredRate,
+				},
+				StreamLimit: StreamLimitSettings{
+					IdleTimeout: defaultStreamIdleTimeout,
+				},
 				TenantID: stringp("example"),
 				Labels: &LabelsConfig{
 					Attributes: map[string]string{
@@ -120,6 +126,12 @@ func TestLoadConfig(t *testing.T) {
 					NumConsumers: 10,
 					QueueSize:    5000,
 				},
+				StreamSharding: StreamShardingSettings{
+					DesiredRate: defaultShardDesiredRate,
+				},
+				StreamLimit: StreamLimitSettings{
+					IdleTimeout: defaultStreamIdleTimeout,
+				},
 				TenantID: stringp("example"),
 				Labels: &LabelsConfig{
 					RecordAttributes: map[string]string{
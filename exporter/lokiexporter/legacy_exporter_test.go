@@ -26,7 +26,7 @@ import (
 	"time"
 
 	"github.com/golang/snappy"
-	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/push"
 	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -375,7 +375,7 @@ func TestExporter_logDataToLoki(t *testing.T) {
 		lr.SetTimestamp(ts)
 
 		pr, numDroppedLogs := exp.logDataToLoki(logs)
-		expectedPr := &logproto.PushRequest{Streams: []logproto.Stream{}}
+		expectedPr := &push.PushRequest{Streams: []push.Stream{}}
 		require.Equal(t, 1, numDroppedLogs)
 		require.Equal(t, expectedPr, pr)
 	})
@@ -461,7 +461,7 @@ func TestExporter_logDataToLoki(t *testing.T) {
 		lri.SetTimestamp(ts)
 
 		pr, numDroppedLogs := exp.logDataToLoki(logs)
-		expectedPr := &logproto.PushRequest{Streams: []logproto.Stream{}}
+		expectedPr := &push.PushRequest{Streams: []push.Stream{}}
 		require.Equal(t, 1, numDroppedLogs)
 		require.Equal(t, expectedPr, pr)
 	})
@@ -586,7 +586,7 @@ func TestExporter_convertLogBodyToEntry(t *testing.T) {
 	}, componenttest.NewNopTelemetrySettings())
 	entry, _ := exp.convertLogBodyToEntry(lr, res)
 
-	expEntry := &logproto.Entry{
+	expEntry := &push.Entry{
 		Timestamp: time.Unix(0, int64(lr.Timestamp())),
 		Line:      "severity=DEBUG severityN=5 traceID=01020304000000000000000000000000 spanID=0506070800000000 host.name=something Payment succeeded",
 	}
@@ -610,16 +610,16 @@ func TestExporter_encode(t *testing.T) {
 		labels := model.LabelSet{
 			model.LabelName("container_name"): model.LabelValue("mycontainer"),
 		}
-		entry := &logproto.Entry{
+		entry := &push.Entry{
 			Timestamp: time.Now(),
 			Line:      "log message",
 		}
-		stream := logproto.Stream{
+		stream := push.Stream{
 			Labels:  labels.String(),
-			Entries: []logproto.Entry{*entry},
+			Entries: []push.Entry{*entry},
 		}
-		pr := &logproto.PushRequest{
-			Streams: []logproto.Stream{stream},
+		pr := &push.PushRequest{
+			Streams: []push.Stream{stream},
 		}
 
 		req, err := encode(pr)
@@ -693,7 +693,7 @@ func TestExporter_convertLogtoJSONEntry(t *testing.T) {
 
 	exp := newLegacyExporter(&Config{}, componenttest.NewNopTelemetrySettings())
 	entry, err := exp.convertLogToJSONEntry(lr, res)
-	expEntry := &logproto.Entry{
+	expEntry := &push.Entry{
 		Timestamp: time.Unix(0, int64(lr.Timestamp())),
 		Line:      `{"body":"log message","resources":{"host.name":"something"}}`,
 	}
@@ -0,0 +1,50 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	mRejectedStreams    = stats.Int64("lokiexporter_rejected_streams", "Number of new streams refused because the tenant reached stream_limit.max_streams", stats.UnitDimensionless)
+	mRejectedLogRecords = stats.Int64("lokiexporter_rejected_log_records", "Number of log records dropped because their stream was refused", stats.UnitDimensionless)
+
+	exporterTagKey = tag.MustNewKey("exporter")
+	tenantTagKey   = tag.MustNewKey("tenant")
+)
+
+// MetricViews returns the metrics views of the Loki exporter.
+func MetricViews() []*view.View {
+	tagKeys := []tag.Key{exporterTagKey, tenantTagKey}
+	return []*view.View{
+		{
+			Name:        mRejectedStreams.Name(),
+			Measure:     mRejectedStreams,
+			Description: mRejectedStreams.Description(),
+			Aggregation: view.Sum(),
+			TagKeys:     tagKeys,
+		},
+		{
+			Name:        mRejectedLogRecords.Name(),
+			Measure:     mRejectedLogRecords,
+			Description: mRejectedLogRecords.Description(),
+			Aggregation: view.Sum(),
+			TagKeys:     tagKeys,
+		},
+	}
+}
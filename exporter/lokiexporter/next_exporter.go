@@ -21,10 +21,15 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"sync"
 
+	"github.com/prometheus/common/model"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
@@ -37,19 +42,31 @@ type nextLokiExporter struct {
 	settings component.TelemetrySettings
 	client   *http.Client
 	wg       sync.WaitGroup
+
+	// shards is nil when stream sharding is disabled
+	shards *shardTracker
+	// limiter is nil when the number of streams isn't limited
+	limiter *streamLimiter
 }
 
 func newNextExporter(config *Config, settings component.TelemetrySettings) *nextLokiExporter {
 	settings.Logger.Info("using the new Loki exporter")
 
-	return &nextLokiExporter{
+	exp := &nextLokiExporter{
 		config:   config,
 		settings: settings,
 	}
+	if config.StreamSharding.Enabled {
+		exp.shards = newShardTracker(config.StreamSharding.DesiredRate)
+	}
+	if config.StreamLimit.MaxStreams > 0 {
+		exp.limiter = newStreamLimiter(config.StreamLimit.MaxStreams, config.StreamLimit.IdleTimeout)
+	}
+	return exp
 }
 
 func (l *nextLokiExporter) pushLogData(ctx context.Context, ld plog.Logs) error {
-	requests := loki.LogsToLokiRequests(ld)
+	requests := l.logsToRequests(ctx, ld)
 
 	var errs error
 	for tenant, request := range requests {
@@ -60,10 +77,153 @@ func (l *nextLokiExporter) pushLogData(ctx context.Context, ld plog.Logs) error
 	return errs
 }
 
-func (l *nextLokiExporter) sendPushRequest(ctx context.Context, tenant string, request loki.PushRequest, ld plog.Logs) error {
-	pushReq := request.PushRequest
-	report := request.Report
-	if len(pushReq.Streams) == 0 {
+// logsToRequests groups the log records in streams per tenant, like loki.LogsToLokiRequests
+// does, and in addition extracts the structured metadata, applies the stream limit and
+// shards the streams above the desired rate.
+func (l *nextLokiExporter) logsToRequests(ctx context.Context, ld plog.Logs) map[string]*pushRequest {
+	now := timeNow()
+	requests := map[string]*pushRequest{}
+
+	var keys []streamKey
+	streams := map[streamKey]*pushStream{}
+	labelSets := map[streamKey]model.LabelSet{}
+	rejected := map[streamKey]int{}
+
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		ills := rls.At(i).ScopeLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).LogRecords()
+			for k := 0; k < logs.Len(); k++ {
+				log, resource := logs.At(k), rls.At(i).Resource()
+
+				tenant := loki.GetTenantFromTenantHint(log.Attributes(), resource.Attributes())
+				request, ok := requests[tenant]
+				if !ok {
+					request = &pushRequest{report: &loki.PushReport{}}
+					requests[tenant] = request
+				}
+
+				log, resource, metadata := l.extractStructuredMetadata(log, resource)
+				entry, err := loki.LogToLokiEntry(log, resource)
+				if err != nil {
+					// Couldn't convert so dropping log.
+					request.report.Errors = append(request.report.Errors, fmt.Errorf("failed to convert, dropping log: %w", err))
+					request.report.NumDropped++
+					continue
+				}
+
+				key := streamKey{tenant: tenant, labels: entry.Labels.String()}
+				stream, ok := streams[key]
+				if !ok {
+					if _, refused := rejected[key]; refused || (l.limiter != nil && !l.limiter.allow(key, now)) {
+						rejected[key]++
+						continue
+					}
+					stream = &pushStream{labels: key.labels}
+					streams[key] = stream
+					labelSets[key] = entry.Labels
+					keys = append(keys, key)
+				}
+
+				request.report.NumSubmitted++
+				stream.entries = append(stream.entries, pushEntry{Entry: *entry.Entry, metadata: metadata})
+			}
+		}
+	}
+
+	for _, key := range keys {
+		stream := streams[key]
+		request := requests[key.tenant]
+		if l.shards == nil {
+			request.streams = append(request.streams, stream)
+			continue
+		}
+
+		size := 0
+		for _, e := range stream.entries {
+			size += e.size()
+		}
+		request.streams = append(request.streams, shardStream(labelSets[key], stream, l.shards.shards(key, size, now))...)
+	}
+
+	for key, dropped := range rejected {
+		l.settings.Logger.Warn(
+			"dropping the log records of a new stream, the tenant reached the maximum number of streams",
+			zap.String("tenant", key.tenant),
+			zap.String("labels", key.labels),
+			zap.Int("dropped", dropped),
+		)
+		_ = stats.RecordWithTags(
+			ctx,
+			[]tag.Mutator{tag.Upsert(exporterTagKey, l.config.ID().String()), tag.Upsert(tenantTagKey, key.tenant)},
+			mRejectedStreams.M(1),
+			mRejectedLogRecords.M(int64(dropped)),
+		)
+	}
+
+	return requests
+}
+
+// extractStructuredMetadata moves the configured structured metadata attributes out of
+// copies of the log record and resource. Log record attributes take precedence over
+// resource attributes of the same name.
+func (l *nextLokiExporter) extractStructuredMetadata(log plog.LogRecord, resource pcommon.Resource) (plog.LogRecord, pcommon.Resource, []labelPair) {
+	if len(l.config.StructuredMetadata) == 0 {
+		return log, resource, nil
+	}
+
+	logCopy := plog.NewLogRecord()
+	log.CopyTo(logCopy)
+	resourceCopy := pcommon.NewResource()
+	resource.CopyTo(resourceCopy)
+
+	var metadata []labelPair
+	for _, name := range l.config.StructuredMetadata {
+		v, ok := logCopy.Attributes().Get(name)
+		if !ok {
+			v, ok = resourceCopy.Attributes().Get(name)
+		}
+		if !ok {
+			continue
+		}
+		metadata = append(metadata, labelPair{name: name, value: v.AsString()})
+		logCopy.Attributes().Remove(name)
+		resourceCopy.Attributes().Remove(name)
+	}
+	return logCopy, resourceCopy, metadata
+}
+
+// shardStream spreads the entries of a stream over n shards, which are labeled
+// with their shard number.
+func shardStream(labels model.LabelSet, stream *pushStream, n int) []*pushStream {
+	if n <= 1 {
+		return []*pushStream{stream}
+	}
+	if n > len(stream.entries) {
+		n = len(stream.entries)
+	}
+
+	shards := make([]*pushStream, n)
+	for i := range shards {
+		shardLabels := labels.Clone()
+		shardLabels[streamShardLabel] = model.LabelValue(strconv.Itoa(i))
+		shards[i] = &pushStream{labels: shardLabels.String()}
+	}
+	for i, e := range stream.entries {
+		shard := shards[i%n]
+		shard.entries = append(shard.entries, e)
+	}
+	return shards
+}
+
+func (l *nextLokiExporter) sendPushRequest(ctx context.Context, tenant string, request *pushRequest, ld plog.Logs) error {
+	report := request.report
+	if len(request.streams) == 0 {
+		if len(report.Errors) == 0 {
+			// all the log records were refused by the stream limit
+			return nil
+		}
 		return consumererror.NewPermanent(fmt.Errorf("failed to transform logs into Loki log streams"))
 	}
 	if len(report.Errors) > 0 {
@@ -74,11 +234,7 @@ func (l *nextLokiExporter) sendPushRequest(ctx context.Context, tenant string, r
 		)
 	}
 
-	buf, err := encode(pushReq)
-	if err != nil {
-		return consumererror.NewPermanent(err)
-	}
-
+	buf := request.encode()
 	req, err := http.NewRequestWithContext(ctx, "POST", l.config.HTTPClientSettings.Endpoint, bytes.NewReader(buf))
 	if err != nil {
 		return consumererror.NewPermanent(err)
@@ -20,11 +20,13 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/golang/snappy"
-	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/push"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
@@ -68,7 +70,7 @@ func TestPushLogData(t *testing.T) {
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
-			actualPushRequest := &logproto.PushRequest{}
+			actualPushRequest := &push.PushRequest{}
 
 			// prepare
 			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -244,7 +246,7 @@ func TestLogsToLokiRequestWithGroupingByTenant(t *testing.T) {
 	}
 	for _, tC := range tests {
 		t.Run(tC.desc, func(t *testing.T) {
-			actualPushRequestPerTenant := map[string]*logproto.PushRequest{}
+			actualPushRequestPerTenant := map[string]*push.PushRequest{}
 
 			// prepare
 			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -254,7 +256,7 @@ func TestLogsToLokiRequestWithGroupingByTenant(t *testing.T) {
 				decPayload, err := snappy.Decode(nil, encPayload)
 				require.NoError(t, err)
 
-				pr := &logproto.PushRequest{}
+				pr := &push.PushRequest{}
 				err = proto.Unmarshal(decPayload, pr)
 				require.NoError(t, err)
 
@@ -300,3 +302,68 @@ func TestLogsToLokiRequestWithGroupingByTenant(t *testing.T) {
 		})
 	}
 }
+
+func TestPushLogDataStreams(t *testing.T) {
+	var payloads [][]byte
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encPayload, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		decPayload, err := snappy.Decode(nil, encPayload)
+		require.NoError(t, err)
+		payloads = append(payloads, decPayload)
+	}))
+	defer ts.Close()
+
+	cfg := createDefaultLegacyConfig().(*Config)
+	cfg.Endpoint = ts.URL
+	cfg.QueueSettings.Enabled = false
+	cfg.StructuredMetadata = []string{"user.id"}
+	cfg.StreamSharding = StreamShardingSettings{Enabled: true, DesiredRate: 100}
+	cfg.StreamLimit = StreamLimitSettings{MaxStreams: 1, IdleTimeout: time.Hour}
+
+	f := NewFactory()
+	exp, err := f.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for i := 0; i < 4; i++ {
+		lr := records.AppendEmpty()
+		lr.Body().SetStr(fmt.Sprintf("%d: %s", i, strings.Repeat("x", 60)))
+		// user.id is sent as structured metadata although the hint makes it a label
+		lr.Attributes().PutStr("loki.attribute.labels", "user.id")
+		lr.Attributes().PutStr("user.id", fmt.Sprint(i))
+	}
+	// a second stream is above the limit and is dropped
+	lr := records.AppendEmpty()
+	lr.Body().SetStr("dropped")
+	lr.Attributes().PutStr("loki.attribute.labels", "http.method")
+	lr.Attributes().PutStr("http.method", "GET")
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), ld))
+	require.Len(t, payloads, 1)
+
+	pr := &push.PushRequest{}
+	require.NoError(t, proto.Unmarshal(payloads[0], pr))
+
+	// 4 entries of about 80 bytes over one second at a desired rate of 100 bytes per second
+	require.Len(t, pr.Streams, 4)
+	var lines []string
+	for i, stream := range pr.Streams {
+		assert.Equal(t, fmt.Sprintf(`{__stream_shard__="%d", exporter="OTLP"}`, i), stream.Labels)
+		require.Len(t, stream.Entries, 1)
+		lines = append(lines, stream.Entries[0].Line)
+	}
+	for i, line := range lines {
+		assert.Equal(t, fmt.Sprintf(`{"body":"%d: %s"}`, i, strings.Repeat("x", 60)), line)
+	}
+
+	stream := field(t, payloads[0], 1)
+	entry := field(t, stream, 2)
+	assert.Equal(t, []labelPair{{name: "user.id", value: "0"}}, decodeMetadata(t, entry))
+}
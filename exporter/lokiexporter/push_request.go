@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"github.com/golang/snappy"
+	"github.com/grafana/loki/pkg/push"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
+)
+
+// pushRequest holds the streams sent to a single tenant.
+type pushRequest struct {
+	streams []*pushStream
+	report  *loki.PushReport
+}
+
+type pushStream struct {
+	labels  string
+	entries []pushEntry
+}
+
+// pushEntry is a Loki entry along with its structured metadata, which isn't part of
+// the push.Entry version this module depends on.
+type pushEntry struct {
+	push.Entry
+	metadata []labelPair
+}
+
+type labelPair struct {
+	name  string
+	value string
+}
+
+func (e pushEntry) size() int {
+	n := len(e.Line)
+	for _, m := range e.metadata {
+		n += len(m.name) + len(m.value)
+	}
+	return n
+}
+
+// encode marshals the push request following the Loki push.proto schema, and compresses it with snappy:
+//
+//	message PushRequest { repeated StreamAdapter streams = 1; }
+//	message StreamAdapter { string labels = 1; repeated EntryAdapter entries = 2; }
+//	message EntryAdapter {
+//	  google.protobuf.Timestamp timestamp = 1;
+//	  string line = 2;
+//	  repeated LabelPairAdapter structuredMetadata = 3;
+//	}
+//	message LabelPairAdapter { string name = 1; string value = 2; }
+func (r *pushRequest) encode() []byte {
+	var buf, stream, entry, msg []byte
+	for _, s := range r.streams {
+		stream = protowire.AppendTag(stream[:0], 1, protowire.BytesType)
+		stream = protowire.AppendString(stream, s.labels)
+		for _, e := range s.entries {
+			entry = entry[:0]
+
+			msg = msg[:0]
+			if secs := e.Timestamp.Unix(); secs != 0 {
+				msg = protowire.AppendTag(msg, 1, protowire.VarintType)
+				msg = protowire.AppendVarint(msg, uint64(secs))
+			}
+			if nanos := e.Timestamp.Nanosecond(); nanos != 0 {
+				msg = protowire.AppendTag(msg, 2, protowire.VarintType)
+				msg = protowire.AppendVarint(msg, uint64(nanos))
+			}
+			entry = protowire.AppendTag(entry, 1, protowire.BytesType)
+			entry = protowire.AppendBytes(entry, msg)
+
+			if e.Line != "" {
+				entry = protowire.AppendTag(entry, 2, protowire.BytesType)
+				entry = protowire.AppendString(entry, e.Line)
+			}
+
+			for _, m := range e.metadata {
+				msg = protowire.AppendTag(msg[:0], 1, protowire.BytesType)
+				msg = protowire.AppendString(msg, m.name)
+				msg = protowire.AppendTag(msg, 2, protowire.BytesType)
+				msg = protowire.AppendString(msg, m.value)
+				entry = protowire.AppendTag(entry, 3, protowire.BytesType)
+				entry = protowire.AppendBytes(entry, msg)
+			}
+
+			stream = protowire.AppendTag(stream, 2, protowire.BytesType)
+			stream = protowire.AppendBytes(stream, entry)
+		}
+		buf = protowire.AppendTag(buf, 1, protowire.BytesType)
+		buf = protowire.AppendBytes(buf, stream)
+	}
+	return snappy.Encode(nil, buf)
+}
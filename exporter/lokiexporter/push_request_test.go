@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/golang/snappy"
+	"github.com/grafana/loki/pkg/push"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestPushRequestEncodeMatchesLogproto(t *testing.T) {
+	ts := time.Unix(1670000000, 123456789)
+	request := &pushRequest{
+		streams: []*pushStream{
+			{
+				labels: `{exporter="OTLP"}`,
+				entries: []pushEntry{
+					{Entry: push.Entry{Timestamp: ts, Line: "first"}},
+					{Entry: push.Entry{Timestamp: ts.Add(time.Second), Line: "second"}},
+				},
+			},
+			{
+				labels:  `{exporter="OTLP", level="ERROR"}`,
+				entries: []pushEntry{{Entry: push.Entry{Timestamp: time.Unix(0, 0)}}},
+			},
+		},
+	}
+
+	expected, err := proto.Marshal(&push.PushRequest{
+		Streams: []push.Stream{
+			{
+				Labels: `{exporter="OTLP"}`,
+				Entries: []push.Entry{
+					{Timestamp: ts, Line: "first"},
+					{Timestamp: ts.Add(time.Second), Line: "second"},
+				},
+			},
+			{
+				Labels:  `{exporter="OTLP", level="ERROR"}`,
+				Entries: []push.Entry{{Timestamp: time.Unix(0, 0)}},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	actual, err := snappy.Decode(nil, request.encode())
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestPushRequestEncodeStructuredMetadata(t *testing.T) {
+	request := &pushRequest{
+		streams: []*pushStream{
+			{
+				labels: `{exporter="OTLP"}`,
+				entries: []pushEntry{
+					{
+						Entry:    push.Entry{Timestamp: time.Unix(1670000000, 0), Line: "line"},
+						metadata: []labelPair{{name: "trace_id", value: "0102"}, {name: "user.id", value: "42"}},
+					},
+				},
+			},
+		},
+	}
+
+	buf, err := snappy.Decode(nil, request.encode())
+	require.NoError(t, err)
+
+	// the metadata is ignored by the push version this module depends on
+	pr := &push.PushRequest{}
+	require.NoError(t, proto.Unmarshal(buf, pr))
+	require.Len(t, pr.Streams, 1)
+	assert.Equal(t, "line", pr.Streams[0].Entries[0].Line)
+
+	stream := field(t, buf, 1)
+	entry := field(t, stream, 2)
+	assert.Equal(t, []labelPair{{name: "trace_id", value: "0102"}, {name: "user.id", value: "42"}}, decodeMetadata(t, entry))
+}
+
+// field returns the first value of the length-delimited field num.
+func field(t *testing.T, buf []byte, num protowire.Number) []byte {
+	for len(buf) > 0 {
+		n, typ, l := protowire.ConsumeTag(buf)
+		require.GreaterOrEqual(t, l, 0)
+		buf = buf[l:]
+		if typ != protowire.BytesType {
+			l = protowire.ConsumeFieldValue(n, typ, buf)
+			require.GreaterOrEqual(t, l, 0)
+			buf = buf[l:]
+			continue
+		}
+		v, l := protowire.ConsumeBytes(buf)
+		require.GreaterOrEqual(t, l, 0)
+		if n == num {
+			return v
+		}
+		buf = buf[l:]
+	}
+	t.Fatalf("field %d not found", num)
+	return nil
+}
+
+func decodeMetadata(t *testing.T, entry []byte) []labelPair {
+	var pairs []labelPair
+	for len(entry) > 0 {
+		n, typ, l := protowire.ConsumeTag(entry)
+		require.GreaterOrEqual(t, l, 0)
+		entry = entry[l:]
+		l = protowire.ConsumeFieldValue(n, typ, entry)
+		require.GreaterOrEqual(t, l, 0)
+		if n == 3 {
+			pair, _ := protowire.ConsumeBytes(entry)
+			pairs = append(pairs, labelPair{name: string(field(t, pair, 1)), value: string(field(t, pair, 2))})
+		}
+		entry = entry[l:]
+	}
+	return pairs
+}
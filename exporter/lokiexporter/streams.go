@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// streamShardLabel is the label Loki itself uses for the shards of a stream.
+	streamShardLabel = "__stream_shard__"
+
+	// shardingWindow is the period over which the rate of a stream is measured.
+	shardingWindow = 10 * time.Second
+
+	defaultShardDesiredRate  = 1 << 20
+	defaultStreamIdleTimeout = time.Hour
+)
+
+// streamKey identifies a stream of a tenant, by its labels before sharding.
+type streamKey struct {
+	tenant string
+	labels string
+}
+
+// shardTracker measures the rate of each stream to decide over how many shards its
+// entries are spread.
+type shardTracker struct {
+	desiredRate float64
+
+	mu        sync.Mutex
+	streams   map[streamKey]*streamRate
+	lastSweep time.Time
+}
+
+type streamRate struct {
+	windowStart time.Time
+	// bytes received since windowStart
+	bytes int
+	// rate in bytes per second over the previous window
+	rate float64
+}
+
+func newShardTracker(desiredRate int64) *shardTracker {
+	return &shardTracker{
+		desiredRate: float64(desiredRate),
+		streams:     map[streamKey]*streamRate{},
+	}
+}
+
+// shards records size bytes for the stream and returns the number of shards the
+// stream needs, which is 1 while the stream is below the desired rate.
+func (t *shardTracker) shards(key streamKey, size int, now time.Time) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.sweep(now)
+
+	r, ok := t.streams[key]
+	if !ok {
+		r = &streamRate{windowStart: now}
+		t.streams[key] = r
+	}
+	if elapsed := now.Sub(r.windowStart); elapsed >= shardingWindow {
+		r.rate = float64(r.bytes) / elapsed.Seconds()
+		r.bytes = 0
+		r.windowStart = now
+	}
+	r.bytes += size
+
+	// a burst within the first second of a window counts as a rate over one second
+	elapsed := now.Sub(r.windowStart)
+	if elapsed < time.Second {
+		elapsed = time.Second
+	}
+	rate := math.Max(r.rate, float64(r.bytes)/elapsed.Seconds())
+
+	if n := int(math.Ceil(rate / t.desiredRate)); n > 1 {
+		return n
+	}
+	return 1
+}
+
+// sweep forgets the streams that didn't receive entries over the last two windows.
+func (t *shardTracker) sweep(now time.Time) {
+	if now.Sub(t.lastSweep) < shardingWindow {
+		return
+	}
+	t.lastSweep = now
+	for key, r := range t.streams {
+		if now.Sub(r.windowStart) >= 2*shardingWindow {
+			delete(t.streams, key)
+		}
+	}
+}
+
+// streamLimiter keeps track of the active streams of each tenant, and refuses new
+// streams once a tenant has reached the maximum number of active streams.
+type streamLimiter struct {
+	maxStreams  int
+	idleTimeout time.Duration
+
+	mu      sync.Mutex
+	tenants map[string]map[string]time.Time
+}
+
+func newStreamLimiter(maxStreams int, idleTimeout time.Duration) *streamLimiter {
+	return &streamLimiter{
+		maxStreams:  maxStreams,
+		idleTimeout: idleTimeout,
+		tenants:     map[string]map[string]time.Time{},
+	}
+}
+
+// allow reports whether entries can be sent to the stream, and marks it as active.
+func (l *streamLimiter) allow(key streamKey, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	streams, ok := l.tenants[key.tenant]
+	if !ok {
+		streams = map[string]time.Time{}
+		l.tenants[key.tenant] = streams
+	}
+
+	if _, ok := streams[key.labels]; !ok && len(streams) >= l.maxStreams {
+		for labels, lastSeen := range streams {
+			if now.Sub(lastSeen) >= l.idleTimeout {
+				delete(streams, labels)
+			}
+		}
+		if len(streams) >= l.maxStreams {
+			return false
+		}
+	}
+
+	streams[key.labels] = now
+	return true
+}
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShardTracker(t *testing.T) {
+	tracker := newShardTracker(1000)
+	key := streamKey{tenant: "acme", labels: `{exporter="OTLP"}`}
+	now := time.Unix(1670000000, 0)
+
+	// below the desired rate
+	assert.Equal(t, 1, tracker.shards(key, 500, now))
+	// 2500 bytes within the first second
+	assert.Equal(t, 3, tracker.shards(key, 2000, now.Add(500*time.Millisecond)))
+	// 2600 bytes over 2 seconds
+	assert.Equal(t, 2, tracker.shards(key, 100, now.Add(2*time.Second)))
+
+	// 30000 bytes over 5 seconds
+	assert.Equal(t, 6, tracker.shards(key, 27400, now.Add(5*time.Second)))
+	// the rate of the previous window, 30000 bytes over 10 seconds, is kept for the next one
+	assert.Equal(t, 3, tracker.shards(key, 10, now.Add(10*time.Second)))
+
+	// other streams aren't affected
+	assert.Equal(t, 1, tracker.shards(streamKey{tenant: "acme", labels: `{exporter="OTLP", level="INFO"}`}, 10, now.Add(10*time.Second)))
+
+	// idle streams are forgotten
+	assert.Equal(t, 1, tracker.shards(streamKey{tenant: "other"}, 10, now.Add(time.Minute)))
+	assert.Len(t, tracker.streams, 1)
+}
+
+func TestStreamLimiter(t *testing.T) {
+	limiter := newStreamLimiter(2, time.Minute)
+	now := time.Unix(1670000000, 0)
+
+	assert.True(t, limiter.allow(streamKey{tenant: "acme", labels: "a"}, now))
+	assert.True(t, limiter.allow(streamKey{tenant: "acme", labels: "b"}, now))
+	assert.False(t, limiter.allow(streamKey{tenant: "acme", labels: "c"}, now))
+
+	// known streams and other tenants are still accepted
+	assert.True(t, limiter.allow(streamKey{tenant: "acme", labels: "a"}, now.Add(30*time.Second)))
+	assert.True(t, limiter.allow(streamKey{tenant: "other", labels: "c"}, now))
+
+	// the idle stream "b" makes room for "c"
+	assert.True(t, limiter.allow(streamKey{tenant: "acme", labels: "c"}, now.Add(time.Minute)))
+	assert.False(t, limiter.allow(streamKey{tenant: "acme", labels: "b"}, now.Add(time.Minute)))
+}
@@ -0,0 +1,187 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunkhecexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/splunkhecexporter"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
+)
+
+var (
+	errAckTimeout  = errors.New("timed out waiting for the indexer acknowledgement")
+	errAckShutdown = errors.New("exporter shut down while waiting for the indexer acknowledgement")
+)
+
+// ackPoller polls the indexer acknowledgement endpoint for the ackIds of the requests sent
+// on the channel of the exporter. A single request is sent per token and poll interval,
+// whatever the number of requests waiting for their acknowledgement.
+type ackPoller struct {
+	client   *http.Client
+	url      string
+	headers  map[string]string
+	interval time.Duration
+	logger   *zap.Logger
+
+	mu sync.Mutex
+	// pending holds the ackIds waiting to be indexed per Authorization header, as the
+	// token of a request can be overridden by the resource attributes.
+	pending map[string]map[uint64]chan struct{}
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+func newAckPoller(client *http.Client, url string, headers map[string]string, interval time.Duration, logger *zap.Logger) *ackPoller {
+	return &ackPoller{
+		client:   client,
+		url:      url,
+		headers:  headers,
+		interval: interval,
+		logger:   logger,
+		pending:  map[string]map[uint64]chan struct{}{},
+		stopCh:   make(chan struct{}),
+	}
+}
+
+func (p *ackPoller) start() {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.poll()
+			case <-p.stopCh:
+				return
+			}
+		}
+	}()
+}
+
+func (p *ackPoller) stop() {
+	close(p.stopCh)
+	p.wg.Wait()
+}
+
+// wait blocks until the events of the request with the given ackId are indexed, or the timeout expires.
+func (p *ackPoller) wait(ctx context.Context, authorization string, ackID uint64, timeout time.Duration) error {
+	indexed := make(chan struct{})
+	p.mu.Lock()
+	acks, ok := p.pending[authorization]
+	if !ok {
+		acks = map[uint64]chan struct{}{}
+		p.pending[authorization] = acks
+	}
+	acks[ackID] = indexed
+	p.mu.Unlock()
+
+	defer func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		delete(acks, ackID)
+		if len(acks) == 0 {
+			delete(p.pending, authorization)
+		}
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-indexed:
+		return nil
+	case <-timer.C:
+		return errAckTimeout
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-p.stopCh:
+		return errAckShutdown
+	}
+}
+
+func (p *ackPoller) poll() {
+	p.mu.Lock()
+	requests := make(map[string][]uint64, len(p.pending))
+	for authorization, acks := range p.pending {
+		for ackID := range acks {
+			requests[authorization] = append(requests[authorization], ackID)
+		}
+	}
+	p.mu.Unlock()
+
+	for authorization, ackIDs := range requests {
+		resp, err := p.query(authorization, ackIDs)
+		if err != nil {
+			p.logger.Debug("Failed to poll the indexer acknowledgement endpoint", zap.Error(err))
+			continue
+		}
+
+		p.mu.Lock()
+		for ackID, indexed := range resp.Acks {
+			if ch, ok := p.pending[authorization][ackID]; ok && indexed {
+				close(ch)
+				delete(p.pending[authorization], ackID)
+			}
+		}
+		p.mu.Unlock()
+	}
+}
+
+func (p *ackPoller) query(authorization string, ackIDs []uint64) (*splunk.AckResponse, error) {
+	body, err := jsoniter.Marshal(splunk.AckRequest{Acks: ackIDs})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range p.headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set("Authorization", authorization)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if err = splunk.HandleHTTPCode(resp); err != nil {
+		return nil, err
+	}
+
+	ackResp := &splunk.AckResponse{}
+	if err = jsoniter.NewDecoder(resp.Body).Decode(ackResp); err != nil {
+		return nil, err
+	}
+	return ackResp, nil
+}
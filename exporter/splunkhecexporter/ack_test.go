@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunkhecexporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/splunk"
+)
+
+// ackServer is a HEC endpoint with indexer acknowledgement, where the events of a request
+// are indexed after a number of polls.
+type ackServer struct {
+	*httptest.Server
+	pollsToIndex int
+	noAckID      bool
+	// padding is written after the event responses.
+	padding int
+
+	mu          sync.Mutex
+	nextID      uint64
+	polls       map[uint64]int
+	channels    map[string]bool
+	events      int
+	connections int
+}
+
+func newAckServer(t *testing.T, pollsToIndex int) *ackServer {
+	s := &ackServer{pollsToIndex: pollsToIndex, polls: map[uint64]int{}, channels: map[string]bool{}}
+	s.Server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.channels[r.Header.Get(splunk.HTTPSplunkChannelHeader)] = true
+		assert.Equal(t, "Splunk 1234", r.Header.Get("Authorization"))
+
+		if r.URL.Path == splunk.DefaultAckPath {
+			var req splunk.AckRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			resp := splunk.AckResponse{Acks: map[uint64]bool{}}
+			for _, id := range req.Acks {
+				s.polls[id]++
+				resp.Acks[id] = s.pollsToIndex >= 0 && s.polls[id] >= s.pollsToIndex
+			}
+			assert.NoError(t, json.NewEncoder(w).Encode(resp))
+			return
+		}
+
+		_, _ = io.Copy(io.Discard, r.Body)
+		s.events++
+		resp := splunk.EventResponse{Text: "Success"}
+		if !s.noAckID {
+			id := s.nextID
+			s.nextID++
+			resp.AckID = &id
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(resp))
+		_, _ = w.Write(bytes.Repeat([]byte(" "), s.padding))
+	}))
+	s.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			s.mu.Lock()
+			s.connections++
+			s.mu.Unlock()
+		}
+	}
+	s.Start()
+	t.Cleanup(s.Close)
+	return s
+}
+
+func newAckConfig(endpoint string) *Config {
+	cfg := NewFactory().CreateDefaultConfig().(*Config)
+	cfg.Endpoint = endpoint + "/services/collector"
+	cfg.Token = "1234"
+	cfg.QueueSettings.Enabled = false
+	cfg.RetrySettings.Enabled = false
+	cfg.Ack.Enabled = true
+	cfg.Ack.PollInterval = 10 * time.Millisecond
+	cfg.Ack.Timeout = 5 * time.Second
+	return cfg
+}
+
+func TestAckIndexed(t *testing.T) {
+	server := newAckServer(t, 3)
+	cfg := newAckConfig(server.URL)
+	// several requests wait for their acknowledgement
+	cfg.MaxContentLengthLogs = 1000
+	cfg.DisableCompression = true
+
+	exp, err := NewFactory().CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), createLogData(1, 1, 10)))
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	assert.Greater(t, server.events, 1)
+	for id := uint64(0); id < server.nextID; id++ {
+		assert.Equal(t, 3, server.polls[id])
+	}
+	// a single random channel is used for events and acknowledgements
+	assert.Len(t, server.channels, 1)
+	assert.NotContains(t, server.channels, "")
+}
+
+func TestAckReusesConnections(t *testing.T) {
+	server := newAckServer(t, 1)
+	// the responses are read beyond the JSON object
+	server.padding = 64 * 1024
+	cfg := newAckConfig(server.URL)
+	cfg.MaxContentLengthLogs = 1000
+	cfg.DisableCompression = true
+
+	exp, err := NewFactory().CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), createLogData(1, 1, 20)))
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	assert.Greater(t, server.events, 3)
+	// the events and the acknowledgement polls are sent one after the other
+	assert.Equal(t, 1, server.connections)
+}
+
+func TestAckTimeout(t *testing.T) {
+	server := newAckServer(t, -1)
+	cfg := newAckConfig(server.URL)
+	cfg.Ack.Channel = "my-channel"
+	cfg.Ack.Timeout = 100 * time.Millisecond
+
+	exp, err := NewFactory().CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+
+	logs := createLogData(1, 1, 2)
+	err = exp.ConsumeLogs(context.Background(), logs)
+	assert.ErrorIs(t, err, errAckTimeout)
+	// the logs can be retried
+	assert.False(t, consumererror.IsPermanent(err))
+	var logsErr consumererror.Logs
+	require.True(t, errors.As(err, &logsErr))
+	assert.Equal(t, logs, logsErr.GetLogs())
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	assert.Equal(t, map[string]bool{"my-channel": true}, server.channels)
+	assert.Greater(t, server.polls[0], 0)
+}
+
+func TestAckMissingAckID(t *testing.T) {
+	server := newAckServer(t, 1)
+	server.noAckID = true
+
+	exp, err := NewFactory().CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), newAckConfig(server.URL))
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}()
+
+	err = exp.ConsumeLogs(context.Background(), createLogData(1, 1, 2))
+	assert.True(t, consumererror.IsPermanent(err))
+}
+
+func TestAckShutdown(t *testing.T) {
+	server := newAckServer(t, -1)
+	poller := newAckPoller(server.Client(), server.URL+splunk.DefaultAckPath, map[string]string{"Authorization": "Splunk 1234"}, 10*time.Millisecond, componenttest.NewNopTelemetrySettings().Logger)
+	poller.start()
+
+	errCh := make(chan error)
+	go func() {
+		errCh <- poller.wait(context.Background(), "Splunk 1234", 0, time.Minute)
+	}()
+	assert.Eventually(t, func() bool {
+		server.mu.Lock()
+		defer server.mu.Unlock()
+		return server.polls[0] > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	poller.stop()
+	assert.ErrorIs(t, <-errCh, errAckShutdown)
+	assert.Empty(t, poller.pending)
+}
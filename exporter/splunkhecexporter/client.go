@@ -53,6 +53,8 @@ type client struct {
 	wg             sync.WaitGroup
 	headers        map[string]string
 	gzipWriterPool *sync.Pool
+	// acks is nil when indexer acknowledgement is disabled
+	acks *ackPoller
 }
 
 // bufferState encapsulates intermediate buffer state when pushing data
@@ -669,8 +671,23 @@ func (c *client) postEvents(ctx context.Context, events io.Reader, headers map[s
 		return err
 	}
 
-	_, errCopy := io.Copy(io.Discard, resp.Body)
-	return multierr.Combine(err, errCopy)
+	if c.acks == nil {
+		_, errCopy := io.Copy(io.Discard, resp.Body)
+		return multierr.Combine(err, errCopy)
+	}
+
+	var eventResp splunk.EventResponse
+	err = jsoniter.NewDecoder(resp.Body).Decode(&eventResp)
+	// The rest of the body is read so that the connection is reused while the
+	// acknowledgement is awaited.
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to decode the HEC response: %w", err)
+	}
+	if eventResp.AckID == nil {
+		return consumererror.NewPermanent(errors.New("the HEC response has no ackId, indexer acknowledgement must be enabled on the token"))
+	}
+	return c.acks.wait(ctx, req.Header.Get("Authorization"), *eventResp.AckID, c.config.Ack.Timeout)
 }
 
 // subLogs returns a subset of `ld` starting from `profilingBufFront` for profiling data
@@ -835,10 +852,16 @@ func subTracesByType(src ptrace.Traces, from *index, dst ptrace.Traces) {
 }
 
 func (c *client) stop(context.Context) error {
+	if c.acks != nil {
+		c.acks.stop()
+	}
 	c.wg.Wait()
 	return nil
 }
 
 func (c *client) start(context.Context, component.Host) (err error) {
+	if c.acks != nil {
+		c.acks.start()
+	}
 	return nil
 }
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"time"
 
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtls"
@@ -36,8 +37,30 @@ const (
 	maxContentLengthLogsLimit        = 800 * 1024 * 1024
 	maxContentLengthMetricsLimit     = 800 * 1024 * 1024
 	maxContentLengthTracesLimit      = 800 * 1024 * 1024
+	defaultAckPollInterval           = time.Second
+	defaultAckTimeout                = time.Minute
 )
 
+// AckSettings defines the indexer acknowledgement settings, see https://docs.splunk.com/Documentation/Splunk/latest/Data/AboutHECIDXAck.
+type AckSettings struct {
+	// Enabled makes the exporter wait for the events of each request to be indexed before reporting them as exported.
+	// Indexer acknowledgement must be enabled on the HEC token.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Path is the path of the indexer acknowledgement endpoint on the Splunk instance. Defaults to "/services/collector/ack".
+	Path string `mapstructure:"path"`
+
+	// Channel is the channel identifier sent with the requests. Defaults to a random UUID.
+	Channel string `mapstructure:"channel"`
+
+	// PollInterval is the interval between two polls of the indexer acknowledgement endpoint. Defaults to 1s.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+
+	// Timeout is the maximum duration to wait for the events of a request to be indexed, after which the request
+	// is retried according to the retry_on_failure settings. Defaults to 1m.
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
 // OtelToHecFields defines the mapping of attributes to HEC fields
 type OtelToHecFields struct {
 	// SeverityText informs the exporter to map the severity text field to a specific HEC field.
@@ -107,6 +130,8 @@ type Config struct {
 	HecToOtelAttrs splunk.HecToOtelAttrs `mapstructure:"hec_metadata_to_otel_attrs"`
 	// HecFields creates a mapping from attributes to HEC fields.
 	HecFields OtelToHecFields `mapstructure:"otel_to_hec_fields"`
+	// Ack defines the indexer acknowledgement settings.
+	Ack AckSettings `mapstructure:"ack"`
 }
 
 func (cfg *Config) getOptionsFromConfig() (*exporterOptions, error) {
@@ -170,5 +195,16 @@ func (cfg *Config) Validate() error {
 	if !cfg.LogDataEnabled && !cfg.ProfilingDataEnabled {
 		return errors.New(`either "log_data_enabled" or "profiling_data_enabled" has to be true`)
 	}
+	if cfg.Ack.Enabled {
+		if cfg.Ack.Path == "" {
+			return errors.New(`requires a non-empty "ack.path"`)
+		}
+		if cfg.Ack.PollInterval <= 0 {
+			return errors.New(`requires "ack.poll_interval" > 0`)
+		}
+		if cfg.Ack.Timeout <= 0 {
+			return errors.New(`requires "ack.timeout" > 0`)
+		}
+	}
 	return nil
 }
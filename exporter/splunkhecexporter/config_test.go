@@ -100,6 +100,13 @@ func TestLoadConfig(t *testing.T) {
 					SeverityNumber: "myseveritynumfield",
 					Name:           "mynamefield",
 				},
+				Ack: AckSettings{
+					Enabled:      true,
+					Path:         "/services/collector/ack",
+					Channel:      "8f1c5b1c-3c9e-4a57-8f4c-6f2e1b0d5a21",
+					PollInterval: 5 * time.Second,
+					Timeout:      2 * time.Minute,
+				},
 			},
 		},
 	}
@@ -225,3 +232,43 @@ func TestConfig_getOptionsFromConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigValidateAck(t *testing.T) {
+	tests := []struct {
+		name string
+		ack  AckSettings
+		err  string
+	}{
+		{
+			name: "disabled",
+			ack:  AckSettings{},
+		},
+		{
+			name: "empty path",
+			ack:  AckSettings{Enabled: true, PollInterval: time.Second, Timeout: time.Minute},
+			err:  `requires a non-empty "ack.path"`,
+		},
+		{
+			name: "no poll interval",
+			ack:  AckSettings{Enabled: true, Path: "/services/collector/ack", Timeout: time.Minute},
+			err:  `requires "ack.poll_interval" > 0`,
+		},
+		{
+			name: "no timeout",
+			ack:  AckSettings{Enabled: true, Path: "/services/collector/ack", PollInterval: time.Second},
+			err:  `requires "ack.timeout" > 0`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Ack = tt.ack
+			err := cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
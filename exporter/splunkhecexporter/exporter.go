@@ -25,6 +25,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -102,7 +103,7 @@ func buildClient(options *exporterOptions, config *Config, logger *zap.Logger) (
 	if err != nil {
 		return nil, fmt.Errorf("could not retrieve TLS config for Splunk HEC Exporter: %w", err)
 	}
-	return &client{
+	c := &client{
 		url: options.url,
 		client: &http.Client{
 			Timeout: config.Timeout,
@@ -132,5 +133,19 @@ func buildClient(options *exporterOptions, config *Config, logger *zap.Logger) (
 		gzipWriterPool: &sync.Pool{New: func() interface{} {
 			return gzip.NewWriter(nil)
 		}},
-	}, nil
+	}
+
+	if config.Ack.Enabled {
+		channel := config.Ack.Channel
+		if channel == "" {
+			channel = uuid.New().String()
+		}
+		c.headers[splunk.HTTPSplunkChannelHeader] = channel
+
+		ackURL := *options.url
+		ackURL.Path = config.Ack.Path
+		c.acks = newAckPoller(c.client, ackURL.String(), c.headers, config.Ack.PollInterval, logger)
+	}
+
+	return c, nil
 }
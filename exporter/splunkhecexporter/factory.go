@@ -86,6 +86,11 @@ func createDefaultConfig() component.ExporterConfig {
 			SeverityNumber: splunk.DefaultSeverityNumberLabel,
 			Name:           splunk.DefaultNameLabel,
 		},
+		Ack: AckSettings{
+			Path:         splunk.DefaultAckPath,
+			PollInterval: defaultAckPollInterval,
+			Timeout:      defaultAckTimeout,
+		},
 	}
 }
 
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apikeyauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/apikeyauthextension"
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+)
+
+var errNoKeysFile = errors.New("no keys file provided")
+
+// Config defines the configuration for the API key authenticator.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"`
+
+	// Filename is the path to the file holding the API keys. The file is watched,
+	// and the keys reloaded when it changes.
+	Filename string `mapstructure:"filename"`
+
+	// Header is the name of the request header holding the API key. Defaults to "Authorization".
+	Header string `mapstructure:"header"`
+
+	// Scheme is the auth-scheme prefixing the API key in the header, e.g. "Bearer".
+	// When empty, the whole header value is the API key. Defaults to "Bearer".
+	Scheme string `mapstructure:"scheme"`
+}
+
+var _ component.ExtensionConfig = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Filename == "" {
+		return errNoKeysFile
+	}
+	if cfg.Header == "" {
+		return errors.New("header must not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apikeyauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/apikeyauthextension"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.uber.org/zap"
+)
+
+// reloadDelay is the time waited after the last change of the keys file before reloading
+// it, so that the file isn't read while being written.
+const reloadDelay = 100 * time.Millisecond
+
+var (
+	errNoAuth              = errors.New("no API key provided")
+	errInvalidSchemePrefix = errors.New("invalid authorization scheme prefix")
+	errInvalidKey          = errors.New("invalid API key")
+)
+
+type apiKeyAuth struct {
+	filename string
+	header   string
+	scheme   string
+	logger   *zap.Logger
+
+	reloadDelay time.Duration
+
+	muKeys sync.RWMutex
+	keys   *keyStore
+
+	shutdownCH chan struct{}
+	wg         sync.WaitGroup
+}
+
+func newAPIKeyAuth(cfg *Config, logger *zap.Logger) *apiKeyAuth {
+	return &apiKeyAuth{
+		filename: cfg.Filename,
+		header:   cfg.Header,
+		scheme:   cfg.Scheme,
+		logger:   logger,
+
+		reloadDelay: reloadDelay,
+	}
+}
+
+func (a *apiKeyAuth) serverAuthenticator() configauth.ServerAuthenticator {
+	return configauth.NewServerAuthenticator(
+		configauth.WithStart(a.start),
+		configauth.WithShutdown(a.shutdown),
+		configauth.WithAuthenticate(a.authenticate),
+	)
+}
+
+// start loads the API keys, and starts a routine reloading them when the keys file changes.
+func (a *apiKeyAuth) start(_ context.Context, _ component.Host) error {
+	if err := a.loadKeys(); err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err = watcher.Add(a.filename); err != nil {
+		_ = watcher.Close()
+		return err
+	}
+
+	a.shutdownCH = make(chan struct{})
+	a.wg.Add(1)
+	go a.watch(watcher)
+	return nil
+}
+
+func (a *apiKeyAuth) watch(watcher *fsnotify.Watcher) {
+	defer a.wg.Done()
+	defer watcher.Close()
+	// reload fires once the file stopped changing for reloadDelay.
+	var reload <-chan time.Time
+	for {
+		select {
+		case <-a.shutdownCH:
+			return
+		case <-reload:
+			reload = nil
+			if err := a.loadKeys(); err != nil {
+				a.logger.Error("Failed to reload the keys file, keeping the previous keys", zap.Error(err))
+			}
+		case err, ok := <-watcher.Errors:
+			if ok {
+				a.logger.Error("Failed to watch the keys file", zap.Error(err))
+			}
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			// NOTE: k8s configmaps uses symlinks and replace the original file,
+			// and so do some editors, the watch has to be added again.
+			if event.Op&(fsnotify.Remove|fsnotify.Rename|fsnotify.Chmod) != 0 {
+				_ = watcher.Remove(event.Name)
+				if err := watcher.Add(a.filename); err != nil {
+					a.logger.Error("Failed to watch the keys file", zap.Error(err))
+				}
+			}
+			reload = time.After(a.reloadDelay)
+		}
+	}
+}
+
+func (a *apiKeyAuth) loadKeys() error {
+	data, err := os.ReadFile(a.filename)
+	if err != nil {
+		return fmt.Errorf("failed to read the keys file: %w", err)
+	}
+	keys, err := parseKeys(data)
+	if err != nil {
+		return err
+	}
+
+	a.muKeys.Lock()
+	a.keys = keys
+	a.muKeys.Unlock()
+	a.logger.Info("Loaded the API keys", zap.String("filename", a.filename), zap.Int("keys", keys.len()))
+	return nil
+}
+
+func (a *apiKeyAuth) shutdown(context.Context) error {
+	if a.shutdownCH != nil {
+		close(a.shutdownCH)
+		a.wg.Wait()
+		a.shutdownCH = nil
+	}
+	return nil
+}
+
+func (a *apiKeyAuth) authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	secret, err := a.apiKey(headers)
+	if err != nil {
+		return ctx, err
+	}
+
+	a.muKeys.RLock()
+	keys := a.keys
+	a.muKeys.RUnlock()
+
+	key := keys.match(secret)
+	if key == nil {
+		return ctx, errInvalidKey
+	}
+
+	cl := client.FromContext(ctx)
+	cl.Auth = &authData{key: key}
+	return client.NewContext(ctx, cl), nil
+}
+
+// apiKey returns the API key of the request, from the first value of the configured header.
+func (a *apiKeyAuth) apiKey(headers map[string][]string) (string, error) {
+	var values []string
+	for name, v := range headers {
+		if strings.EqualFold(name, a.header) {
+			values = v
+			break
+		}
+	}
+	if len(values) == 0 || values[0] == "" {
+		return "", errNoAuth
+	}
+
+	value := values[0]
+	if a.scheme == "" {
+		return value, nil
+	}
+	prefix := a.scheme + " "
+	if len(value) <= len(prefix) || !strings.EqualFold(value[:len(prefix)], prefix) {
+		return "", errInvalidSchemePrefix
+	}
+	return value[len(prefix):], nil
+}
+
+var _ client.AuthData = (*authData)(nil)
+
+// authData exposes the ID and the metadata of an API key.
+type authData struct {
+	key *apiKey
+}
+
+func (a *authData) GetAttribute(name string) interface{} {
+	if name == keyIDAttribute {
+		return a.key.id
+	}
+	return a.key.metadata[name]
+}
+
+func (a *authData) GetAttributeNames() []string {
+	return append([]string{keyIDAttribute}, a.key.metadataKeys...)
+}
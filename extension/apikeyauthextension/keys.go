@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apikeyauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/apikeyauthextension"
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// keyIDAttribute is the name of the auth attribute holding the ID of the API key.
+	keyIDAttribute = "key_id"
+
+	sha256Prefix = "sha256:"
+
+	// keyIDSeparator separates the ID of a bcrypt key from the key itself in the presented secret.
+	keyIDSeparator = "."
+)
+
+// keysFile is the content of the API keys file.
+type keysFile struct {
+	Keys []keyEntry `yaml:"keys"`
+}
+
+type keyEntry struct {
+	// ID identifies the API key, it is exposed as the "key_id" auth attribute.
+	ID string `yaml:"id"`
+	// Hash is the bcrypt hash of the API key, or its hex encoded SHA-256 digest prefixed with "sha256:".
+	Hash string `yaml:"hash"`
+	// Metadata are exposed as auth attributes of the clients using the API key.
+	Metadata map[string]interface{} `yaml:"metadata"`
+}
+
+type apiKey struct {
+	id           string
+	bcryptHash   []byte
+	metadata     map[string]interface{}
+	metadataKeys []string
+}
+
+// keyStore holds the API keys loaded from a version of the keys file.
+type keyStore struct {
+	sha256Keys map[string]*apiKey
+	// bcryptKeys are indexed by ID, as they are presented prefixed with their ID
+	// so that a single bcrypt hash is compared per request.
+	bcryptKeys map[string]*apiKey
+
+	// matched caches the bcrypt keys already matched, by SHA-256 digest of the
+	// presented API key, as comparing bcrypt hashes is purposely slow.
+	mu      sync.Mutex
+	matched map[string]*apiKey
+}
+
+// parseKeys parses the content of the API keys file.
+func parseKeys(data []byte) (*keyStore, error) {
+	var file keysFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse the keys file: %w", err)
+	}
+
+	store := &keyStore{
+		sha256Keys: map[string]*apiKey{},
+		bcryptKeys: map[string]*apiKey{},
+		matched:    map[string]*apiKey{},
+	}
+	ids := map[string]struct{}{}
+	for i, entry := range file.Keys {
+		if entry.ID == "" {
+			return nil, fmt.Errorf("keys[%d]: id must be specified", i)
+		}
+		if _, ok := ids[entry.ID]; ok {
+			return nil, fmt.Errorf("keys[%d]: duplicate id %q", i, entry.ID)
+		}
+		ids[entry.ID] = struct{}{}
+
+		key, err := newAPIKey(entry)
+		if err != nil {
+			return nil, fmt.Errorf("keys[%d]: %w", i, err)
+		}
+
+		if strings.HasPrefix(entry.Hash, sha256Prefix) {
+			digest := strings.ToLower(strings.TrimPrefix(entry.Hash, sha256Prefix))
+			if decoded, err := hex.DecodeString(digest); err != nil || len(decoded) != sha256.Size {
+				return nil, fmt.Errorf("keys[%d]: invalid sha256 hash", i)
+			}
+			store.sha256Keys[digest] = key
+			continue
+		}
+		if _, err := bcrypt.Cost([]byte(entry.Hash)); err != nil {
+			return nil, fmt.Errorf("keys[%d]: hash must be a bcrypt hash or a sha256 digest prefixed with %q", i, sha256Prefix)
+		}
+		if strings.Contains(entry.ID, keyIDSeparator) {
+			return nil, fmt.Errorf("keys[%d]: the id of a bcrypt key must not contain %q", i, keyIDSeparator)
+		}
+		key.bcryptHash = []byte(entry.Hash)
+		store.bcryptKeys[entry.ID] = key
+	}
+	// An empty file is most likely being written, e.g. truncated before being written,
+	// rather than meant to reject every client.
+	if store.len() == 0 {
+		return nil, errors.New("the keys file must contain at least one key")
+	}
+	return store, nil
+}
+
+func newAPIKey(entry keyEntry) (*apiKey, error) {
+	key := &apiKey{
+		id:       entry.ID,
+		metadata: make(map[string]interface{}, len(entry.Metadata)),
+	}
+	for name, value := range entry.Metadata {
+		if name == keyIDAttribute {
+			return nil, fmt.Errorf("metadata: %q is reserved", keyIDAttribute)
+		}
+		key.metadata[name] = metadataValue(value)
+		key.metadataKeys = append(key.metadataKeys, name)
+	}
+	sort.Strings(key.metadataKeys)
+	return key, nil
+}
+
+// metadataValue converts a metadata value to a string, or a list of strings.
+func metadataValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, e := range v {
+			values = append(values, fmt.Sprint(e))
+		}
+		return values
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// match returns the API key matching the given secret, nil if none does.
+// A bcrypt key is presented as "<id>.<key>", so that the secret is only compared
+// to the hash of the key with that ID.
+func (s *keyStore) match(secret string) *apiKey {
+	sum := sha256.Sum256([]byte(secret))
+	digest := hex.EncodeToString(sum[:])
+	if key, ok := s.sha256Keys[digest]; ok {
+		return key
+	}
+
+	s.mu.Lock()
+	key, ok := s.matched[digest]
+	s.mu.Unlock()
+	if ok {
+		return key
+	}
+
+	id, plain, ok := strings.Cut(secret, keyIDSeparator)
+	if !ok {
+		return nil
+	}
+	key, ok = s.bcryptKeys[id]
+	if !ok || bcrypt.CompareHashAndPassword(key.bcryptHash, []byte(plain)) != nil {
+		return nil
+	}
+	s.mu.Lock()
+	s.matched[digest] = key
+	s.mu.Unlock()
+	return key
+}
+
+func (s *keyStore) len() int {
+	return len(s.sha256Keys) + len(s.bcryptKeys)
+}
@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apikeyauthextension
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKeys(t *testing.T) {
+	data, err := os.ReadFile("testdata/keys.yaml")
+	require.NoError(t, err)
+
+	keys, err := parseKeys(data)
+	require.NoError(t, err)
+	assert.Equal(t, 2, keys.len())
+
+	teamA := keys.match("team-a-secret")
+	require.NotNil(t, teamA)
+	assert.Equal(t, "team-a", teamA.id)
+	assert.Equal(t, map[string]interface{}{
+		"tenant":  "tenant-a",
+		"team":    "payments",
+		"signals": []string{"traces", "logs"},
+	}, teamA.metadata)
+	assert.Equal(t, []string{"signals", "team", "tenant"}, teamA.metadataKeys)
+
+	teamB := keys.match("team-b.team-b-secret")
+	require.NotNil(t, teamB)
+	assert.Equal(t, "team-b", teamB.id)
+	assert.Same(t, teamB, keys.match("team-b.team-b-secret"))
+	assert.Len(t, keys.matched, 1)
+
+	// A bcrypt key must be prefixed with its own ID.
+	assert.Nil(t, keys.match("team-b-secret"))
+	assert.Nil(t, keys.match("team-a.team-b-secret"))
+	assert.Nil(t, keys.match("team-b.unknown-secret"))
+	assert.Nil(t, keys.match("unknown-secret"))
+	assert.Nil(t, keys.match(""))
+	assert.Len(t, keys.matched, 1)
+}
+
+func TestParseKeysErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		data        string
+		expectedErr string
+	}{
+		{
+			name:        "invalid yaml",
+			data:        "keys: {",
+			expectedErr: "failed to parse the keys file",
+		},
+		{
+			name:        "empty file",
+			data:        "",
+			expectedErr: "the keys file must contain at least one key",
+		},
+		{
+			name:        "no keys",
+			data:        "keys: []",
+			expectedErr: "the keys file must contain at least one key",
+		},
+		{
+			name:        "missing id",
+			data:        "keys: [{hash: 'sha256:9a437339f86986f3de68d17a40511c2e61588bb30f4c5e01ab5c4991a2a4dc41'}]",
+			expectedErr: "keys[0]: id must be specified",
+		},
+		{
+			name: "duplicate id",
+			data: `keys:
+  - {id: a, hash: "sha256:9a437339f86986f3de68d17a40511c2e61588bb30f4c5e01ab5c4991a2a4dc41"}
+  - {id: a, hash: "sha256:9a437339f86986f3de68d17a40511c2e61588bb30f4c5e01ab5c4991a2a4dc41"}`,
+			expectedErr: `keys[1]: duplicate id "a"`,
+		},
+		{
+			name:        "invalid sha256",
+			data:        "keys: [{id: a, hash: 'sha256:abc'}]",
+			expectedErr: "keys[0]: invalid sha256 hash",
+		},
+		{
+			name:        "plain text secret",
+			data:        "keys: [{id: a, hash: 'team-a-secret'}]",
+			expectedErr: "keys[0]: hash must be a bcrypt hash or a sha256 digest prefixed with \"sha256:\"",
+		},
+		{
+			name:        "bcrypt id with separator",
+			data:        "keys: [{id: a.b, hash: '$2a$04$nVpdIXQskFvVgRX0X3jveOGPHK3bpm0ZreBMiPAiYgTPKEuEfTA0.'}]",
+			expectedErr: `keys[0]: the id of a bcrypt key must not contain "."`,
+		},
+		{
+			name:        "reserved metadata",
+			data:        "keys: [{id: a, hash: 'sha256:9a437339f86986f3de68d17a40511c2e61588bb30f4c5e01ab5c4991a2a4dc41', metadata: {key_id: b}}]",
+			expectedErr: `keys[0]: metadata: "key_id" is reserved`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseKeys([]byte(tt.data))
+			assert.ErrorContains(t, err, tt.expectedErr)
+		})
+	}
+}
@@ -23,8 +23,9 @@ import (
 var (
 	errMissingHeader        = fmt.Errorf("missing header name")
 	errMissingHeadersConfig = fmt.Errorf("missing headers configuration")
-	errMissingSource        = fmt.Errorf("missing header source, must be 'from_context' or 'value'")
-	errConflictingSources   = fmt.Errorf("invalid header source, must either 'from_context' or 'value'")
+	errMissingSource        = fmt.Errorf("missing header source, must be 'from_context', 'from_file' or 'value'")
+	errConflictingSources   = fmt.Errorf("invalid header source, must either 'from_context', 'from_file' or 'value'")
+	errDefaultWithValue     = fmt.Errorf("invalid header default, can't be used with 'value'")
 )
 
 type Config struct {
@@ -36,6 +37,9 @@ type HeaderConfig struct {
 	Key         *string `mapstructure:"key"`
 	Value       *string `mapstructure:"value"`
 	FromContext *string `mapstructure:"from_context"`
+	FromFile    *string `mapstructure:"from_file"`
+	// DefaultValue is used when the value from the context or the file is empty.
+	DefaultValue *string `mapstructure:"default"`
 }
 
 // Validate checks if the extension configuration is valid
@@ -47,12 +51,21 @@ func (cfg *Config) Validate() error {
 		if header.Key == nil || *header.Key == "" {
 			return errMissingHeader
 		}
-		if header.FromContext == nil && header.Value == nil {
+		sources := 0
+		for _, source := range []*string{header.Value, header.FromContext, header.FromFile} {
+			if source != nil {
+				sources++
+			}
+		}
+		if sources == 0 {
 			return errMissingSource
 		}
-		if header.FromContext != nil && header.Value != nil {
+		if sources > 1 {
 			return errConflictingSources
 		}
+		if header.Value != nil && header.DefaultValue != nil {
+			return errDefaultWithValue
+		}
 	}
 	return nil
 }
@@ -54,6 +54,23 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "2"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(component.NewID(typeStr)),
+				HeadersConfig: []HeaderConfig{
+					{
+						Key:          stringp("X-Scope-OrgID"),
+						FromContext:  stringp("tenant_id"),
+						DefaultValue: stringp("anonymous"),
+					},
+					{
+						Key:      stringp("Authorization"),
+						FromFile: stringp("/var/run/secrets/tokens/token"),
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -120,6 +137,38 @@ func TestValidateConfig(t *testing.T) {
 			},
 			errConflictingSources,
 		},
+		{
+			"header value from file",
+			[]HeaderConfig{
+				{
+					Key:      stringp("name"),
+					FromFile: stringp("/var/run/secrets/token"),
+				},
+			},
+			nil,
+		},
+		{
+			"header value from context and file",
+			[]HeaderConfig{
+				{
+					Key:         stringp("name"),
+					FromContext: stringp("from context"),
+					FromFile:    stringp("/var/run/secrets/token"),
+				},
+			},
+			errConflictingSources,
+		},
+		{
+			"header default with value",
+			[]HeaderConfig{
+				{
+					Key:          stringp("name"),
+					Value:        stringp("from config"),
+					DefaultValue: stringp("default"),
+				},
+			},
+			errDefaultWithValue,
+		},
 		{
 			"header value source is missing",
 			[]HeaderConfig{
@@ -20,38 +20,81 @@ import (
 	"fmt"
 	"net/http"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configauth"
+	"go.uber.org/zap"
 	"google.golang.org/grpc/credentials"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/headerssetterextension/internal/source"
 )
 
 type Header struct {
-	key    string
-	source source.Source
+	key          string
+	source       source.Source
+	defaultValue string
 }
 
-func newHeadersSetterExtension(cfg *Config) (configauth.ClientAuthenticator, error) {
+// value returns the value of the header, the default value when the source
+// value is empty.
+func (h *Header) value(ctx context.Context) (string, error) {
+	value, err := h.source.Get(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to determine the source: %w", err)
+	}
+	if value == "" {
+		return h.defaultValue, nil
+	}
+	return value, nil
+}
+
+func newHeadersSetterExtension(cfg *Config, logger *zap.Logger) (configauth.ClientAuthenticator, error) {
 	if cfg == nil {
 		return nil, errors.New("extension configuration is not provided")
 	}
 
 	headers := make([]Header, 0, len(cfg.HeadersConfig))
+	var fileSources []*source.FileSource
 	for _, header := range cfg.HeadersConfig {
 		var s source.Source
-		if header.Value != nil {
+		switch {
+		case header.Value != nil:
 			s = &source.StaticSource{
 				Value: *header.Value,
 			}
-		} else if header.FromContext != nil {
+		case header.FromContext != nil:
 			s = &source.ContextSource{
 				Key: *header.FromContext,
 			}
+		case header.FromFile != nil:
+			fs := &source.FileSource{
+				Filename: *header.FromFile,
+				Logger:   logger,
+			}
+			fileSources = append(fileSources, fs)
+			s = fs
 		}
-		headers = append(headers, Header{key: *header.Key, source: s})
+		h := Header{key: *header.Key, source: s}
+		if header.DefaultValue != nil {
+			h.defaultValue = *header.DefaultValue
+		}
+		headers = append(headers, h)
 	}
 
 	return configauth.NewClientAuthenticator(
+		configauth.WithClientStart(func(context.Context, component.Host) error {
+			for _, fs := range fileSources {
+				if err := fs.Start(); err != nil {
+					return err
+				}
+			}
+			return nil
+		}),
+		configauth.WithClientShutdown(func(context.Context) error {
+			for _, fs := range fileSources {
+				fs.Shutdown()
+			}
+			return nil
+		}),
 		configauth.WithClientRoundTripper(
 			func(base http.RoundTripper) (http.RoundTripper, error) {
 				return &headersRoundTripper{
@@ -80,9 +123,9 @@ func (h *headersPerRPC) GetRequestMetadata(
 
 	metadata := make(map[string]string, len(h.headers))
 	for _, header := range h.headers {
-		value, err := header.source.Get(ctx)
+		value, err := header.value(ctx)
 		if err != nil {
-			return nil, fmt.Errorf("failed to determine the source: %w", err)
+			return nil, err
 		}
 		metadata[header.key] = value
 	}
@@ -109,9 +152,9 @@ func (h *headersRoundTripper) RoundTrip(req *http.Request) (*http.Response, erro
 		req2.Header = make(http.Header)
 	}
 	for _, header := range h.headers {
-		value, err := header.source.Get(req.Context())
+		value, err := header.value(req.Context())
 		if err != nil {
-			return nil, fmt.Errorf("failed to determine the source: %w", err)
+			return nil, err
 		}
 		req2.Header.Set(header.key, value)
 	}
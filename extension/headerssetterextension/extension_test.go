@@ -17,10 +17,16 @@ package headerssetterextension
 import (
 	"context"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
 )
 
 type mockRoundTripper struct{}
@@ -36,7 +42,7 @@ func (m *mockRoundTripper) RoundTrip(req *http.Request) (*http.Response, error)
 func TestRoundTripper(t *testing.T) {
 	for _, tt := range tests {
 		t.Run("round_tripper", func(t *testing.T) {
-			ext, err := newHeadersSetterExtension(tt.cfg)
+			ext, err := newHeadersSetterExtension(tt.cfg, zap.NewNop())
 			assert.NoError(t, err)
 			assert.NotNil(t, ext)
 
@@ -71,7 +77,7 @@ func TestRoundTripper(t *testing.T) {
 func TestPerRPCCredentials(t *testing.T) {
 	for _, tt := range tests {
 		t.Run("", func(t *testing.T) {
-			ext, err := newHeadersSetterExtension(tt.cfg)
+			ext, err := newHeadersSetterExtension(tt.cfg, zap.NewNop())
 			assert.NoError(t, err)
 			assert.NotNil(t, ext)
 
@@ -220,9 +226,115 @@ var (
 				"header_name": "",
 			},
 		},
+		{
+			cfg: &Config{
+				HeadersConfig: []HeaderConfig{
+					{
+						Key:          &header,
+						FromContext:  stringp("tenant"),
+						DefaultValue: stringp("default tenant"),
+					},
+				},
+			},
+			expectedHeaders: map[string]string{
+				"header_name": "default tenant",
+			},
+		},
+		{
+			cfg: &Config{
+				HeadersConfig: []HeaderConfig{
+					{
+						Key:          &header,
+						FromContext:  stringp("tenant"),
+						DefaultValue: stringp("default tenant"),
+					},
+				},
+			},
+			metadata: client.NewMetadata(
+				map[string][]string{"tenant": {"acme"}},
+			),
+			expectedHeaders: map[string]string{
+				"header_name": "acme",
+			},
+		},
 	}
 )
 
+func TestEmptyValueSet(t *testing.T) {
+	ext, err := newHeadersSetterExtension(&Config{
+		HeadersConfig: []HeaderConfig{
+			{Key: &header, FromContext: stringp("tenant")},
+		},
+	}, zap.NewNop())
+	require.NoError(t, err)
+
+	perRPC, err := ext.PerRPCCredentials()
+	require.NoError(t, err)
+	metadata, err := perRPC.GetRequestMetadata(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{header: ""}, metadata)
+
+	roundTripper, err := ext.RoundTripper(mrt)
+	require.NoError(t, err)
+	req, err := http.NewRequestWithContext(context.Background(), "GET", "", nil)
+	require.NoError(t, err)
+	resp, err := roundTripper.RoundTrip(req)
+	require.NoError(t, err)
+	assert.Contains(t, resp.Header, http.CanonicalHeaderKey(header))
+	assert.Equal(t, "", resp.Header.Get(header))
+}
+
+func TestFromFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(filename, []byte("first-token\n"), 0600))
+
+	ext, err := newHeadersSetterExtension(&Config{
+		HeadersConfig: []HeaderConfig{
+			{Key: stringp("Authorization"), FromFile: &filename},
+		},
+	}, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, ext.Shutdown(context.Background()))
+	}()
+
+	perRPC, err := ext.PerRPCCredentials()
+	require.NoError(t, err)
+	roundTripper, err := ext.RoundTripper(mrt)
+	require.NoError(t, err)
+
+	headers := func() (string, string) {
+		metadata, err := perRPC.GetRequestMetadata(context.Background())
+		require.NoError(t, err)
+		req, err := http.NewRequestWithContext(context.Background(), "GET", "", nil)
+		require.NoError(t, err)
+		resp, err := roundTripper.RoundTrip(req)
+		require.NoError(t, err)
+		return metadata["Authorization"], resp.Header.Get("Authorization")
+	}
+
+	grpcValue, httpValue := headers()
+	assert.Equal(t, "first-token", grpcValue)
+	assert.Equal(t, "first-token", httpValue)
+
+	require.NoError(t, os.WriteFile(filename, []byte("second-token\n"), 0600))
+	assert.Eventually(t, func() bool {
+		grpcValue, httpValue = headers()
+		return grpcValue == "second-token" && httpValue == "second-token"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestFromFileMissing(t *testing.T) {
+	ext, err := newHeadersSetterExtension(&Config{
+		HeadersConfig: []HeaderConfig{
+			{Key: stringp("Authorization"), FromFile: stringp(filepath.Join(t.TempDir(), "missing"))},
+		},
+	}, zap.NewNop())
+	require.NoError(t, err)
+	assert.Error(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+}
+
 func stringp(str string) *string {
 	return &str
 }
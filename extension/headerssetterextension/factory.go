@@ -43,8 +43,8 @@ func createDefaultConfig() component.ExtensionConfig {
 
 func createExtension(
 	_ context.Context,
-	_ component.ExtensionCreateSettings,
+	set component.ExtensionCreateSettings,
 	cfg component.ExtensionConfig,
 ) (component.Extension, error) {
-	return newHeadersSetterExtension(cfg.(*Config))
+	return newHeadersSetterExtension(cfg.(*Config), set.Logger)
 }
@@ -3,9 +3,11 @@ module github.com/open-telemetry/opentelemetry-collector-contrib/extension/heade
 go 1.18
 
 require (
+	github.com/fsnotify/fsnotify v1.6.0
 	github.com/stretchr/testify v1.8.1
 	go.opentelemetry.io/collector v0.65.0
 	go.opentelemetry.io/collector/component v0.65.0
+	go.uber.org/zap v1.23.0
 	google.golang.org/grpc v1.51.0
 )
 
@@ -29,7 +31,6 @@ require (
 	go.opentelemetry.io/otel/trace v1.11.1 // indirect
 	go.uber.org/atomic v1.10.0 // indirect
 	go.uber.org/multierr v1.8.0 // indirect
-	go.uber.org/zap v1.23.0 // indirect
 	golang.org/x/net v0.0.0-20220722155237-a158d28d115b // indirect
 	golang.org/x/sys v0.2.0 // indirect
 	golang.org/x/text v0.4.0 // indirect
@@ -17,19 +17,49 @@ package source // import "github.com/open-telemetry/opentelemetry-collector-cont
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"go.opentelemetry.io/collector/client"
 )
 
 var _ Source = (*ContextSource)(nil)
 
+const (
+	metadataPrefix = "metadata."
+	authPrefix     = "auth."
+)
+
+// ContextSource looks up the value in the client.Info of the context: in the
+// metadata, or in the attributes of the authentication data when the key is
+// prefixed with "auth.".
 type ContextSource struct {
 	Key string
 }
 
 func (ts *ContextSource) Get(ctx context.Context) (string, error) {
 	cl := client.FromContext(ctx)
-	ss := cl.Metadata.Get(ts.Key)
+
+	var ss []string
+	switch {
+	case strings.HasPrefix(ts.Key, authPrefix):
+		if cl.Auth == nil {
+			return "", nil
+		}
+		switch attr := cl.Auth.GetAttribute(strings.TrimPrefix(ts.Key, authPrefix)).(type) {
+		case string:
+			return attr, nil
+		case []string:
+			ss = attr
+		case nil:
+			return "", nil
+		default:
+			return "", fmt.Errorf("unexpected type %T of the auth attribute %q", attr, ts.Key)
+		}
+	case strings.HasPrefix(ts.Key, metadataPrefix):
+		ss = cl.Metadata.Get(strings.TrimPrefix(ts.Key, metadataPrefix))
+	default:
+		ss = cl.Metadata.Get(ts.Key)
+	}
 
 	if len(ss) == 0 {
 		return "", nil
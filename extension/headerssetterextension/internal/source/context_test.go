@@ -57,3 +57,62 @@ func TestContextSourceMultipleFound(t *testing.T) {
 	assert.Error(t, err)
 	assert.Empty(t, header)
 }
+
+type authData map[string]interface{}
+
+func (a authData) GetAttribute(name string) interface{} {
+	return a[name]
+}
+
+func (a authData) GetAttributeNames() []string {
+	names := make([]string, 0, len(a))
+	for name := range a {
+		names = append(names, name)
+	}
+	return names
+}
+
+func TestContextSourceMetadataPrefix(t *testing.T) {
+	ts := &ContextSource{Key: "metadata.X-Scope-OrgID"}
+	cl := client.FromContext(context.Background())
+	cl.Metadata = client.NewMetadata(map[string][]string{"X-Scope-OrgID": {"acme"}})
+	ctx := client.NewContext(context.Background(), cl)
+
+	header, err := ts.Get(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", header)
+}
+
+func TestContextSourceAuth(t *testing.T) {
+	cl := client.FromContext(context.Background())
+	cl.Auth = authData{
+		"spiffe_id": "spiffe://example.org/ns/prod/sa/checkout",
+		"dns_names": []string{"checkout.example.org"},
+		"groups":    []string{"admins", "developers"},
+		"count":     2,
+	}
+	ctx := client.NewContext(context.Background(), cl)
+
+	header, err := (&ContextSource{Key: "auth.spiffe_id"}).Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "spiffe://example.org/ns/prod/sa/checkout", header)
+
+	header, err = (&ContextSource{Key: "auth.dns_names"}).Get(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "checkout.example.org", header)
+
+	header, err = (&ContextSource{Key: "auth.unknown"}).Get(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, header)
+
+	_, err = (&ContextSource{Key: "auth.groups"}).Get(ctx)
+	assert.Error(t, err)
+
+	_, err = (&ContextSource{Key: "auth.count"}).Get(ctx)
+	assert.Error(t, err)
+
+	header, err = (&ContextSource{Key: "auth.spiffe_id"}).Get(context.Background())
+	assert.NoError(t, err)
+	assert.Empty(t, header)
+}
@@ -0,0 +1,143 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/headerssetterextension/internal/source"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/zap"
+)
+
+var _ Source = (*FileSource)(nil)
+
+// FileSource returns the content of a file, re-read whenever the file changes,
+// e.g. for rotating tokens. The leading and trailing white spaces are removed.
+type FileSource struct {
+	Filename string
+	Logger   *zap.Logger
+
+	mu    sync.RWMutex
+	value string
+
+	shutdownCH chan struct{}
+	wg         sync.WaitGroup
+}
+
+func (fs *FileSource) Get(context.Context) (string, error) {
+	fs.mu.RLock()
+	defer fs.mu.RUnlock()
+	return fs.value, nil
+}
+
+// Start reads the file, and starts a routine reading it again when it changes.
+func (fs *FileSource) Start() error {
+	if err := fs.read(); err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	if err = watcher.Add(fs.Filename); err != nil {
+		_ = watcher.Close()
+		return err
+	}
+
+	fs.shutdownCH = make(chan struct{})
+	fs.wg.Add(1)
+	go fs.watch(watcher)
+	return nil
+}
+
+func (fs *FileSource) watch(watcher *fsnotify.Watcher) {
+	defer fs.wg.Done()
+	defer watcher.Close()
+	for {
+		select {
+		case <-fs.shutdownCH:
+			return
+		case err, ok := <-watcher.Errors:
+			if ok {
+				fs.Logger.Error("Failed to watch the header file", zap.String("filename", fs.Filename), zap.Error(err))
+			}
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			// NOTE: k8s projected volumes and configmaps use symlinks and
+			// replace the original file, the watch has to be added again.
+			if event.Op&(fsnotify.Remove|fsnotify.Rename|fsnotify.Chmod) != 0 {
+				_ = watcher.Remove(event.Name)
+				if err := watcher.Add(fs.Filename); err != nil {
+					fs.Logger.Error("Failed to watch the header file", zap.String("filename", fs.Filename), zap.Error(err))
+				}
+			}
+			if err := fs.reload(); err != nil {
+				fs.Logger.Error("Failed to read the header file, keeping the previous value", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (fs *FileSource) read() error {
+	value, err := fs.readFile()
+	if err != nil {
+		return err
+	}
+	fs.mu.Lock()
+	fs.value = value
+	fs.mu.Unlock()
+	return nil
+}
+
+// reload reads the file after a change. An empty content is ignored, as the file
+// is briefly empty while it's truncated and written again during a rotation.
+func (fs *FileSource) reload() error {
+	value, err := fs.readFile()
+	if err != nil {
+		return err
+	}
+	if value == "" {
+		fs.Logger.Debug("The header file is empty, keeping the previous value", zap.String("filename", fs.Filename))
+		return nil
+	}
+	fs.mu.Lock()
+	fs.value = value
+	fs.mu.Unlock()
+	return nil
+}
+
+func (fs *FileSource) readFile() (string, error) {
+	content, err := os.ReadFile(fs.Filename)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the header file: %w", err)
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+// Shutdown stops watching the file.
+func (fs *FileSource) Shutdown() {
+	if fs.shutdownCH != nil {
+		close(fs.shutdownCH)
+		fs.wg.Wait()
+		fs.shutdownCH = nil
+	}
+}
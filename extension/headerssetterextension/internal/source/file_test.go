@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestFileSource(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(filename, []byte("first-token\n"), 0600))
+
+	fs := &FileSource{Filename: filename, Logger: zap.NewNop()}
+	require.NoError(t, fs.Start())
+	defer fs.Shutdown()
+
+	value, err := fs.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "first-token", value)
+
+	require.NoError(t, os.WriteFile(filename, []byte("second-token\n"), 0600))
+	assert.Eventually(t, func() bool {
+		value, err = fs.Get(context.Background())
+		return err == nil && value == "second-token"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The file is replaced, as done for the rotation of projected tokens.
+	replacement := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(replacement, []byte("third-token"), 0600))
+	require.NoError(t, os.Rename(replacement, filename))
+	assert.Eventually(t, func() bool {
+		value, err = fs.Get(context.Background())
+		return err == nil && value == "third-token"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestFileSourceMissingFile(t *testing.T) {
+	fs := &FileSource{Filename: filepath.Join(t.TempDir(), "missing"), Logger: zap.NewNop()}
+	assert.ErrorContains(t, fs.Start(), "failed to read the header file")
+	fs.Shutdown()
+}
+
+func TestFileSourceEmptyReload(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(filename, []byte("first-token\n"), 0600))
+
+	fs := &FileSource{Filename: filename, Logger: zap.NewNop()}
+	require.NoError(t, fs.read())
+
+	// The file is truncated before being written again during a rotation.
+	require.NoError(t, os.WriteFile(filename, nil, 0600))
+	require.NoError(t, fs.reload())
+	value, err := fs.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "first-token", value)
+
+	require.NoError(t, os.WriteFile(filename, []byte("second-token\n"), 0600))
+	require.NoError(t, fs.reload())
+	value, err = fs.Get(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, "second-token", value)
+}
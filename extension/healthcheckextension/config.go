@@ -34,6 +34,19 @@ type Config struct {
 	// The default path is "/".
 	Path string `mapstructure:"path"`
 
+	// LivenessPath represents the path of the liveness probe, answering as long
+	// as the extension is running. The default path is "/livez", it is disabled when empty.
+	LivenessPath string `mapstructure:"liveness_path"`
+
+	// ReadinessPath represents the path of the readiness probe, answering with a
+	// success when the pipelines are started and no critical exporter is failing.
+	// The default path is "/readyz", it is disabled when empty.
+	ReadinessPath string `mapstructure:"readiness_path"`
+
+	// StatusPath represents the path of the JSON status of the pipelines and
+	// their components. The default path is "/status", it is disabled when empty.
+	StatusPath string `mapstructure:"status_path"`
+
 	// CheckCollectorPipeline contains the list of settings of collector pipeline health check
 	CheckCollectorPipeline checkCollectorPipelineSettings `mapstructure:"check_collector_pipeline"`
 }
@@ -43,6 +56,7 @@ var (
 	errNoEndpointProvided                      = errors.New("bad config: endpoint must be specified")
 	errInvalidExporterFailureThresholdProvided = errors.New("bad config: exporter_failure_threshold expects a positive number")
 	errInvalidPath                             = errors.New("bad config: path must start with /")
+	errDuplicatePath                           = errors.New("bad config: path, liveness_path, readiness_path and status_path must be different")
 )
 
 // Validate checks if the extension configuration is valid
@@ -60,6 +74,19 @@ func (cfg *Config) Validate() error {
 	if !strings.HasPrefix(cfg.Path, "/") {
 		return errInvalidPath
 	}
+	paths := map[string]bool{cfg.Path: true}
+	for _, path := range []string{cfg.LivenessPath, cfg.ReadinessPath, cfg.StatusPath} {
+		if path == "" {
+			continue
+		}
+		if !strings.HasPrefix(path, "/") {
+			return errInvalidPath
+		}
+		if paths[path] {
+			return errDuplicatePath
+		}
+		paths[path] = true
+	}
 	return nil
 }
 
@@ -70,4 +97,7 @@ type checkCollectorPipelineSettings struct {
 	Interval string `mapstructure:"interval"`
 	// ExporterFailureThreshold is the threshold of exporter failure numbers during the Interval
 	ExporterFailureThreshold int `mapstructure:"exporter_failure_threshold"`
+	// CriticalExporters are the IDs of the exporters making the readiness probe
+	// fail when their failures during the Interval exceed ExporterFailureThreshold.
+	CriticalExporters []string `mapstructure:"critical_exporters"`
 }
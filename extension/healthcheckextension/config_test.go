@@ -55,6 +55,28 @@ func TestLoadConfig(t *testing.T) {
 				},
 				CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
 				Path:                   "/",
+				LivenessPath:           "/livez",
+				ReadinessPath:          "/readyz",
+				StatusPath:             "/status",
+			},
+		},
+		{
+			id: component.NewIDWithName(typeStr, "status"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(component.NewID(typeStr)),
+				HTTPServerSettings: confighttp.HTTPServerSettings{
+					Endpoint: "localhost:13",
+				},
+				CheckCollectorPipeline: checkCollectorPipelineSettings{
+					Enabled:                  false,
+					Interval:                 "1m",
+					ExporterFailureThreshold: 2,
+					CriticalExporters:        []string{"otlp/backend"},
+				},
+				Path:          "/",
+				LivenessPath:  "/health/live",
+				ReadinessPath: "/health/ready",
+				StatusPath:    "",
 			},
 		},
 		{
@@ -69,6 +91,10 @@ func TestLoadConfig(t *testing.T) {
 			id:          component.NewIDWithName(typeStr, "invalidpath"),
 			expectedErr: errInvalidPath,
 		},
+		{
+			id:          component.NewIDWithName(typeStr, "duplicatepath"),
+			expectedErr: errDuplicatePath,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
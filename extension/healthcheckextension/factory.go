@@ -29,6 +29,10 @@ const (
 	// Use 0.0.0.0 to make the health check endpoint accessible
 	// in container orchestration environments like Kubernetes.
 	defaultEndpoint = "0.0.0.0:13133"
+
+	defaultLivenessPath  = "/livez"
+	defaultReadinessPath = "/readyz"
+	defaultStatusPath    = "/status"
 )
 
 // NewFactory creates a factory for HealthCheck extension.
@@ -49,6 +53,9 @@ func createDefaultConfig() component.ExtensionConfig {
 		},
 		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
 		Path:                   "/",
+		LivenessPath:           defaultLivenessPath,
+		ReadinessPath:          defaultReadinessPath,
+		StatusPath:             defaultStatusPath,
 	}
 }
 
@@ -37,6 +37,9 @@ func TestFactory_CreateDefaultConfig(t *testing.T) {
 		},
 		CheckCollectorPipeline: defaultCheckCollectorPipelineSettings(),
 		Path:                   "/",
+		LivenessPath:           "/livez",
+		ReadinessPath:          "/readyz",
+		StatusPath:             "/status",
 	}, cfg)
 
 	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
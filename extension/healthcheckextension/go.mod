@@ -9,6 +9,8 @@ require (
 	go.opencensus.io v0.24.0
 	go.opentelemetry.io/collector v0.65.0
 	go.opentelemetry.io/collector/component v0.65.0
+	go.opentelemetry.io/otel v1.11.1
+	go.opentelemetry.io/otel/sdk v1.11.1
 	go.uber.org/zap v1.23.0
 )
 
@@ -34,7 +36,6 @@ require (
 	go.opentelemetry.io/collector/featuregate v0.65.0 // indirect
 	go.opentelemetry.io/collector/pdata v0.65.0 // indirect
 	go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp v0.36.4 // indirect
-	go.opentelemetry.io/otel v1.11.1 // indirect
 	go.opentelemetry.io/otel/metric v0.33.0 // indirect
 	go.opentelemetry.io/otel/trace v1.11.1 // indirect
 	go.uber.org/atomic v1.10.0 // indirect
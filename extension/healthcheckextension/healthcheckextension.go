@@ -16,6 +16,7 @@ package healthcheckextension // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -24,6 +25,7 @@ import (
 	"github.com/jaegertracing/jaeger/pkg/healthcheck"
 	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.uber.org/zap"
 )
 
@@ -34,14 +36,31 @@ type healthCheckExtension struct {
 	server   *http.Server
 	stopCh   chan struct{}
 	exporter *healthCheckExporter
+	tracker  *statusTracker
+	host     component.Host
 	settings component.TelemetrySettings
 }
 
 var _ component.PipelineWatcher = (*healthCheckExtension)(nil)
 
+// registerableTracerProvider is a tracer provider supporting the SDK methods
+// RegisterSpanProcessor and UnregisterSpanProcessor, as the one of the collector.
+type registerableTracerProvider interface {
+	RegisterSpanProcessor(sdktrace.SpanProcessor)
+	UnregisterSpanProcessor(sdktrace.SpanProcessor)
+}
+
 func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) error {
 
 	hc.logger.Info("Starting health_check extension", zap.Any("config", hc.config))
+	interval, err := time.ParseDuration(hc.config.CheckCollectorPipeline.Interval)
+	if err != nil {
+		return err
+	}
+	if err = checkCriticalExporters(hc.config.CheckCollectorPipeline.CriticalExporters, host); err != nil {
+		return err
+	}
+
 	ln, err := hc.config.ToListener()
 	if err != nil {
 		return fmt.Errorf("failed to bind to address %s: %w", hc.config.Endpoint, err)
@@ -51,60 +70,88 @@ func (hc *healthCheckExtension) Start(_ context.Context, host component.Host) er
 	if err != nil {
 		return err
 	}
+	hc.host = host
 
+	// The component statuses are tracked from the collector internal telemetry.
+	hc.tracker = newStatusTracker(interval, hc.config.CheckCollectorPipeline.ExporterFailureThreshold)
+	view.RegisterExporter(hc.tracker)
+	// The errors of the components are tracked from the spans of their operations.
+	if tp, ok := hc.settings.TracerProvider.(registerableTracerProvider); ok {
+		tp.RegisterSpanProcessor(hc.tracker)
+	} else {
+		hc.logger.Warn("Span processor registration is not available, the status won't report the last errors")
+	}
+
+	mux := http.NewServeMux()
 	if !hc.config.CheckCollectorPipeline.Enabled {
 		// Mount HC handler
-		mux := http.NewServeMux()
 		mux.Handle(hc.config.Path, hc.state.Handler())
-		hc.server.Handler = mux
-		hc.stopCh = make(chan struct{})
-		go func() {
-			defer close(hc.stopCh)
-
-			// The listener ownership goes to the server.
-			if err = hc.server.Serve(ln); !errors.Is(err, http.ErrServerClosed) && err != nil {
-				host.ReportFatalError(err)
-			}
-		}()
 	} else {
 		// collector pipeline health check
 		hc.exporter = newHealthCheckExporter()
 		view.RegisterExporter(hc.exporter)
+		mux.Handle(hc.config.Path, hc.handler())
+	}
+	if hc.config.LivenessPath != "" {
+		mux.Handle(hc.config.LivenessPath, hc.livenessHandler())
+	}
+	if hc.config.ReadinessPath != "" {
+		mux.Handle(hc.config.ReadinessPath, hc.readinessHandler())
+	}
+	if hc.config.StatusPath != "" {
+		mux.Handle(hc.config.StatusPath, hc.statusHandler())
+	}
+	hc.server.Handler = mux
 
-		interval, err := time.ParseDuration(hc.config.CheckCollectorPipeline.Interval)
-		if err != nil {
-			return err
-		}
-
-		// ticker used by collector pipeline health check for rotation
-		ticker := time.NewTicker(time.Second)
+	// ticker used by collector pipeline health check for rotation
+	ticker := time.NewTicker(time.Second)
 
-		mux := http.NewServeMux()
-		mux.Handle(hc.config.Path, hc.handler())
-		hc.server.Handler = mux
-		hc.stopCh = make(chan struct{})
-		go func() {
-			defer close(hc.stopCh)
+	hc.stopCh = make(chan struct{})
+	go func() {
+		defer close(hc.stopCh)
+		defer view.UnregisterExporter(hc.tracker)
+		if hc.exporter != nil {
 			defer view.UnregisterExporter(hc.exporter)
+		}
 
-			go func() {
-				for {
-					select {
-					case <-ticker.C:
+		go func() {
+			defer ticker.Stop()
+			for {
+				select {
+				case now := <-ticker.C:
+					if hc.exporter != nil {
 						hc.exporter.rotate(interval)
-					case <-hc.stopCh:
-						return
 					}
+					hc.tracker.rotate(now)
+				case <-hc.stopCh:
+					return
 				}
-			}()
-
-			if errHTTP := hc.server.Serve(ln); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
-				host.ReportFatalError(errHTTP)
 			}
-
 		}()
-	}
 
+		// The listener ownership goes to the server.
+		if errHTTP := hc.server.Serve(ln); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+
+	return nil
+}
+
+// checkCriticalExporters ensures the critical exporters are in a pipeline, as a misspelled
+// exporter would otherwise never make the readiness probe fail.
+func checkCriticalExporters(critical []string, host component.Host) error {
+	exporterIDs := map[string]bool{}
+	for _, exporters := range host.GetExporters() {
+		for id := range exporters {
+			exporterIDs[id.String()] = true
+		}
+	}
+	for _, id := range critical {
+		if !exporterIDs[id] {
+			return fmt.Errorf("critical exporter %q is not in any pipeline", id)
+		}
+	}
 	return nil
 }
 
@@ -123,6 +170,103 @@ func (hc *healthCheckExtension) check() bool {
 	return hc.exporter.checkHealthStatus(hc.config.CheckCollectorPipeline.ExporterFailureThreshold)
 }
 
+// livenessHandler answers as long as the extension serves requests, so that
+// the collector isn't restarted because of a failing pipeline.
+func (hc *healthCheckExtension) livenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "alive"})
+	})
+}
+
+// readinessHandler answers with a success when the pipelines are started, and
+// none of the critical exporters is failing.
+func (hc *healthCheckExtension) readinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		doc := hc.status()
+		writeJSON(w, statusCode(doc.Ready), map[string]interface{}{"status": doc.Status})
+	})
+}
+
+// statusHandler answers with the status of the pipelines and their components.
+func (hc *healthCheckExtension) statusHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		doc := hc.status()
+		writeJSON(w, statusCode(doc.Ready), doc)
+	})
+}
+
+type statusDocument struct {
+	Status string `json:"status"`
+	Ready  bool   `json:"-"`
+	// ReceiversStarted is set once the pipelines, and their receivers, are started.
+	ReceiversStarted bool                        `json:"receivers_started"`
+	DataTypes        map[string]*dataTypeStatus  `json:"data_types"`
+	Receivers        map[string]*componentStatus `json:"receivers"`
+	Exporters        map[string]*componentStatus `json:"exporters"`
+}
+
+// dataTypeStatus is the status of the pipelines of a data type, as the host
+// only exposes the exporters of each data type, not the pipelines.
+type dataTypeStatus struct {
+	Healthy   bool     `json:"healthy"`
+	Exporters []string `json:"exporters"`
+}
+
+func (hc *healthCheckExtension) status() *statusDocument {
+	critical := map[string]bool{}
+	for _, id := range hc.config.CheckCollectorPipeline.CriticalExporters {
+		critical[id] = true
+	}
+
+	doc := &statusDocument{
+		DataTypes: map[string]*dataTypeStatus{},
+		Receivers: hc.tracker.statuses(receiverKind, nil, nil),
+	}
+	exporterIDs := map[string]bool{}
+	for dataType, exporters := range hc.host.GetExporters() {
+		ids := map[string]bool{}
+		for id := range exporters {
+			ids[id.String()] = true
+			exporterIDs[id.String()] = true
+		}
+		doc.DataTypes[string(dataType)] = &dataTypeStatus{Exporters: sortedKeys(ids)}
+	}
+	doc.Exporters = hc.tracker.statuses(exporterKind, sortedKeys(exporterIDs), critical)
+
+	doc.ReceiversStarted = hc.state.Get() == healthcheck.Ready
+	doc.Ready = doc.ReceiversStarted
+	for _, dataType := range doc.DataTypes {
+		dataType.Healthy = true
+		for _, id := range dataType.Exporters {
+			dataType.Healthy = dataType.Healthy && doc.Exporters[id].Healthy
+		}
+	}
+	for id := range critical {
+		if status, ok := doc.Exporters[id]; ok && !status.Healthy {
+			doc.Ready = false
+		}
+	}
+
+	doc.Status = "unavailable"
+	if doc.Ready {
+		doc.Status = "ready"
+	}
+	return doc
+}
+
+func statusCode(ready bool) int {
+	if ready {
+		return http.StatusOK
+	}
+	return http.StatusServiceUnavailable
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
 func (hc *healthCheckExtension) Shutdown(context.Context) error {
 	if hc.server == nil {
 		return nil
@@ -131,6 +275,9 @@ func (hc *healthCheckExtension) Shutdown(context.Context) error {
 	if hc.stopCh != nil {
 		<-hc.stopCh
 	}
+	if tp, ok := hc.settings.TracerProvider.(registerableTracerProvider); ok && hc.tracker != nil {
+		tp.UnregisterSpanProcessor(hc.tracker)
+	}
 	return err
 }
 
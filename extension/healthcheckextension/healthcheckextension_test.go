@@ -16,6 +16,7 @@ package healthcheckextension
 
 import (
 	"context"
+	"encoding/json"
 	"net"
 	"net/http"
 	"runtime"
@@ -28,6 +29,8 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
 )
@@ -336,3 +339,137 @@ func newAssertNoErrorHost(t *testing.T) component.Host {
 func (aneh *assertNoErrorHost) ReportFatalError(err error) {
 	assert.NoError(aneh, err)
 }
+
+type hostWithExporters struct {
+	component.Host
+	exporters map[component.DataType]map[component.ID]component.Component
+}
+
+func (h *hostWithExporters) GetExporters() map[component.DataType]map[component.ID]component.Component {
+	return h.exporters
+}
+
+func TestHealthCheckExtensionUnknownCriticalExporter(t *testing.T) {
+	config := Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+		CheckCollectorPipeline: checkCollectorPipelineSettings{
+			Interval:                 "5m",
+			ExporterFailureThreshold: 1,
+			CriticalExporters:        []string{"otlp/backnd"},
+		},
+	}
+
+	hcExt := newServer(config, componenttest.NewNopTelemetrySettings())
+	require.NotNil(t, hcExt)
+
+	host := &hostWithExporters{
+		Host: componenttest.NewNopHost(),
+		exporters: map[component.DataType]map[component.ID]component.Component{
+			component.DataTypeTraces: {
+				component.NewIDWithName("otlp", "backend"): nil,
+			},
+		},
+	}
+	assert.EqualError(t, hcExt.Start(context.Background(), host), `critical exporter "otlp/backnd" is not in any pipeline`)
+}
+
+func TestHealthCheckExtensionProbesAndStatus(t *testing.T) {
+	config := Config{
+		HTTPServerSettings: confighttp.HTTPServerSettings{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+		CheckCollectorPipeline: checkCollectorPipelineSettings{
+			Interval:                 "5m",
+			ExporterFailureThreshold: 1,
+			CriticalExporters:        []string{"otlp/backend"},
+		},
+		Path:          "/",
+		LivenessPath:  "/livez",
+		ReadinessPath: "/readyz",
+		StatusPath:    "/status",
+	}
+
+	settings := componenttest.NewNopTelemetrySettings()
+	tp := sdktrace.NewTracerProvider()
+	settings.TracerProvider = tp
+	hcExt := newServer(config, settings)
+	require.NotNil(t, hcExt)
+
+	host := &hostWithExporters{
+		Host: componenttest.NewNopHost(),
+		exporters: map[component.DataType]map[component.ID]component.Component{
+			component.DataTypeTraces: {
+				component.NewIDWithName("otlp", "backend"): nil,
+				component.NewID("logging"):                 nil,
+			},
+			component.DataTypeMetrics: {
+				component.NewID("logging"): nil,
+			},
+		},
+	}
+	require.NoError(t, hcExt.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, hcExt.Shutdown(context.Background())) })
+	require.Eventuallyf(t, ensureServerRunning(config.Endpoint), 30*time.Second, 1*time.Second, "Failed to start the testing server.")
+
+	get := func(path string) (int, map[string]interface{}) {
+		resp, err := http.Get("http://" + config.Endpoint + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+		return resp.StatusCode, body
+	}
+
+	code, _ := get("/livez")
+	assert.Equal(t, http.StatusOK, code)
+	code, body := get("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "unavailable", body["status"])
+	_, body = get("/status")
+	assert.Equal(t, false, body["receivers_started"])
+
+	require.NoError(t, hcExt.Ready())
+	code, body = get("/readyz")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ready", body["status"])
+
+	// A failing exporter that isn't critical doesn't affect the readiness.
+	now := time.Now()
+	hcExt.tracker.ExportView(viewData("receiver/accepted_spans", now, receiverKind, map[string]float64{"otlp": 10}))
+	hcExt.tracker.ExportView(viewData("exporter/send_failed_spans", now, exporterKind, map[string]float64{"logging": 1}))
+	hcExt.tracker.ExportView(viewData("exporter/send_failed_spans", now.Add(time.Second), exporterKind, map[string]float64{"logging": 2}))
+	_, span := tp.Tracer("test").Start(context.Background(), "exporter/logging/traces")
+	span.SetStatus(codes.Error, "connection refused")
+	span.End()
+	code, body = get("/status")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "ready", body["status"])
+	assert.Equal(t, true, body["receivers_started"])
+	assert.Equal(t, map[string]interface{}{
+		"traces":  map[string]interface{}{"healthy": false, "exporters": []interface{}{"logging", "otlp/backend"}},
+		"metrics": map[string]interface{}{"healthy": false, "exporters": []interface{}{"logging"}},
+	}, body["data_types"])
+	exporters := body["exporters"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"healthy": true, "critical": true, "recent_failures": float64(0)}, exporters["otlp/backend"])
+	logging := exporters["logging"].(map[string]interface{})
+	assert.Equal(t, false, logging["healthy"])
+	assert.Equal(t, float64(2), logging["recent_failures"])
+	assert.Contains(t, logging, "last_failure")
+	assert.Equal(t, "connection refused", logging["last_error"])
+	receivers := body["receivers"].(map[string]interface{})
+	assert.Contains(t, receivers["otlp"], "last_success")
+
+	// A failing critical exporter makes the collector not ready, but alive.
+	hcExt.tracker.ExportView(viewData("exporter/send_failed_spans", now, exporterKind, map[string]float64{"otlp/backend": 1}))
+	hcExt.tracker.ExportView(viewData("exporter/send_failed_spans", now.Add(time.Second), exporterKind, map[string]float64{"otlp/backend": 2}))
+	code, _ = get("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	code, body = get("/status")
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "unavailable", body["status"])
+	code, _ = get("/livez")
+	assert.Equal(t, http.StatusOK, code)
+}
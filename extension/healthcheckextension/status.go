@@ -0,0 +1,244 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheckextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats/view"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	receiverKind = "receiver"
+	exporterKind = "exporter"
+)
+
+// successViews and failureViews are the internal telemetry views of the
+// collector reporting the data received or exported by the components.
+var (
+	successViews = map[string]string{
+		"receiver/accepted_spans":         receiverKind,
+		"receiver/accepted_metric_points": receiverKind,
+		"receiver/accepted_log_records":   receiverKind,
+		"exporter/sent_spans":             exporterKind,
+		"exporter/sent_metric_points":     exporterKind,
+		"exporter/sent_log_records":       exporterKind,
+	}
+	failureViews = map[string]string{
+		"receiver/refused_spans":             receiverKind,
+		"receiver/refused_metric_points":     receiverKind,
+		"receiver/refused_log_records":       receiverKind,
+		"exporter/send_failed_spans":         exporterKind,
+		"exporter/send_failed_metric_points": exporterKind,
+		"exporter/send_failed_log_records":   exporterKind,
+	}
+)
+
+// componentStatus is the status of a component, as reported in the status document.
+type componentStatus struct {
+	Healthy        bool       `json:"healthy"`
+	Critical       bool       `json:"critical,omitempty"`
+	LastSuccess    *time.Time `json:"last_success,omitempty"`
+	LastFailure    *time.Time `json:"last_failure,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	RecentFailures int        `json:"recent_failures"`
+}
+
+// componentState is the state tracked for a component from the internal telemetry.
+type componentState struct {
+	lastSuccess time.Time
+	lastFailure time.Time
+	lastError   string
+	// failures are the times failures were reported during the interval.
+	failures []time.Time
+}
+
+// statusTracker is an OpenCensus exporter tracking the successes and failures
+// of the receivers and exporters, reported by the collector internal telemetry.
+// It is also a span processor recording the errors of the failed operations of
+// the receivers and exporters, reported in the status of their spans.
+type statusTracker struct {
+	mu        sync.Mutex
+	interval  time.Duration
+	threshold int
+	// components are the states of the components, by kind and ID.
+	components map[string]map[string]*componentState
+	// values are the last cumulative values of the views, by view name and tags.
+	values map[string]float64
+}
+
+var _ view.Exporter = (*statusTracker)(nil)
+var _ sdktrace.SpanProcessor = (*statusTracker)(nil)
+
+func newStatusTracker(interval time.Duration, threshold int) *statusTracker {
+	return &statusTracker{
+		interval:  interval,
+		threshold: threshold,
+		components: map[string]map[string]*componentState{
+			receiverKind: {},
+			exporterKind: {},
+		},
+		values: map[string]float64{},
+	}
+}
+
+// ExportView records a success, or a failure, of the components whose value
+// of a success, or failure, view increased.
+func (t *statusTracker) ExportView(vd *view.Data) {
+	kind, success := successViews[vd.View.Name]
+	if !success {
+		var failure bool
+		if kind, failure = failureViews[vd.View.Name]; !failure {
+			return
+		}
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, row := range vd.Rows {
+		sum, ok := row.Data.(*view.SumData)
+		if !ok {
+			continue
+		}
+		var id string
+		tags := make([]string, 0, len(row.Tags))
+		for _, tag := range row.Tags {
+			if tag.Key.Name() == kind {
+				id = tag.Value
+			}
+			tags = append(tags, tag.Key.Name()+"="+tag.Value)
+		}
+		if id == "" {
+			continue
+		}
+
+		key := vd.View.Name + "{" + strings.Join(tags, ",") + "}"
+		if sum.Value <= t.values[key] {
+			continue
+		}
+		t.values[key] = sum.Value
+
+		state := t.state(kind, id)
+		if success {
+			state.lastSuccess = vd.End
+		} else {
+			state.lastFailure = vd.End
+			state.failures = append(state.failures, vd.End)
+		}
+	}
+}
+
+// OnEnd records the error of a failed operation of a receiver or an exporter.
+// Their spans are named "<kind>/<id>/<operation>".
+func (t *statusTracker) OnEnd(s sdktrace.ReadOnlySpan) {
+	if s.Status().Code != codes.Error {
+		return
+	}
+	kind, rest, ok := strings.Cut(s.Name(), "/")
+	if !ok || (kind != receiverKind && kind != exporterKind) {
+		return
+	}
+	i := strings.LastIndex(rest, "/")
+	if i <= 0 {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.state(kind, rest[:i]).lastError = s.Status().Description
+}
+
+func (t *statusTracker) OnStart(context.Context, sdktrace.ReadWriteSpan) {}
+
+func (t *statusTracker) Shutdown(context.Context) error {
+	return nil
+}
+
+func (t *statusTracker) ForceFlush(context.Context) error {
+	return nil
+}
+
+func (t *statusTracker) state(kind, id string) *componentState {
+	state, ok := t.components[kind][id]
+	if !ok {
+		state = &componentState{}
+		t.components[kind][id] = state
+	}
+	return state
+}
+
+// rotate forgets the failures older than the interval.
+func (t *statusTracker) rotate(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, states := range t.components {
+		for _, state := range states {
+			i := 0
+			for i < len(state.failures) && !state.failures[i].Add(t.interval).After(now) {
+				i++
+			}
+			state.failures = state.failures[i:]
+		}
+	}
+}
+
+// statuses returns the statuses of the components of the given kind, with an
+// entry for each of the known IDs even if nothing was reported for them.
+func (t *statusTracker) statuses(kind string, known []string, critical map[string]bool) map[string]*componentStatus {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	statuses := map[string]*componentStatus{}
+	for _, id := range known {
+		statuses[id] = &componentStatus{Healthy: true}
+	}
+	for id, state := range t.components[kind] {
+		status := &componentStatus{
+			Healthy:        len(state.failures) <= t.threshold,
+			RecentFailures: len(state.failures),
+		}
+		if !state.lastSuccess.IsZero() {
+			lastSuccess := state.lastSuccess
+			status.LastSuccess = &lastSuccess
+		}
+		if !state.lastFailure.IsZero() {
+			lastFailure := state.lastFailure
+			status.LastFailure = &lastFailure
+		}
+		status.LastError = state.lastError
+		statuses[id] = status
+	}
+	for id, status := range statuses {
+		status.Critical = critical[id]
+	}
+	return statuses
+}
+
+// sortedKeys returns the keys of a set, sorted.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package healthcheckextension
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+func viewData(name string, end time.Time, kind string, values map[string]float64) *view.Data {
+	key := tag.MustNewKey(kind)
+	vd := &view.Data{View: &view.View{Name: name}, End: end}
+	for id, value := range values {
+		vd.Rows = append(vd.Rows, &view.Row{
+			Tags: []tag.Tag{{Key: key, Value: id}},
+			Data: &view.SumData{Value: value},
+		})
+	}
+	return vd
+}
+
+func TestStatusTracker(t *testing.T) {
+	tracker := newStatusTracker(time.Minute, 1)
+	start := time.Now()
+
+	tracker.ExportView(viewData("exporter/sent_spans", start, exporterKind, map[string]float64{"otlp/backend": 10, "logging": 5}))
+	tracker.ExportView(viewData("exporter/send_failed_spans", start, exporterKind, map[string]float64{"otlp/backend": 2}))
+	tracker.ExportView(viewData("receiver/accepted_spans", start, receiverKind, map[string]float64{"otlp": 15}))
+	tracker.ExportView(viewData("processor/dropped_spans", start, "processor", map[string]float64{"batch": 1}))
+
+	// Unchanged values aren't new successes or failures.
+	later := start.Add(10 * time.Second)
+	tracker.ExportView(viewData("exporter/sent_spans", later, exporterKind, map[string]float64{"otlp/backend": 10, "logging": 6}))
+	tracker.ExportView(viewData("exporter/send_failed_spans", later, exporterKind, map[string]float64{"otlp/backend": 3}))
+
+	exporters := tracker.statuses(exporterKind, []string{"otlp/backend", "logging", "otlp/unused"}, map[string]bool{"otlp/backend": true})
+	require.Len(t, exporters, 3)
+
+	backend := exporters["otlp/backend"]
+	assert.False(t, backend.Healthy)
+	assert.True(t, backend.Critical)
+	assert.Equal(t, 2, backend.RecentFailures)
+	require.NotNil(t, backend.LastSuccess)
+	assert.Equal(t, start, *backend.LastSuccess)
+	require.NotNil(t, backend.LastFailure)
+	assert.Equal(t, later, *backend.LastFailure)
+
+	logging := exporters["logging"]
+	assert.True(t, logging.Healthy)
+	assert.False(t, logging.Critical)
+	require.NotNil(t, logging.LastSuccess)
+	assert.Equal(t, later, *logging.LastSuccess)
+	assert.Nil(t, logging.LastFailure)
+
+	assert.Equal(t, &componentStatus{Healthy: true}, exporters["otlp/unused"])
+
+	receivers := tracker.statuses(receiverKind, nil, nil)
+	require.Len(t, receivers, 1)
+	assert.True(t, receivers["otlp"].Healthy)
+
+	// The failures older than the interval are forgotten.
+	tracker.rotate(start.Add(65 * time.Second))
+	backend = tracker.statuses(exporterKind, nil, nil)["otlp/backend"]
+	assert.True(t, backend.Healthy)
+	assert.Equal(t, 1, backend.RecentFailures)
+	tracker.rotate(start.Add(75 * time.Second))
+	backend = tracker.statuses(exporterKind, nil, nil)["otlp/backend"]
+	assert.Equal(t, 0, backend.RecentFailures)
+	assert.Equal(t, later, *backend.LastFailure)
+}
+
+func TestStatusTrackerLastError(t *testing.T) {
+	tracker := newStatusTracker(time.Minute, 1)
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(tracker))
+	endSpan := func(name string, code codes.Code, description string) {
+		_, span := tp.Tracer("test").Start(context.Background(), name)
+		span.SetStatus(code, description)
+		span.End()
+	}
+
+	endSpan("exporter/otlp/backend/traces", codes.Error, "connection refused")
+	endSpan("exporter/otlp/backend/traces", codes.Unset, "")
+	endSpan("receiver/otlp/TraceDataReceived", codes.Error, "queue is full")
+	endSpan("processor/batch/traces", codes.Error, "ignored")
+	endSpan("exporter", codes.Error, "ignored")
+
+	// The last error is kept after a success.
+	assert.Equal(t, "connection refused", tracker.statuses(exporterKind, nil, nil)["otlp/backend"].LastError)
+	receivers := tracker.statuses(receiverKind, nil, nil)
+	require.Len(t, receivers, 1)
+	assert.Equal(t, "queue is full", receivers["otlp"].LastError)
+}
@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtlsauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/mtlsauthextension"
+
+import (
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+)
+
+const defaultForwardedClientCertHeader = "X-Forwarded-Client-Cert"
+
+// Config defines the configuration for the mTLS authenticator.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"`
+
+	// Allow lists the identities allowed to send data. When empty, any client
+	// with a verified certificate is allowed, unless denied.
+	Allow IdentityPatterns `mapstructure:"allow"`
+
+	// Deny lists the identities denied to send data, it has precedence over Allow.
+	Deny IdentityPatterns `mapstructure:"deny"`
+
+	// ForwardedClientCert configures reading the client certificate identity
+	// from a header set by a proxy terminating the TLS connections.
+	ForwardedClientCert ForwardedClientCertSettings `mapstructure:"forwarded_client_cert"`
+}
+
+// IdentityPatterns defines regular expressions matched against the identity of
+// the client certificates. The expressions must match the whole value.
+type IdentityPatterns struct {
+	// SPIFFEIDs are matched against the SPIFFE ID of the certificate, e.g. "spiffe://example.org/ns/prod/.*".
+	SPIFFEIDs []string `mapstructure:"spiffe_ids"`
+
+	// Subjects are matched against the RFC 2253 string of the certificate subject, e.g. "CN=.*,O=Example".
+	Subjects []string `mapstructure:"subjects"`
+}
+
+// ForwardedClientCertSettings defines how to read the client certificate
+// identity from the header set by a proxy, in the format of the Envoy
+// x-forwarded-client-cert header.
+type ForwardedClientCertSettings struct {
+	// Enabled makes the identity read from the header instead of the TLS
+	// connection. The header must be set, and sanitized, by a trusted proxy.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Header is the name of the header. Defaults to "X-Forwarded-Client-Cert".
+	Header string `mapstructure:"header"`
+}
+
+var _ component.ExtensionConfig = (*Config)(nil)
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if _, err := cfg.Allow.compile(); err != nil {
+		return fmt.Errorf("allow: %w", err)
+	}
+	if _, err := cfg.Deny.compile(); err != nil {
+		return fmt.Errorf("deny: %w", err)
+	}
+	if cfg.ForwardedClientCert.Enabled && cfg.ForwardedClientCert.Header == "" {
+		return fmt.Errorf("forwarded_client_cert: header must not be empty")
+	}
+	return nil
+}
+
+// identityMatcher matches client identities against compiled IdentityPatterns.
+type identityMatcher struct {
+	spiffeIDs []*regexp.Regexp
+	subjects  []*regexp.Regexp
+}
+
+func (p IdentityPatterns) compile() (*identityMatcher, error) {
+	m := &identityMatcher{}
+	var err error
+	if m.spiffeIDs, err = compilePatterns(p.SPIFFEIDs); err != nil {
+		return nil, fmt.Errorf("spiffe_ids: %w", err)
+	}
+	if m.subjects, err = compilePatterns(p.Subjects); err != nil {
+		return nil, fmt.Errorf("subjects: %w", err)
+	}
+	return m, nil
+}
+
+func compilePatterns(patterns []string) ([]*regexp.Regexp, error) {
+	regexps := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile("^(?:" + pattern + ")$")
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		regexps = append(regexps, re)
+	}
+	return regexps, nil
+}
+
+func (m *identityMatcher) empty() bool {
+	return len(m.spiffeIDs) == 0 && len(m.subjects) == 0
+}
+
+func (m *identityMatcher) matches(id *identity) bool {
+	if id.spiffeID != "" {
+		for _, re := range m.spiffeIDs {
+			if re.MatchString(id.spiffeID) {
+				return true
+			}
+		}
+	}
+	for _, re := range m.subjects {
+		if re.MatchString(id.subject) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtlsauthextension
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id          component.ID
+		expected    component.ExtensionConfig
+		expectedErr string
+	}{
+		{
+			id:       component.NewID(typeStr),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(typeStr, "patterns"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(component.NewID(typeStr)),
+				Allow: IdentityPatterns{
+					SPIFFEIDs: []string{"spiffe://example.org/ns/prod/.*"},
+					Subjects:  []string{`CN=.*\.prod\.example\.org`},
+				},
+				Deny: IdentityPatterns{
+					SPIFFEIDs: []string{"spiffe://example.org/ns/prod/sa/legacy"},
+				},
+				ForwardedClientCert: ForwardedClientCertSettings{
+					Header: "X-Forwarded-Client-Cert",
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(typeStr, "forwarded"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(component.NewID(typeStr)),
+				ForwardedClientCert: ForwardedClientCertSettings{
+					Enabled: true,
+					Header:  "X-Forwarded-Client-Cert",
+				},
+			},
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "invalid_pattern"),
+			expectedErr: `allow: subjects: invalid pattern "CN=("`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalExtensionConfig(sub, cfg))
+			if tt.expectedErr != "" {
+				assert.ErrorContains(t, component.ValidateConfig(cfg), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
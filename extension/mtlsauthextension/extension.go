@@ -0,0 +1,129 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtlsauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/mtlsauthextension"
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/config/configauth"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+var (
+	errNoCertificate = errors.New("no verified client certificate")
+	errDenied        = errors.New("client certificate identity not allowed")
+)
+
+type mtlsAuth struct {
+	allow               *identityMatcher
+	deny                *identityMatcher
+	forwardedClientCert ForwardedClientCertSettings
+}
+
+func newServerAuthExtension(cfg *Config) (configauth.ServerAuthenticator, error) {
+	allow, err := cfg.Allow.compile()
+	if err != nil {
+		return nil, err
+	}
+	deny, err := cfg.Deny.compile()
+	if err != nil {
+		return nil, err
+	}
+
+	ma := mtlsAuth{
+		allow:               allow,
+		deny:                deny,
+		forwardedClientCert: cfg.ForwardedClientCert,
+	}
+	return configauth.NewServerAuthenticator(
+		configauth.WithAuthenticate(ma.authenticate),
+	), nil
+}
+
+func (ma *mtlsAuth) authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	id, err := ma.identity(ctx, headers)
+	if err != nil {
+		return ctx, err
+	}
+
+	if ma.deny.matches(id) || (!ma.allow.empty() && !ma.allow.matches(id)) {
+		return ctx, errDenied
+	}
+
+	cl := client.FromContext(ctx)
+	cl.Auth = &authData{identity: id}
+	return client.NewContext(ctx, cl), nil
+}
+
+// identity returns the identity of the client certificate, from the forwarded
+// client certificate header when enabled, otherwise from the TLS connection.
+func (ma *mtlsAuth) identity(ctx context.Context, headers map[string][]string) (*identity, error) {
+	if ma.forwardedClientCert.Enabled {
+		var values []string
+		for name, v := range headers {
+			if strings.EqualFold(name, ma.forwardedClientCert.Header) {
+				values = v
+				break
+			}
+		}
+		if len(values) == 0 {
+			return nil, errNoCertificate
+		}
+		return identityFromForwardedClientCert(strings.Join(values, ","))
+	}
+
+	// Only gRPC servers expose the TLS connection in the context.
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil, errNoCertificate
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.VerifiedChains) == 0 || len(tlsInfo.State.VerifiedChains[0]) == 0 {
+		return nil, errNoCertificate
+	}
+	return identityFromCertificate(tlsInfo.State.VerifiedChains[0][0]), nil
+}
+
+var _ client.AuthData = (*authData)(nil)
+
+// authData exposes the identity of a client certificate.
+type authData struct {
+	identity *identity
+}
+
+func (a *authData) GetAttribute(name string) interface{} {
+	switch name {
+	case "subject":
+		return a.identity.subject
+	case "common_name":
+		return a.identity.commonName
+	case "dns_names":
+		return a.identity.dnsNames
+	case "uris":
+		return a.identity.uris
+	case "spiffe_id":
+		return a.identity.spiffeID
+	default:
+		return nil
+	}
+}
+
+func (*authData) GetAttributeNames() []string {
+	return []string{"subject", "common_name", "dns_names", "uris", "spiffe_id"}
+}
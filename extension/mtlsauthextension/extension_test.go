@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtlsauthextension
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+)
+
+func peerContext(cert *x509.Certificate) context.Context {
+	state := tls.ConnectionState{}
+	if cert != nil {
+		state.PeerCertificates = []*x509.Certificate{cert}
+		state.VerifiedChains = [][]*x509.Certificate{{cert}}
+	}
+	return peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{State: state}})
+}
+
+func certificate(cn string, spiffeID string) *x509.Certificate {
+	cert := &x509.Certificate{Subject: pkix.Name{CommonName: cn}}
+	if spiffeID != "" {
+		u, _ := url.Parse(spiffeID)
+		cert.URIs = []*url.URL{u}
+	}
+	return cert
+}
+
+func TestAuthenticate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Allow = IdentityPatterns{
+		SPIFFEIDs: []string{"spiffe://example.org/ns/prod/.*"},
+		Subjects:  []string{`CN=.*\.prod\.example\.org`},
+	}
+	cfg.Deny = IdentityPatterns{
+		SPIFFEIDs: []string{"spiffe://example.org/ns/prod/sa/legacy"},
+	}
+	auth, err := newServerAuthExtension(cfg)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		ctx         context.Context
+		expectedErr error
+	}{
+		{
+			name:        "no peer",
+			ctx:         context.Background(),
+			expectedErr: errNoCertificate,
+		},
+		{
+			name:        "no verified certificate",
+			ctx:         peerContext(nil),
+			expectedErr: errNoCertificate,
+		},
+		{
+			name: "allowed spiffe id",
+			ctx:  peerContext(certificate("checkout", "spiffe://example.org/ns/prod/sa/checkout")),
+		},
+		{
+			name: "allowed subject",
+			ctx:  peerContext(certificate("checkout.prod.example.org", "")),
+		},
+		{
+			name:        "not allowed",
+			ctx:         peerContext(certificate("checkout", "spiffe://example.org/ns/dev/sa/checkout")),
+			expectedErr: errDenied,
+		},
+		{
+			name:        "not a prefix match",
+			ctx:         peerContext(certificate("checkout.prod.example.org.evil.com", "spiffe://evil.com/spiffe://example.org/ns/prod/")),
+			expectedErr: errDenied,
+		},
+		{
+			name:        "denied",
+			ctx:         peerContext(certificate("legacy.prod.example.org", "spiffe://example.org/ns/prod/sa/legacy")),
+			expectedErr: errDenied,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, err := auth.Authenticate(tt.ctx, map[string][]string{})
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				assert.Nil(t, client.FromContext(ctx).Auth)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, client.FromContext(ctx).Auth)
+		})
+	}
+}
+
+func TestAuthenticateAuthData(t *testing.T) {
+	auth, err := newServerAuthExtension(createDefaultConfig().(*Config))
+	require.NoError(t, err)
+
+	cert := certificate("checkout", "spiffe://example.org/ns/prod/sa/checkout")
+	cert.DNSNames = []string{"checkout.example.org"}
+	ctx, err := auth.Authenticate(peerContext(cert), map[string][]string{})
+	require.NoError(t, err)
+
+	authData := client.FromContext(ctx).Auth
+	require.NotNil(t, authData)
+	assert.Equal(t, "CN=checkout", authData.GetAttribute("subject"))
+	assert.Equal(t, "checkout", authData.GetAttribute("common_name"))
+	assert.Equal(t, []string{"checkout.example.org"}, authData.GetAttribute("dns_names"))
+	assert.Equal(t, []string{"spiffe://example.org/ns/prod/sa/checkout"}, authData.GetAttribute("uris"))
+	assert.Equal(t, "spiffe://example.org/ns/prod/sa/checkout", authData.GetAttribute("spiffe_id"))
+	assert.Nil(t, authData.GetAttribute("unknown"))
+	assert.Equal(t, []string{"subject", "common_name", "dns_names", "uris", "spiffe_id"}, authData.GetAttributeNames())
+}
+
+func TestAuthenticateForwardedClientCert(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.ForwardedClientCert.Enabled = true
+	cfg.Allow.SPIFFEIDs = []string{"spiffe://example.org/ns/prod/.*"}
+	auth, err := newServerAuthExtension(cfg)
+	require.NoError(t, err)
+
+	// The identity of the TLS connection is the one of the proxy, and is ignored.
+	proxyCtx := peerContext(certificate("proxy", "spiffe://example.org/ns/mesh/sa/proxy"))
+
+	ctx, err := auth.Authenticate(proxyCtx, map[string][]string{
+		"x-forwarded-client-cert": {`Hash=abc;Subject="CN=checkout";URI=spiffe://example.org/ns/prod/sa/checkout`},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "spiffe://example.org/ns/prod/sa/checkout", client.FromContext(ctx).Auth.GetAttribute("spiffe_id"))
+
+	_, err = auth.Authenticate(proxyCtx, map[string][]string{})
+	assert.ErrorIs(t, err, errNoCertificate)
+
+	_, err = auth.Authenticate(proxyCtx, map[string][]string{
+		"X-Forwarded-Client-Cert": {`Hash=abc;Subject="CN=checkout";URI=spiffe://example.org/ns/dev/sa/checkout`},
+	})
+	assert.ErrorIs(t, err, errDenied)
+
+	_, err = auth.Authenticate(proxyCtx, map[string][]string{
+		"X-Forwarded-Client-Cert": {`Hash=abc`},
+	})
+	assert.ErrorIs(t, err, errInvalidForwardedClientCert)
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtlsauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/mtlsauthextension"
+
+import (
+	"crypto/x509"
+	"errors"
+	"strings"
+)
+
+const spiffeScheme = "spiffe"
+
+var errInvalidForwardedClientCert = errors.New("invalid forwarded client certificate header")
+
+// identity is the identity of a client certificate.
+type identity struct {
+	subject    string
+	commonName string
+	dnsNames   []string
+	uris       []string
+	spiffeID   string
+}
+
+// identityFromCertificate returns the identity of a verified client certificate.
+func identityFromCertificate(cert *x509.Certificate) *identity {
+	id := &identity{
+		subject:    cert.Subject.String(),
+		commonName: cert.Subject.CommonName,
+		dnsNames:   cert.DNSNames,
+	}
+	for _, uri := range cert.URIs {
+		id.uris = append(id.uris, uri.String())
+		if id.spiffeID == "" && uri.Scheme == spiffeScheme {
+			id.spiffeID = uri.String()
+		}
+	}
+	return id
+}
+
+// identityFromForwardedClientCert returns the identity of the client
+// certificate in a header in the format of the Envoy x-forwarded-client-cert
+// header, e.g. `By=...;Subject="CN=client,O=Example";URI=spiffe://example.org/client;DNS=client.example.org`.
+// When the header has several elements, one per proxy, the last one is used
+// as it was added by the proxy closest to the collector.
+func identityFromForwardedClientCert(value string) (*identity, error) {
+	elements := splitUnquoted(value, ',')
+	element := strings.TrimSpace(elements[len(elements)-1])
+	if element == "" {
+		return nil, errInvalidForwardedClientCert
+	}
+
+	id := &identity{}
+	for _, pair := range splitUnquoted(element, ';') {
+		key, val, found := strings.Cut(strings.TrimSpace(pair), "=")
+		if !found {
+			return nil, errInvalidForwardedClientCert
+		}
+		val = unquote(val)
+		switch strings.ToLower(key) {
+		case "subject":
+			id.subject = val
+			id.commonName = commonName(val)
+		case "uri":
+			id.uris = append(id.uris, val)
+			if id.spiffeID == "" && strings.HasPrefix(val, spiffeScheme+"://") {
+				id.spiffeID = val
+			}
+		case "dns":
+			id.dnsNames = append(id.dnsNames, val)
+		}
+	}
+	if id.subject == "" && len(id.uris) == 0 && len(id.dnsNames) == 0 {
+		return nil, errInvalidForwardedClientCert
+	}
+	return id, nil
+}
+
+// splitUnquoted splits s around sep, ignoring the separators between double quotes.
+func splitUnquoted(s string, sep byte) []string {
+	var parts []string
+	inQuotes, escaped := false, false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case escaped:
+			escaped = false
+		case c == '\\' && inQuotes:
+			escaped = true
+		case c == '"':
+			inQuotes = !inQuotes
+		case c == sep && !inQuotes:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		return strings.ReplaceAll(s[1:len(s)-1], `\"`, `"`)
+	}
+	return s
+}
+
+// commonName returns the CN attribute of an RFC 2253 distinguished name.
+func commonName(dn string) string {
+	for _, rdn := range strings.Split(dn, ",") {
+		if key, val, found := strings.Cut(strings.TrimSpace(rdn), "="); found && strings.EqualFold(key, "CN") {
+			return val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,91 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mtlsauthextension
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdentityFromCertificate(t *testing.T) {
+	cert := &x509.Certificate{
+		Subject: pkix.Name{
+			CommonName:   "checkout.prod.example.org",
+			Organization: []string{"Example"},
+		},
+		DNSNames: []string{"checkout.prod.example.org"},
+		URIs: []*url.URL{
+			{Scheme: "https", Host: "example.org"},
+			{Scheme: "spiffe", Host: "example.org", Path: "/ns/prod/sa/checkout"},
+		},
+	}
+
+	assert.Equal(t, &identity{
+		subject:    "CN=checkout.prod.example.org,O=Example",
+		commonName: "checkout.prod.example.org",
+		dnsNames:   []string{"checkout.prod.example.org"},
+		uris:       []string{"https://example.org", "spiffe://example.org/ns/prod/sa/checkout"},
+		spiffeID:   "spiffe://example.org/ns/prod/sa/checkout",
+	}, identityFromCertificate(cert))
+}
+
+func TestIdentityFromForwardedClientCert(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected *identity
+	}{
+		{
+			name:  "single element",
+			value: `By=spiffe://example.org/ns/gateway/sa/collector;Hash=abc;Subject="CN=checkout,O=Example";URI=spiffe://example.org/ns/prod/sa/checkout;DNS=checkout.example.org;DNS=checkout`,
+			expected: &identity{
+				subject:    "CN=checkout,O=Example",
+				commonName: "checkout",
+				dnsNames:   []string{"checkout.example.org", "checkout"},
+				uris:       []string{"spiffe://example.org/ns/prod/sa/checkout"},
+				spiffeID:   "spiffe://example.org/ns/prod/sa/checkout",
+			},
+		},
+		{
+			name:  "several elements",
+			value: `By=spiffe://example.org/a;URI=spiffe://example.org/spoofed,By=spiffe://example.org/b;Subject="CN=\"quoted\",O=Example";URI=spiffe://example.org/ns/prod/sa/checkout`,
+			expected: &identity{
+				subject:    `CN="quoted",O=Example`,
+				commonName: `"quoted"`,
+				uris:       []string{"spiffe://example.org/ns/prod/sa/checkout"},
+				spiffeID:   "spiffe://example.org/ns/prod/sa/checkout",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, err := identityFromForwardedClientCert(tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, id)
+		})
+	}
+}
+
+func TestIdentityFromForwardedClientCertErrors(t *testing.T) {
+	for _, value := range []string{"", "By=spiffe://example.org/a;Hash=abc", "Subject"} {
+		_, err := identityFromForwardedClientCert(value)
+		assert.ErrorIs(t, err, errInvalidForwardedClientCert, value)
+	}
+}
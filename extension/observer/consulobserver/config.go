@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consulobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/consulobserver"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+// Config defines configuration for the Consul observer.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"`
+
+	// Address is the address of the Consul agent, e.g. localhost:8500 or https://consul:8501.
+	// If not provided, the CONSUL_HTTP_ADDR environment variable or localhost:8500 is used.
+	Address string `mapstructure:"address"`
+
+	// Datacenter to watch. If not provided, the datacenter of the agent is used.
+	Datacenter string `mapstructure:"datacenter"`
+
+	// Token is used to provide a per-request ACL token which overrides the
+	// agent's default token. Token or TokenFile are only required if Consul's
+	// ACL System is enabled.
+	Token string `mapstructure:"token"`
+
+	// TokenFile is a file containing the token to use for the requests.
+	TokenFile string `mapstructure:"token_file"`
+
+	// Namespace of the services to watch, for Consul Enterprise.
+	Namespace string `mapstructure:"namespace"`
+
+	// Services is the list of the names of the services to watch. All the
+	// services of the catalog are watched when empty.
+	Services []string `mapstructure:"services"`
+
+	// Tags is the list of tags the instances must all have to be reported.
+	Tags []string `mapstructure:"tags"`
+
+	// PassingOnly determines whether to only report the instances whose health
+	// checks are passing. `true` by default.
+	PassingOnly bool `mapstructure:"passing_only"`
+
+	// RefreshInterval determines how often the changes of the catalog are
+	// reported. The catalog is watched with blocking queries, and the changes
+	// are reported at the next refresh.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+}
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.RefreshInterval <= 0 {
+		return errors.New("refresh_interval must be positive")
+	}
+	return nil
+}
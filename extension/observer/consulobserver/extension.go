@@ -0,0 +1,279 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consulobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/consulobserver"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+const (
+	// waitTime bounds the duration of the blocking queries.
+	waitTime = 5 * time.Minute
+	// defaultRetryInterval is the time to wait before retrying a failed query.
+	defaultRetryInterval = 5 * time.Second
+)
+
+var _ component.Extension = (*consulObserver)(nil)
+var _ observer.Observable = (*consulObserver)(nil)
+
+type consulObserver struct {
+	*observer.EndpointsWatcher
+	lister *endpointsLister
+}
+
+// endpointsLister watches the Consul catalog with blocking queries, and lists
+// the latest known instances of the services as endpoints.
+type endpointsLister struct {
+	config        *Config
+	logger        *zap.Logger
+	client        *api.Client
+	observerName  string
+	retryInterval time.Duration
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mu sync.Mutex
+	// services holds the endpoints of each watched service.
+	services map[string][]observer.Endpoint
+	// watchers holds the cancel functions of the service watchers.
+	watchers map[string]context.CancelFunc
+}
+
+func newObserver(logger *zap.Logger, config *Config) (component.Extension, error) {
+	apiConfig := api.DefaultConfig()
+	if config.Address != "" {
+		apiConfig.Address = config.Address
+	}
+	if config.Datacenter != "" {
+		apiConfig.Datacenter = config.Datacenter
+	}
+	if config.Namespace != "" {
+		apiConfig.Namespace = config.Namespace
+	}
+	if config.Token != "" {
+		apiConfig.Token = config.Token
+	}
+	if config.TokenFile != "" {
+		apiConfig.TokenFile = config.TokenFile
+	}
+
+	client, err := api.NewClient(apiConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating consul client: %w", err)
+	}
+
+	lister := &endpointsLister{
+		config:        config,
+		logger:        logger,
+		client:        client,
+		observerName:  config.ID().String(),
+		retryInterval: defaultRetryInterval,
+		services:      map[string][]observer.Endpoint{},
+		watchers:      map[string]context.CancelFunc{},
+	}
+	return &consulObserver{
+		EndpointsWatcher: observer.NewEndpointsWatcher(lister, config.RefreshInterval, logger),
+		lister:           lister,
+	}, nil
+}
+
+func (c *consulObserver) Start(context.Context, component.Host) error {
+	var ctx context.Context
+	ctx, c.lister.cancel = context.WithCancel(context.Background())
+	c.lister.wg.Add(1)
+	go c.lister.watchServices(ctx)
+	return nil
+}
+
+func (c *consulObserver) Shutdown(context.Context) error {
+	c.StopListAndWatch()
+	if c.lister.cancel != nil {
+		c.lister.cancel()
+	}
+	c.lister.wg.Wait()
+	return nil
+}
+
+func (e *endpointsLister) ListEndpoints() []observer.Endpoint {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var endpoints []observer.Endpoint
+	for _, serviceEndpoints := range e.services {
+		endpoints = append(endpoints, serviceEndpoints...)
+	}
+	return endpoints
+}
+
+// watchServices watches the services of the catalog, and starts or stops
+// watching their instances as they are registered or deregistered.
+func (e *endpointsLister) watchServices(ctx context.Context) {
+	defer e.wg.Done()
+
+	var index uint64
+	for {
+		services, meta, err := e.client.Catalog().Services(e.queryOptions(ctx, index))
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			e.logger.Warn("Failed to list the services of the Consul catalog", zap.Error(err))
+			if !e.waitRetry(ctx) {
+				return
+			}
+			continue
+		}
+		index = nextIndex(index, meta.LastIndex)
+		e.updateServices(ctx, services)
+	}
+}
+
+func (e *endpointsLister) updateServices(ctx context.Context, services map[string][]string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for name, cancel := range e.watchers {
+		if _, ok := services[name]; !ok {
+			cancel()
+			delete(e.watchers, name)
+			delete(e.services, name)
+		}
+	}
+
+	for name := range services {
+		if _, ok := e.watchers[name]; ok || !e.watched(name) {
+			continue
+		}
+		serviceCtx, cancel := context.WithCancel(ctx)
+		e.watchers[name] = cancel
+		e.wg.Add(1)
+		go e.watchService(serviceCtx, name)
+	}
+}
+
+func (e *endpointsLister) watched(name string) bool {
+	if len(e.config.Services) == 0 {
+		return true
+	}
+	for _, service := range e.config.Services {
+		if service == name {
+			return true
+		}
+	}
+	return false
+}
+
+// watchService watches the instances of a service until the context is canceled.
+func (e *endpointsLister) watchService(ctx context.Context, name string) {
+	defer e.wg.Done()
+
+	var index uint64
+	for {
+		entries, meta, err := e.client.Health().ServiceMultipleTags(name, e.config.Tags, e.config.PassingOnly, e.queryOptions(ctx, index))
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			e.logger.Warn("Failed to list the instances of a Consul service", zap.String("service", name), zap.Error(err))
+			if !e.waitRetry(ctx) {
+				return
+			}
+			continue
+		}
+		index = nextIndex(index, meta.LastIndex)
+
+		endpoints := make([]observer.Endpoint, 0, len(entries))
+		for _, entry := range entries {
+			endpoints = append(endpoints, e.convertEntry(entry))
+		}
+
+		e.mu.Lock()
+		// The service may have been deregistered while the query was running.
+		if ctx.Err() == nil {
+			e.services[name] = endpoints
+		}
+		e.mu.Unlock()
+	}
+}
+
+// convertEntry converts an instance of a service into a hostport endpoint. The
+// address of the node is used when the instance doesn't have its own address.
+func (e *endpointsLister) convertEntry(entry *api.ServiceEntry) observer.Endpoint {
+	address := entry.Service.Address
+	var nodeName string
+	if entry.Node != nil {
+		nodeName = entry.Node.Node
+		if address == "" {
+			address = entry.Node.Address
+		}
+	}
+	ip := net.ParseIP(address)
+
+	return observer.Endpoint{
+		ID:     observer.EndpointID(fmt.Sprintf("%s/%s/%s", e.observerName, nodeName, entry.Service.ID)),
+		Target: net.JoinHostPort(address, strconv.Itoa(entry.Service.Port)),
+		Details: &observer.HostPort{
+			Port:        uint16(entry.Service.Port),
+			Transport:   observer.ProtocolTCP,
+			IsIPv6:      ip != nil && ip.To4() == nil,
+			ServiceName: entry.Service.Service,
+			Tags:        entry.Service.Tags,
+			Metadata:    entry.Service.Meta,
+		},
+	}
+}
+
+func (e *endpointsLister) queryOptions(ctx context.Context, index uint64) *api.QueryOptions {
+	return (&api.QueryOptions{WaitIndex: index, WaitTime: waitTime}).WithContext(ctx)
+}
+
+// waitRetry waits for the retry interval, and returns false if the context is
+// canceled in the meantime.
+func (e *endpointsLister) waitRetry(ctx context.Context) bool {
+	timer := time.NewTimer(e.retryInterval)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// nextIndex returns the index of the next blocking query. The index is reset
+// when it goes backwards, e.g. after a restore of the Consul servers, and is
+// at least 1 so that the next query blocks.
+func nextIndex(previous, last uint64) uint64 {
+	switch {
+	case last < previous:
+		return 0
+	case last < 1:
+		return 1
+	default:
+		return last
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package consulobserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+// fakeConsul serves the catalog and health endpoints of the Consul HTTP API.
+// The blocking queries return immediately, and are retried by the observer.
+type fakeConsul struct {
+	mu       sync.Mutex
+	index    int
+	services map[string][]*api.ServiceEntry
+}
+
+func (f *fakeConsul) setServices(services map[string][]*api.ServiceEntry) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.index++
+	f.services = services
+}
+
+func (f *fakeConsul) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	w.Header().Set("X-Consul-Index", strconv.Itoa(f.index))
+	switch {
+	case r.URL.Path == "/v1/catalog/services":
+		services := map[string][]string{}
+		for name := range f.services {
+			services[name] = nil
+		}
+		_ = json.NewEncoder(w).Encode(services)
+	case strings.HasPrefix(r.URL.Path, "/v1/health/service/"):
+		entries := f.services[strings.TrimPrefix(r.URL.Path, "/v1/health/service/")]
+		if entries == nil {
+			entries = []*api.ServiceEntry{}
+		}
+		_ = json.NewEncoder(w).Encode(entries)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func entry(node, nodeAddress, id, service, address string, port int, tags []string, meta map[string]string) *api.ServiceEntry {
+	return &api.ServiceEntry{
+		Node: &api.Node{Node: node, Address: nodeAddress},
+		Service: &api.AgentService{
+			ID:      id,
+			Service: service,
+			Address: address,
+			Port:    port,
+			Tags:    tags,
+			Meta:    meta,
+		},
+	}
+}
+
+type endpointsSink struct {
+	mu        sync.Mutex
+	endpoints map[observer.EndpointID]observer.Endpoint
+}
+
+func (s *endpointsSink) ID() observer.NotifyID {
+	return "endpoints_sink"
+}
+
+func (s *endpointsSink) OnAdd(added []observer.Endpoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range added {
+		s.endpoints[e.ID] = e
+	}
+}
+
+func (s *endpointsSink) OnRemove(removed []observer.Endpoint) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range removed {
+		delete(s.endpoints, e.ID)
+	}
+}
+
+func (s *endpointsSink) OnChange(changed []observer.Endpoint) {
+	s.OnAdd(changed)
+}
+
+func (s *endpointsSink) get() map[observer.EndpointID]observer.Endpoint {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	endpoints := map[observer.EndpointID]observer.Endpoint{}
+	for id, e := range s.endpoints {
+		endpoints[id] = e
+	}
+	return endpoints
+}
+
+func TestObserver(t *testing.T) {
+	consul := &fakeConsul{}
+	consul.setServices(map[string][]*api.ServiceEntry{
+		"redis": {
+			entry("node-1", "10.0.0.1", "redis-1", "redis", "", 6379, []string{"primary"}, map[string]string{"version": "7"}),
+			entry("node-2", "10.0.0.2", "redis-2", "redis", "fd00::2", 6380, nil, nil),
+		},
+		"consul": {
+			entry("node-1", "10.0.0.1", "consul", "consul", "", 8300, nil, nil),
+		},
+	})
+	server := httptest.NewServer(consul)
+	defer server.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Address = server.URL
+	cfg.Services = []string{"redis", "postgres"}
+	cfg.RefreshInterval = 10 * time.Millisecond
+
+	ext, err := newObserver(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	obs := ext.(*consulObserver)
+	obs.lister.retryInterval = 10 * time.Millisecond
+
+	require.NoError(t, obs.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, obs.Shutdown(context.Background()))
+	}()
+
+	sink := &endpointsSink{endpoints: map[observer.EndpointID]observer.Endpoint{}}
+	obs.ListAndWatch(sink)
+
+	require.Eventually(t, func() bool {
+		return len(sink.get()) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, map[observer.EndpointID]observer.Endpoint{
+		"consul_observer/node-1/redis-1": {
+			ID:     "consul_observer/node-1/redis-1",
+			Target: "10.0.0.1:6379",
+			Details: &observer.HostPort{
+				Port:        6379,
+				Transport:   observer.ProtocolTCP,
+				ServiceName: "redis",
+				Tags:        []string{"primary"},
+				Metadata:    map[string]string{"version": "7"},
+			},
+		},
+		"consul_observer/node-2/redis-2": {
+			ID:     "consul_observer/node-2/redis-2",
+			Target: "[fd00::2]:6380",
+			Details: &observer.HostPort{
+				Port:        6380,
+				Transport:   observer.ProtocolTCP,
+				IsIPv6:      true,
+				ServiceName: "redis",
+			},
+		},
+	}, sink.get())
+
+	consul.setServices(map[string][]*api.ServiceEntry{
+		"postgres": {
+			entry("node-3", "10.0.0.3", "postgres-1", "postgres", "", 5432, nil, nil),
+		},
+	})
+
+	require.Eventually(t, func() bool {
+		endpoints := sink.get()
+		_, ok := endpoints["consul_observer/node-3/postgres-1"]
+		return len(endpoints) == 1 && ok
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestNextIndex(t *testing.T) {
+	assert.Equal(t, uint64(1), nextIndex(0, 0))
+	assert.Equal(t, uint64(5), nextIndex(1, 5))
+	assert.Equal(t, uint64(5), nextIndex(5, 5))
+	assert.Equal(t, uint64(0), nextIndex(5, 2))
+}
@@ -36,6 +36,10 @@ const (
 	PodType EndpointType = "pod"
 	// K8sNodeType is a Kubernetes Node endpoint.
 	K8sNodeType EndpointType = "k8s.node"
+	// K8sServiceType is a Kubernetes Service endpoint.
+	K8sServiceType EndpointType = "k8s.service"
+	// K8sIngressType is a Kubernetes Ingress endpoint.
+	K8sIngressType EndpointType = "k8s.ingress"
 	// HostPortType is a hostport endpoint.
 	HostPortType EndpointType = "hostport"
 	// ContainerType is a container endpoint.
@@ -46,6 +50,8 @@ var (
 	_ EndpointDetails = (*Pod)(nil)
 	_ EndpointDetails = (*Port)(nil)
 	_ EndpointDetails = (*K8sNode)(nil)
+	_ EndpointDetails = (*K8sService)(nil)
+	_ EndpointDetails = (*K8sIngress)(nil)
 	_ EndpointDetails = (*HostPort)(nil)
 	_ EndpointDetails = (*Container)(nil)
 )
@@ -170,6 +176,13 @@ type HostPort struct {
 	Transport Transport
 	// IsIPv6 indicates whether or not the Endpoint is IPv6.
 	IsIPv6 bool
+	// ServiceName is the name of the service the Endpoint is registered as in a
+	// service catalog such as Consul. Empty otherwise.
+	ServiceName string
+	// Tags of the service in the service catalog.
+	Tags []string
+	// Metadata of the service in the service catalog.
+	Metadata map[string]string
 }
 
 func (h *HostPort) Env() EndpointEnv {
@@ -179,6 +192,9 @@ func (h *HostPort) Env() EndpointEnv {
 		"is_ipv6":      h.IsIPv6,
 		"port":         h.Port,
 		"transport":    h.Transport,
+		"service_name": h.ServiceName,
+		"tags":         h.Tags,
+		"metadata":     h.Metadata,
 	}
 }
 
@@ -273,3 +289,95 @@ func (n *K8sNode) Env() EndpointEnv {
 func (n *K8sNode) Type() EndpointType {
 	return K8sNodeType
 }
+
+// K8sService represents a Kubernetes Service object.
+type K8sService struct {
+	// Name of the service.
+	Name string
+	// UID is the unique ID in the cluster for the service.
+	UID string
+	// Labels is a map of user-specified metadata.
+	Labels map[string]string
+	// Annotations is a map of user-specified metadata.
+	Annotations map[string]string
+	// Namespace must be unique for services with same name.
+	Namespace string
+	// ServiceType is the type of the service: ClusterIP, NodePort, LoadBalancer or ExternalName.
+	ServiceType string
+	// ClusterIP is the virtual IP of the service, "None" for headless services.
+	ClusterIP string
+	// Ports exposed by the service.
+	Ports []K8sServicePort
+}
+
+// K8sServicePort is a port exposed by a Kubernetes Service.
+type K8sServicePort struct {
+	// Name of the service port.
+	Name string
+	// Port number exposed by the service.
+	Port uint16
+	// Transport is the transport protocol used by the port. (TCP or UDP).
+	Transport Transport
+}
+
+func (s *K8sService) Env() EndpointEnv {
+	ports := make([]map[string]interface{}, len(s.Ports))
+	for i, port := range s.Ports {
+		ports[i] = map[string]interface{}{
+			"name":      port.Name,
+			"port":      port.Port,
+			"transport": port.Transport,
+		}
+	}
+	return map[string]interface{}{
+		"uid":          s.UID,
+		"name":         s.Name,
+		"labels":       s.Labels,
+		"annotations":  s.Annotations,
+		"namespace":    s.Namespace,
+		"service_type": s.ServiceType,
+		"cluster_ip":   s.ClusterIP,
+		"ports":        ports,
+	}
+}
+
+func (s *K8sService) Type() EndpointType {
+	return K8sServiceType
+}
+
+// K8sIngress represents a host and path routed by a Kubernetes Ingress object.
+type K8sIngress struct {
+	// Name of the ingress.
+	Name string
+	// UID is the unique ID in the cluster for the ingress.
+	UID string
+	// Labels is a map of user-specified metadata.
+	Labels map[string]string
+	// Annotations is a map of user-specified metadata.
+	Annotations map[string]string
+	// Namespace must be unique for ingresses with same name.
+	Namespace string
+	// Scheme is "https" when TLS is configured for the host, "http" otherwise.
+	Scheme string
+	// Host routed by the ingress.
+	Host string
+	// Path routed by the ingress.
+	Path string
+}
+
+func (i *K8sIngress) Env() EndpointEnv {
+	return map[string]interface{}{
+		"uid":         i.UID,
+		"name":        i.Name,
+		"labels":      i.Labels,
+		"annotations": i.Annotations,
+		"namespace":   i.Namespace,
+		"scheme":      i.Scheme,
+		"host":        i.Host,
+		"path":        i.Path,
+	}
+}
+
+func (i *K8sIngress) Type() EndpointType {
+	return K8sIngressType
+}
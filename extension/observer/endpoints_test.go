@@ -126,6 +126,9 @@ func TestEndpointEnv(t *testing.T) {
 				"is_ipv6":      true,
 				"port":         uint16(2379),
 				"transport":    ProtocolUDP,
+				"service_name": "",
+				"tags":         []string(nil),
+				"metadata":     map[string]string(nil),
 			},
 			wantErr: false,
 		},
@@ -211,6 +214,88 @@ func TestEndpointEnv(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "Kubernetes Service",
+			endpoint: Endpoint{
+				ID:     EndpointID("k8s_service_endpoint_id"),
+				Target: "a-service.default.svc",
+				Details: &K8sService{
+					Name:        "a-service",
+					UID:         "a-service-uid",
+					Namespace:   "default",
+					ServiceType: "ClusterIP",
+					ClusterIP:   "10.0.0.1",
+					Annotations: map[string]string{
+						"annotation_key": "annotation_val",
+					},
+					Labels: map[string]string{
+						"label_key": "label_val",
+					},
+					Ports: []K8sServicePort{
+						{Name: "http", Port: 80, Transport: ProtocolTCP},
+					},
+				},
+			},
+			want: EndpointEnv{
+				"type":         "k8s.service",
+				"id":           "k8s_service_endpoint_id",
+				"endpoint":     "a-service.default.svc",
+				"name":         "a-service",
+				"uid":          "a-service-uid",
+				"namespace":    "default",
+				"service_type": "ClusterIP",
+				"cluster_ip":   "10.0.0.1",
+				"annotations": map[string]string{
+					"annotation_key": "annotation_val",
+				},
+				"labels": map[string]string{
+					"label_key": "label_val",
+				},
+				"ports": []map[string]interface{}{
+					{"name": "http", "port": uint16(80), "transport": ProtocolTCP},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Kubernetes Ingress",
+			endpoint: Endpoint{
+				ID:     EndpointID("k8s_ingress_endpoint_id"),
+				Target: "https://example.com/api",
+				Details: &K8sIngress{
+					Name:      "an-ingress",
+					UID:       "an-ingress-uid",
+					Namespace: "default",
+					Scheme:    "https",
+					Host:      "example.com",
+					Path:      "/api",
+					Annotations: map[string]string{
+						"annotation_key": "annotation_val",
+					},
+					Labels: map[string]string{
+						"label_key": "label_val",
+					},
+				},
+			},
+			want: EndpointEnv{
+				"type":      "k8s.ingress",
+				"id":        "k8s_ingress_endpoint_id",
+				"endpoint":  "https://example.com/api",
+				"name":      "an-ingress",
+				"uid":       "an-ingress-uid",
+				"namespace": "default",
+				"scheme":    "https",
+				"host":      "example.com",
+				"path":      "/api",
+				"annotations": map[string]string{
+					"annotation_key": "annotation_val",
+				},
+				"labels": map[string]string{
+					"label_key": "label_val",
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -58,13 +58,22 @@ func TestLoadConfig(t *testing.T) {
 				ObserveNodes:      true,
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "services-and-ingresses"),
+			expected: &Config{
+				ExtensionSettings: config.NewExtensionSettings(component.NewID(typeStr)),
+				APIConfig:         k8sconfig.APIConfig{AuthType: k8sconfig.AuthTypeServiceAccount},
+				ObserveServices:   true,
+				ObserveIngresses:  true,
+			},
+		},
 		{
 			id:          component.NewIDWithName(typeStr, "invalid_auth"),
 			expectedErr: "invalid authType for kubernetes: not a real auth type",
 		},
 		{
 			id:          component.NewIDWithName(typeStr, "invalid_no_observing"),
-			expectedErr: "one of observe_pods and observe_nodes must be true",
+			expectedErr: "one of observe_pods, observe_nodes, observe_services and observe_ingresses must be true",
 		},
 	}
 	for _, tt := range tests {
@@ -223,3 +223,51 @@ func TestExtensionObserveNodes(t *testing.T) {
 
 	require.NoError(t, ext.Shutdown(context.Background()))
 }
+
+func TestExtensionObserveServicesAndIngresses(t *testing.T) {
+	factory := NewFactory()
+	config := factory.CreateDefaultConfig().(*Config)
+	config.ObservePods = false
+	config.ObserveServices = true
+	config.ObserveIngresses = true
+	mockServiceHost(t, config)
+
+	ext, err := newObserver(config, componenttest.NewNopTelemetrySettings())
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+
+	obs := ext.(*k8sObserver)
+	require.NotNil(t, obs.serviceListerWatcher)
+	require.NotNil(t, obs.ingressListerWatcher)
+	serviceListerWatcher := framework.NewFakeControllerSource()
+	obs.serviceListerWatcher = serviceListerWatcher
+	ingressListerWatcher := framework.NewFakeControllerSource()
+	obs.ingressListerWatcher = ingressListerWatcher
+
+	serviceListerWatcher.Add(service1V1)
+	ingressListerWatcher.Add(ingress1V1)
+
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	sink := &endpointSink{}
+	obs.ListAndWatch(sink)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.added) == 4
+	})
+
+	types := map[observer.EndpointType]int{}
+	for _, endpoint := range sink.added {
+		types[endpoint.Details.Type()]++
+	}
+	assert.Equal(t, map[observer.EndpointType]int{observer.K8sServiceType: 1, observer.K8sIngressType: 3}, types)
+
+	serviceListerWatcher.Delete(service1V1)
+	ingressListerWatcher.Modify(ingress1V2)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.removed) == 2
+	})
+
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
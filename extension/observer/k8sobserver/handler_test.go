@@ -203,3 +203,82 @@ func TestNodeEndpointsChanged(t *testing.T) {
 		},
 	}, th.ListEndpoints())
 }
+
+func TestServiceEndpointsAdded(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(service1V1)
+	assert.ElementsMatch(t, []observer.Endpoint{
+		{
+			ID:     "test-1/service1-UID",
+			Target: "service1.default.svc",
+			Details: &observer.K8sService{
+				UID:         "service1-UID",
+				Labels:      map[string]string{"env": "prod"},
+				Name:        "service1",
+				Namespace:   "default",
+				ServiceType: "ClusterIP",
+				ClusterIP:   "10.0.0.1",
+				Ports: []observer.K8sServicePort{
+					{Name: "http", Port: 80, Transport: observer.ProtocolTCP},
+					{Name: "dns", Port: 53, Transport: observer.ProtocolUDP},
+				},
+			},
+		},
+	}, th.ListEndpoints())
+}
+
+func TestServiceEndpointsRemoved(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(service1V1)
+	th.OnDelete(service1V1)
+	assert.Empty(t, th.ListEndpoints())
+}
+
+func TestServiceEndpointsChanged(t *testing.T) {
+	th := newTestHandler()
+	// Nothing changed.
+	th.OnUpdate(service1V1, service1V1)
+	require.Empty(t, th.ListEndpoints())
+
+	th.OnUpdate(service1V1, service1V2)
+	endpoints := th.ListEndpoints()
+	require.Len(t, endpoints, 1)
+	assert.Equal(t, map[string]string{"env": "prod", "service-version": "2"}, endpoints[0].Details.(*observer.K8sService).Labels)
+}
+
+func TestIngressEndpointsAdded(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(ingress1V1)
+	var targets []string
+	for _, endpoint := range th.ListEndpoints() {
+		targets = append(targets, endpoint.Target)
+	}
+	assert.ElementsMatch(t, []string{
+		"https://secure.example.com/api",
+		"https://secure.example.com/ui",
+		"http://example.com/",
+	}, targets)
+}
+
+func TestIngressEndpointsRemoved(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(ingress1V1)
+	th.OnDelete(ingress1V1)
+	assert.Empty(t, th.ListEndpoints())
+}
+
+func TestIngressEndpointsChanged(t *testing.T) {
+	th := newTestHandler()
+	th.OnAdd(ingress1V1)
+
+	// A path removed.
+	th.OnUpdate(ingress1V1, ingress1V2)
+	var targets []string
+	for _, endpoint := range th.ListEndpoints() {
+		targets = append(targets, endpoint.Target)
+	}
+	assert.ElementsMatch(t, []string{
+		"https://secure.example.com/api",
+		"http://example.com/",
+	}, targets)
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/k8sobserver"
+
+import (
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+// convertIngressToEndpoints converts an ingress instance into a k8s.ingress observer.Endpoint for each host
+// and path it routes. Its Target is the URL of the path, e.g. https://example.com/api. Rules without host
+// use the address of the load balancer of the ingress, and are skipped until it's known. Rules with a
+// wildcard host are skipped, as they can't be contacted.
+func convertIngressToEndpoints(idNamespace string, ingress *networkingv1.Ingress) []observer.Endpoint {
+	rules := ingress.Spec.Rules
+	if len(rules) == 0 && ingress.Spec.DefaultBackend != nil {
+		rules = []networkingv1.IngressRule{{}}
+	}
+
+	var endpoints []observer.Endpoint
+	seen := map[observer.EndpointID]bool{}
+	for _, rule := range rules {
+		host := rule.Host
+		if host == "" {
+			host = loadBalancerAddress(ingress)
+		}
+		if host == "" || strings.Contains(host, "*") {
+			continue
+		}
+
+		paths := []string{"/"}
+		if rule.HTTP != nil && len(rule.HTTP.Paths) > 0 {
+			paths = paths[:0]
+			for _, path := range rule.HTTP.Paths {
+				if path.Path == "" {
+					paths = append(paths, "/")
+				} else {
+					paths = append(paths, path.Path)
+				}
+			}
+		}
+
+		scheme := "http"
+		if ingressTLSHost(ingress, rule.Host) {
+			scheme = "https"
+		}
+
+		for _, path := range paths {
+			endpointID := observer.EndpointID(fmt.Sprintf("%s/%s/%s%s", idNamespace, ingress.UID, host, path))
+			if seen[endpointID] {
+				continue
+			}
+			seen[endpointID] = true
+			endpoints = append(endpoints, observer.Endpoint{
+				ID:     endpointID,
+				Target: fmt.Sprintf("%s://%s%s", scheme, host, path),
+				Details: &observer.K8sIngress{
+					UID:         string(ingress.UID),
+					Annotations: ingress.Annotations,
+					Labels:      ingress.Labels,
+					Name:        ingress.Name,
+					Namespace:   ingress.Namespace,
+					Scheme:      scheme,
+					Host:        host,
+					Path:        path,
+				},
+			})
+		}
+	}
+	return endpoints
+}
+
+// loadBalancerAddress returns the first IP or hostname of the load balancer of the ingress.
+func loadBalancerAddress(ingress *networkingv1.Ingress) string {
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		if lb.IP != "" {
+			return lb.IP
+		}
+		if lb.Hostname != "" {
+			return lb.Hostname
+		}
+	}
+	return ""
+}
+
+// ingressTLSHost returns whether TLS is configured for the host. A TLS entry without hosts applies to all the
+// hosts of the ingress.
+func ingressTLSHost(ingress *networkingv1.Ingress, host string) bool {
+	for _, tls := range ingress.Spec.TLS {
+		if len(tls.Hosts) == 0 {
+			return true
+		}
+		for _, tlsHost := range tls.Hosts {
+			if tlsHost == host {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func ingressEndpoint(id observer.EndpointID, target, scheme, host, path string) observer.Endpoint {
+	return observer.Endpoint{
+		ID:     id,
+		Target: target,
+		Details: &observer.K8sIngress{
+			UID:       "name-UID",
+			Labels:    map[string]string{"env": "prod"},
+			Name:      "name",
+			Namespace: "default",
+			Scheme:    scheme,
+			Host:      host,
+			Path:      path,
+		},
+	}
+}
+
+func TestIngressObjectToK8sIngressEndpoints(t *testing.T) {
+	endpoints := convertIngressToEndpoints("namespace", NewIngress("name"))
+	assert.Equal(t, []observer.Endpoint{
+		ingressEndpoint("namespace/name-UID/secure.example.com/api", "https://secure.example.com/api", "https", "secure.example.com", "/api"),
+		ingressEndpoint("namespace/name-UID/secure.example.com/ui", "https://secure.example.com/ui", "https", "secure.example.com", "/ui"),
+		ingressEndpoint("namespace/name-UID/example.com/", "http://example.com/", "http", "example.com", "/"),
+	}, endpoints)
+}
+
+func TestIngressWithoutHostToK8sIngressEndpoints(t *testing.T) {
+	ingress := NewIngress("name")
+	ingress.Spec.TLS = nil
+	ingress.Spec.Rules = nil
+	ingress.Spec.DefaultBackend = &networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{Name: "backend"},
+	}
+
+	// The address of the load balancer isn't known yet.
+	assert.Empty(t, convertIngressToEndpoints("namespace", ingress))
+
+	ingress.Status.LoadBalancer.Ingress = []v1.LoadBalancerIngress{{IP: "1.2.3.4"}}
+	assert.Equal(t, []observer.Endpoint{
+		ingressEndpoint("namespace/name-UID/1.2.3.4/", "http://1.2.3.4/", "http", "1.2.3.4", "/"),
+	}, convertIngressToEndpoints("namespace", ingress))
+}
@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package k8sobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/k8sobserver"
+
+import (
+	"fmt"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+// convertServiceToEndpoint converts a service instance into a k8s.service observer.Endpoint. Its Target is
+// the DNS name of the service in the cluster, <name>.<namespace>.svc.
+func convertServiceToEndpoint(idNamespace string, service *v1.Service) observer.Endpoint {
+	serviceID := observer.EndpointID(fmt.Sprintf("%s/%s", idNamespace, service.UID))
+
+	var ports []observer.K8sServicePort
+	for _, port := range service.Spec.Ports {
+		ports = append(ports, observer.K8sServicePort{
+			Name:      port.Name,
+			Port:      uint16(port.Port),
+			Transport: getTransport(port.Protocol),
+		})
+	}
+
+	serviceDetails := observer.K8sService{
+		UID:         string(service.UID),
+		Annotations: service.Annotations,
+		Labels:      service.Labels,
+		Name:        service.Name,
+		Namespace:   service.Namespace,
+		ServiceType: string(service.Spec.Type),
+		ClusterIP:   service.Spec.ClusterIP,
+		Ports:       ports,
+	}
+
+	return observer.Endpoint{
+		ID:      serviceID,
+		Target:  fmt.Sprintf("%s.%s.svc", service.Name, service.Namespace),
+		Details: &serviceDetails,
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/kafkaobserver"
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+)
+
+// Config defines configuration for the Kafka observer.
+type Config struct {
+	config.ExtensionSettings `mapstructure:",squash"`
+
+	// The list of kafka brokers used to discover the cluster (default localhost:9092)
+	Brokers []string `mapstructure:"brokers"`
+
+	// ProtocolVersion Kafka protocol version
+	ProtocolVersion string `mapstructure:"protocol_version"`
+
+	// ClientID is the id associated with the client that fetches the metadata of the cluster.
+	ClientID string `mapstructure:"client_id"`
+
+	// Authentication data
+	Authentication kafkaexporter.Authentication `mapstructure:"auth"`
+
+	// RefreshInterval determines how often the metadata of the cluster is
+	// fetched to discover the brokers.
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+}
+
+// Validate checks if the extension configuration is valid
+func (cfg *Config) Validate() error {
+	if len(cfg.Brokers) == 0 {
+		return errors.New("brokers must not be empty")
+	}
+	if cfg.RefreshInterval <= 0 {
+		return errors.New("refresh_interval must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/kafkaobserver"
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+var _ component.Extension = (*kafkaObserver)(nil)
+var _ observer.Observable = (*kafkaObserver)(nil)
+
+type kafkaObserver struct {
+	*observer.EndpointsWatcher
+	lister *endpointsLister
+}
+
+// endpointsLister fetches the metadata of the Kafka cluster, and lists its
+// brokers as endpoints.
+type endpointsLister struct {
+	config       *Config
+	logger       *zap.Logger
+	saramaConfig *sarama.Config
+	observerName string
+
+	mu     sync.Mutex
+	client sarama.Client
+	// endpoints holds the last known brokers, reported when the metadata
+	// can't be fetched.
+	endpoints []observer.Endpoint
+}
+
+func newObserver(logger *zap.Logger, config *Config) (component.Extension, error) {
+	sc := sarama.NewConfig()
+	sc.ClientID = config.ClientID
+	// The metadata is refreshed by the observer at every refresh interval.
+	sc.Metadata.RefreshFrequency = 0
+	if config.ProtocolVersion != "" {
+		version, err := sarama.ParseKafkaVersion(config.ProtocolVersion)
+		if err != nil {
+			return nil, err
+		}
+		sc.Version = version
+	}
+	if err := kafkaexporter.ConfigureAuthentication(config.Authentication, sc); err != nil {
+		return nil, err
+	}
+
+	lister := &endpointsLister{
+		config:       config,
+		logger:       logger,
+		saramaConfig: sc,
+		observerName: config.ID().String(),
+	}
+	return &kafkaObserver{
+		EndpointsWatcher: observer.NewEndpointsWatcher(lister, config.RefreshInterval, logger),
+		lister:           lister,
+	}, nil
+}
+
+func (k *kafkaObserver) Start(context.Context, component.Host) error {
+	return nil
+}
+
+func (k *kafkaObserver) Shutdown(context.Context) error {
+	k.StopListAndWatch()
+	return k.lister.close()
+}
+
+func (e *endpointsLister) ListEndpoints() []observer.Endpoint {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	brokers, err := e.fetchBrokers()
+	if err != nil {
+		e.logger.Warn("Failed to fetch the brokers of the Kafka cluster", zap.Error(err))
+		return e.endpoints
+	}
+
+	endpoints := make([]observer.Endpoint, 0, len(brokers))
+	for _, broker := range brokers {
+		endpoint, err := e.convertBroker(broker)
+		if err != nil {
+			e.logger.Warn("Failed to convert a Kafka broker", zap.String("address", broker.Addr()), zap.Error(err))
+			continue
+		}
+		endpoints = append(endpoints, endpoint)
+	}
+	e.endpoints = endpoints
+	return endpoints
+}
+
+// fetchBrokers connects to the cluster on the first call, and refreshes the
+// metadata of the cluster on the next ones.
+func (e *endpointsLister) fetchBrokers() ([]*sarama.Broker, error) {
+	if e.client == nil {
+		client, err := sarama.NewClient(e.config.Brokers, e.saramaConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client: %w", err)
+		}
+		e.client = client
+	} else if err := e.client.RefreshMetadata(); err != nil {
+		return nil, fmt.Errorf("failed to refresh metadata: %w", err)
+	}
+	return e.client.Brokers(), nil
+}
+
+// convertBroker converts a broker of the cluster into a hostport endpoint.
+func (e *endpointsLister) convertBroker(broker *sarama.Broker) (observer.Endpoint, error) {
+	host, portStr, err := net.SplitHostPort(broker.Addr())
+	if err != nil {
+		return observer.Endpoint{}, err
+	}
+	port, err := strconv.ParseUint(portStr, 10, 16)
+	if err != nil {
+		return observer.Endpoint{}, fmt.Errorf("invalid port %q: %w", portStr, err)
+	}
+	ip := net.ParseIP(host)
+
+	metadata := map[string]string{"broker.id": strconv.Itoa(int(broker.ID()))}
+	if rack := broker.Rack(); rack != "" {
+		metadata["rack"] = rack
+	}
+
+	return observer.Endpoint{
+		ID:     observer.EndpointID(fmt.Sprintf("%s/broker-%d", e.observerName, broker.ID())),
+		Target: broker.Addr(),
+		Details: &observer.HostPort{
+			Port:        uint16(port),
+			Transport:   observer.ProtocolTCP,
+			IsIPv6:      ip != nil && ip.To4() == nil,
+			ServiceName: "kafka",
+			Metadata:    metadata,
+		},
+	}, nil
+}
+
+func (e *endpointsLister) close() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.client != nil && !e.client.Closed() {
+		return e.client.Close()
+	}
+	return nil
+}
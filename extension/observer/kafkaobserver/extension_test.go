@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaobserver
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func brokerEndpoint(broker *sarama.MockBroker) observer.Endpoint {
+	id := strconv.Itoa(int(broker.BrokerID()))
+	_, port, _ := net.SplitHostPort(broker.Addr())
+	portNumber, _ := strconv.Atoi(port)
+	return observer.Endpoint{
+		ID:     observer.EndpointID("kafka_observer/broker-" + id),
+		Target: broker.Addr(),
+		Details: &observer.HostPort{
+			Port:        uint16(portNumber),
+			Transport:   observer.ProtocolTCP,
+			ServiceName: "kafka",
+			Metadata:    map[string]string{"broker.id": id},
+		},
+	}
+}
+
+func TestListEndpoints(t *testing.T) {
+	seed := sarama.NewMockBroker(t, 1)
+	other := sarama.NewMockBroker(t, 2)
+	defer other.Close()
+
+	setMetadata := func(brokers ...*sarama.MockBroker) {
+		metadata := sarama.NewMockMetadataResponse(t)
+		for _, broker := range brokers {
+			metadata.SetBroker(broker.Addr(), broker.BrokerID())
+		}
+		seed.SetHandlerByMap(map[string]sarama.MockResponse{"MetadataRequest": metadata})
+	}
+	setMetadata(seed, other)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Brokers = []string{seed.Addr()}
+
+	ext, err := newObserver(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	obs := ext.(*kafkaObserver)
+	obs.lister.saramaConfig.Metadata.Retry.Max = 0
+	obs.lister.saramaConfig.Metadata.Retry.Backoff = time.Millisecond
+	defer func() {
+		require.NoError(t, obs.Shutdown(context.Background()))
+	}()
+
+	assert.ElementsMatch(t, []observer.Endpoint{brokerEndpoint(seed), brokerEndpoint(other)}, obs.lister.ListEndpoints())
+
+	setMetadata(seed)
+	assert.ElementsMatch(t, []observer.Endpoint{brokerEndpoint(seed)}, obs.lister.ListEndpoints())
+
+	// The last known brokers are reported when the cluster can't be reached.
+	seed.Close()
+	assert.ElementsMatch(t, []observer.Endpoint{brokerEndpoint(seed)}, obs.lister.ListEndpoints())
+}
+
+func TestListEndpointsUnreachableCluster(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Brokers = []string{"127.0.0.1:0"}
+
+	ext, err := newObserver(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	obs := ext.(*kafkaObserver)
+	obs.lister.saramaConfig.Metadata.Retry.Max = 0
+
+	assert.Empty(t, obs.lister.ListEndpoints())
+	assert.NoError(t, obs.Shutdown(context.Background()))
+}
+
+func TestConvertBroker(t *testing.T) {
+	lister := &endpointsLister{observerName: "kafka_observer/1"}
+
+	endpoint, err := lister.convertBroker(sarama.NewBroker("[fd00::1]:9093"))
+	require.NoError(t, err)
+	assert.Equal(t, observer.Endpoint{
+		ID:     "kafka_observer/1/broker--1",
+		Target: "[fd00::1]:9093",
+		Details: &observer.HostPort{
+			Port:        9093,
+			Transport:   observer.ProtocolTCP,
+			IsIPv6:      true,
+			ServiceName: "kafka",
+			Metadata:    map[string]string{"broker.id": "-1"},
+		},
+	}, endpoint)
+
+	_, err = lister.convertBroker(sarama.NewBroker("kafka"))
+	assert.Error(t, err)
+}
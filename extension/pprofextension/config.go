@@ -15,11 +15,32 @@
 package pprofextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/pprofextension"
 
 import (
+	"errors"
+	"fmt"
+	"time"
+
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/confignet"
 )
 
+const (
+	cpuProfile       = "cpu"
+	heapProfile      = "heap"
+	goroutineProfile = "goroutine"
+	mutexProfile     = "mutex"
+	blockProfile     = "block"
+)
+
+var (
+	errInvalidInterval    = errors.New("\"interval\" must be positive")
+	errInvalidCPUDuration = errors.New("\"cpu_duration\" must be positive and shorter than \"interval\"")
+	errInvalidMaxSize     = errors.New("\"max_size_mib\" must be positive")
+	errCPUProfileConflict = errors.New("the \"cpu\" profile can't be captured continuously when \"save_to_file\" is set")
+	errMissingEndpoint    = errors.New("\"endpoint\" is required for the \"pyroscope\" upload")
+)
+
 // Config has the configuration for the extension enabling the golang
 // net/http/pprof (Performance Profiler) extension.
 type Config struct {
@@ -42,11 +63,92 @@ type Config struct {
 	// Optional file name to save the CPU profile to. The profiling starts when the
 	// Collector starts and is saved to the file when the Collector is terminated.
 	SaveToFile string `mapstructure:"save_to_file"`
+
+	// ContinuousProfiling periodically captures profiles of the Collector, and
+	// writes them to a local directory or pushes them to a Pyroscope-compatible
+	// endpoint.
+	ContinuousProfiling ContinuousProfilingSettings `mapstructure:"continuous_profiling"`
+}
+
+// ContinuousProfilingSettings defines the periodic capture of the profiles.
+// The capture is enabled when Directory or Pyroscope is set.
+type ContinuousProfilingSettings struct {
+	// Interval between two captures of the profiles.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// CPUDuration is the duration of the CPU profile captured at each interval.
+	CPUDuration time.Duration `mapstructure:"cpu_duration"`
+
+	// Profiles to capture, among cpu, heap, goroutine, mutex and block. Defaults
+	// to cpu, heap and goroutine.
+	Profiles []string `mapstructure:"profiles"`
+
+	// Directory to write the profiles to. The oldest profiles are removed
+	// when the profiles in the directory exceed MaxSizeMiB.
+	Directory string `mapstructure:"directory"`
+
+	// MaxSizeMiB is the maximum size of the profiles in Directory, in MiB.
+	MaxSizeMiB int64 `mapstructure:"max_size_mib"`
+
+	// Pyroscope configures the push of the profiles to a Pyroscope-compatible
+	// ingestion endpoint.
+	Pyroscope *PyroscopeSettings `mapstructure:"pyroscope"`
+
+	// Labels added to the labels identifying the Collector in the pushed profiles.
+	Labels map[string]string `mapstructure:"labels"`
+}
+
+// PyroscopeSettings defines the push of the profiles to a Pyroscope-compatible
+// endpoint.
+type PyroscopeSettings struct {
+	confighttp.HTTPClientSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// ApplicationName of the profiles. Defaults to the name of the Collector
+	// executable.
+	ApplicationName string `mapstructure:"application_name"`
+}
+
+func (cps *ContinuousProfilingSettings) enabled() bool {
+	return cps.Directory != "" || cps.Pyroscope != nil
+}
+
+func (cps *ContinuousProfilingSettings) profiles() []string {
+	if len(cps.Profiles) == 0 {
+		return []string{cpuProfile, heapProfile, goroutineProfile}
+	}
+	return cps.Profiles
 }
 
 var _ component.ExtensionConfig = (*Config)(nil)
 
 // Validate checks if the extension configuration is valid
 func (cfg *Config) Validate() error {
+	cps := cfg.ContinuousProfiling
+	if !cps.enabled() {
+		return nil
+	}
+	if cps.Interval <= 0 {
+		return errInvalidInterval
+	}
+	if cps.Directory != "" && cps.MaxSizeMiB <= 0 {
+		return errInvalidMaxSize
+	}
+	if cps.Pyroscope != nil && cps.Pyroscope.Endpoint == "" {
+		return errMissingEndpoint
+	}
+	for _, profile := range cps.profiles() {
+		switch profile {
+		case cpuProfile:
+			if cps.CPUDuration <= 0 || cps.CPUDuration >= cps.Interval {
+				return errInvalidCPUDuration
+			}
+			if cfg.SaveToFile != "" {
+				return errCPUProfileConflict
+			}
+		case heapProfile, goroutineProfile, mutexProfile, blockProfile:
+		default:
+			return fmt.Errorf("unknown profile %q", profile)
+		}
+	}
 	return nil
 }
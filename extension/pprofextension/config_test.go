@@ -17,11 +17,13 @@ package pprofextension
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/confmap/confmaptest"
 )
@@ -44,6 +46,34 @@ func TestLoadConfig(t *testing.T) {
 				TCPAddr:              confignet.TCPAddr{Endpoint: "127.0.0.1:1777"},
 				BlockProfileFraction: 3,
 				MutexProfileFraction: 5,
+				ContinuousProfiling: ContinuousProfilingSettings{
+					Interval:    defaultInterval,
+					CPUDuration: defaultCPUDuration,
+					MaxSizeMiB:  defaultMaxSizeMiB,
+				},
+			},
+		},
+		{
+			id: component.NewIDWithName(typeStr, "continuous"),
+			expected: &Config{
+				ExtensionSettings:    config.NewExtensionSettings(component.NewID(typeStr)),
+				TCPAddr:              confignet.TCPAddr{Endpoint: defaultEndpoint},
+				MutexProfileFraction: 10,
+				ContinuousProfiling: ContinuousProfilingSettings{
+					Interval:    5 * time.Minute,
+					CPUDuration: 30 * time.Second,
+					Profiles:    []string{"cpu", "heap", "goroutine", "mutex"},
+					Directory:   "/var/lib/otelcol/profiles",
+					MaxSizeMiB:  50,
+					Pyroscope: &PyroscopeSettings{
+						HTTPClientSettings: confighttp.HTTPClientSettings{
+							Endpoint: "http://pyroscope:4040",
+							Timeout:  10 * time.Second,
+						},
+						ApplicationName: "gateway",
+					},
+					Labels: map[string]string{"deployment.environment": "production"},
+				},
 			},
 		},
 	}
@@ -62,3 +92,87 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		modify      func(cfg *Config)
+		expectedErr string
+	}{
+		{
+			name:   "disabled",
+			modify: func(cfg *Config) { cfg.ContinuousProfiling.Interval = 0 },
+		},
+		{
+			name:   "directory",
+			modify: func(cfg *Config) { cfg.ContinuousProfiling.Directory = "profiles" },
+		},
+		{
+			name: "invalid interval",
+			modify: func(cfg *Config) {
+				cfg.ContinuousProfiling.Directory = "profiles"
+				cfg.ContinuousProfiling.Interval = 0
+			},
+			expectedErr: errInvalidInterval.Error(),
+		},
+		{
+			name: "cpu duration longer than interval",
+			modify: func(cfg *Config) {
+				cfg.ContinuousProfiling.Directory = "profiles"
+				cfg.ContinuousProfiling.CPUDuration = 2 * time.Minute
+			},
+			expectedErr: errInvalidCPUDuration.Error(),
+		},
+		{
+			name: "cpu duration ignored without cpu profile",
+			modify: func(cfg *Config) {
+				cfg.ContinuousProfiling.Directory = "profiles"
+				cfg.ContinuousProfiling.CPUDuration = 0
+				cfg.ContinuousProfiling.Profiles = []string{"heap"}
+			},
+		},
+		{
+			name: "invalid max size",
+			modify: func(cfg *Config) {
+				cfg.ContinuousProfiling.Directory = "profiles"
+				cfg.ContinuousProfiling.MaxSizeMiB = 0
+			},
+			expectedErr: errInvalidMaxSize.Error(),
+		},
+		{
+			name: "missing pyroscope endpoint",
+			modify: func(cfg *Config) {
+				cfg.ContinuousProfiling.Pyroscope = &PyroscopeSettings{}
+			},
+			expectedErr: errMissingEndpoint.Error(),
+		},
+		{
+			name: "cpu profile with save to file",
+			modify: func(cfg *Config) {
+				cfg.ContinuousProfiling.Directory = "profiles"
+				cfg.SaveToFile = "cpu.pprof"
+			},
+			expectedErr: errCPUProfileConflict.Error(),
+		},
+		{
+			name: "unknown profile",
+			modify: func(cfg *Config) {
+				cfg.ContinuousProfiling.Directory = "profiles"
+				cfg.ContinuousProfiling.Profiles = []string{"heap", "threadcreate"}
+			},
+			expectedErr: `unknown profile "threadcreate"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,348 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pprofextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/pprofextension"
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+)
+
+const (
+	profileFileSuffix    = ".pb.gz"
+	profileTimeFormat    = "20060102T150405.000Z"
+	defaultUploadTimeout = 30 * time.Second
+	defaultAppName       = "otelcol"
+	// cpuSampleRate is the sampling frequency of the CPU profiler of the runtime, in Hz.
+	cpuSampleRate = 100
+)
+
+var errStopped = errors.New("continuous profiling stopped")
+
+// continuousProfiler periodically captures the profiles of the process, and
+// writes them to a directory or pushes them to a Pyroscope-compatible endpoint.
+type continuousProfiler struct {
+	settings  ContinuousProfilingSettings
+	logger    *zap.Logger
+	telemetry component.TelemetrySettings
+	buildInfo component.BuildInfo
+
+	client    *http.Client
+	ingestURL string
+	name      string
+	// previous holds the last captured cumulative profiles, sent along with the
+	// new ones so that the backend computes the deltas.
+	previous map[string][]byte
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+func newContinuousProfiler(settings ContinuousProfilingSettings, set component.ExtensionCreateSettings) *continuousProfiler {
+	return &continuousProfiler{
+		settings:  settings,
+		logger:    set.Logger,
+		telemetry: set.TelemetrySettings,
+		buildInfo: set.BuildInfo,
+		previous:  map[string][]byte{},
+	}
+}
+
+func (cp *continuousProfiler) start(host component.Host) error {
+	if cp.settings.Directory != "" {
+		if err := os.MkdirAll(cp.settings.Directory, 0700); err != nil {
+			return fmt.Errorf("failed to create the profiles directory: %w", err)
+		}
+	}
+
+	if cp.settings.Pyroscope != nil {
+		client, err := cp.settings.Pyroscope.ToClient(host, cp.telemetry)
+		if err != nil {
+			return err
+		}
+		if client.Timeout == 0 {
+			client.Timeout = defaultUploadTimeout
+		}
+		cp.client = client
+		cp.ingestURL = strings.TrimSuffix(cp.settings.Pyroscope.Endpoint, "/") + "/ingest"
+		cp.name = cp.applicationName()
+	}
+
+	var ctx context.Context
+	ctx, cp.cancel = context.WithCancel(context.Background())
+	cp.wg.Add(1)
+	go cp.run(ctx)
+	return nil
+}
+
+func (cp *continuousProfiler) shutdown() {
+	if cp.cancel != nil {
+		cp.cancel()
+	}
+	cp.wg.Wait()
+}
+
+func (cp *continuousProfiler) run(ctx context.Context) {
+	defer cp.wg.Done()
+
+	ticker := time.NewTicker(cp.settings.Interval)
+	defer ticker.Stop()
+	for {
+		if err := cp.capture(ctx); errors.Is(err, errStopped) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// capture captures each configured profile and exports it. It only returns
+// errStopped, as the other failures are logged so that the next captures are
+// still attempted.
+func (cp *continuousProfiler) capture(ctx context.Context) error {
+	for _, profile := range cp.settings.profiles() {
+		from := time.Now()
+		data, err := cp.profile(ctx, profile)
+		if errors.Is(err, errStopped) {
+			return err
+		}
+		if err != nil {
+			cp.logger.Warn("Failed to capture profile", zap.String("profile", profile), zap.Error(err))
+			continue
+		}
+		cp.export(ctx, profile, from, time.Now(), data)
+	}
+	return nil
+}
+
+func (cp *continuousProfiler) profile(ctx context.Context, profile string) ([]byte, error) {
+	var buf bytes.Buffer
+	if profile != cpuProfile {
+		if err := pprof.Lookup(profile).WriteTo(&buf, 0); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	// Fails when another CPU profile is running, e.g. requested on the
+	// /debug/pprof/profile endpoint.
+	if err := pprof.StartCPUProfile(&buf); err != nil {
+		return nil, err
+	}
+	timer := time.NewTimer(cp.settings.CPUDuration)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		pprof.StopCPUProfile()
+		return nil, errStopped
+	case <-timer.C:
+	}
+	pprof.StopCPUProfile()
+	return buf.Bytes(), nil
+}
+
+func (cp *continuousProfiler) export(ctx context.Context, profile string, from, until time.Time, data []byte) {
+	if cp.settings.Directory != "" {
+		if err := cp.write(profile, from, data); err != nil {
+			cp.logger.Warn("Failed to write profile", zap.String("profile", profile), zap.Error(err))
+		}
+		if err := cp.rotate(); err != nil {
+			cp.logger.Warn("Failed to remove the oldest profiles", zap.Error(err))
+		}
+	}
+
+	if cp.client != nil {
+		if err := cp.upload(ctx, profile, from, until, data); err != nil {
+			cp.logger.Warn("Failed to push profile", zap.String("profile", profile), zap.Error(err))
+		}
+	}
+}
+
+// write writes the profile to a temporary file renamed once complete, so that
+// the directory doesn't hold truncated profiles when the process is killed.
+func (cp *continuousProfiler) write(profile string, from time.Time, data []byte) error {
+	f, err := os.CreateTemp(cp.settings.Directory, "."+profile+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err = f.Write(data); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s%s", profile, from.UTC().Format(profileTimeFormat), profileFileSuffix)
+	return os.Rename(f.Name(), filepath.Join(cp.settings.Directory, name))
+}
+
+// rotate removes the oldest profiles of the directory until their total size
+// doesn't exceed the limit.
+func (cp *continuousProfiler) rotate() error {
+	entries, err := os.ReadDir(cp.settings.Directory)
+	if err != nil {
+		return err
+	}
+
+	var (
+		files []os.FileInfo
+		total int64
+	)
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || !strings.HasSuffix(entry.Name(), profileFileSuffix) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, info)
+		total += info.Size()
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		if files[i].ModTime().Equal(files[j].ModTime()) {
+			return files[i].Name() < files[j].Name()
+		}
+		return files[i].ModTime().Before(files[j].ModTime())
+	})
+
+	maxSize := cp.settings.MaxSizeMiB * 1024 * 1024
+	for _, file := range files {
+		if total <= maxSize {
+			break
+		}
+		if err := os.Remove(filepath.Join(cp.settings.Directory, file.Name())); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		total -= file.Size()
+	}
+	return nil
+}
+
+// upload pushes the profile to the Pyroscope ingestion API. The previous
+// capture of a cumulative profile is sent as well, for the backend to compute
+// the values of the interval.
+func (cp *continuousProfiler) upload(ctx context.Context, profile string, from, until time.Time, data []byte) error {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	if err := writeFormFile(writer, "profile", data); err != nil {
+		return err
+	}
+	if profile != cpuProfile && profile != goroutineProfile {
+		if previous, ok := cp.previous[profile]; ok {
+			if err := writeFormFile(writer, "prev_profile", previous); err != nil {
+				return err
+			}
+		}
+		cp.previous[profile] = data
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	query := url.Values{}
+	query.Set("name", cp.name)
+	query.Set("from", strconv.FormatInt(from.Unix(), 10))
+	query.Set("until", strconv.FormatInt(until.Unix(), 10))
+	query.Set("format", "pprof")
+	query.Set("spyName", "gospy")
+	if profile == cpuProfile {
+		query.Set("sampleRate", strconv.Itoa(cpuSampleRate))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, cp.ingestURL+"?"+query.Encode(), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := cp.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("endpoint responded with HTTP status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func writeFormFile(writer *multipart.Writer, field string, data []byte) error {
+	part, err := writer.CreateFormFile(field, field+".pprof")
+	if err != nil {
+		return err
+	}
+	_, err = part.Write(data)
+	return err
+}
+
+// applicationName returns the name of the application with the labels
+// identifying the Collector, e.g. otelcol{host.name=node-1,service.name=otelcol}.
+func (cp *continuousProfiler) applicationName() string {
+	labels := map[string]string{
+		"service.name":    cp.buildInfo.Command,
+		"service.version": cp.buildInfo.Version,
+	}
+	if hostname, err := os.Hostname(); err == nil {
+		labels["host.name"] = hostname
+	}
+	for key, value := range cp.settings.Labels {
+		labels[key] = value
+	}
+
+	keys := make([]string, 0, len(labels))
+	for key, value := range labels {
+		if value != "" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = key + "=" + labels[key]
+	}
+
+	name := cp.settings.Pyroscope.ApplicationName
+	if name == "" {
+		name = cp.buildInfo.Command
+	}
+	if name == "" {
+		name = defaultAppName
+	}
+	return name + "{" + strings.Join(pairs, ",") + "}"
+}
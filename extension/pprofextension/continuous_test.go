@@ -0,0 +1,167 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pprofextension
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/confignet"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+func TestContinuousProfilingDirectory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "profiles")
+	config := Config{
+		TCPAddr: confignet.TCPAddr{
+			Endpoint: testutil.GetAvailableLocalAddress(t),
+		},
+		ContinuousProfiling: ContinuousProfilingSettings{
+			Interval:    100 * time.Millisecond,
+			CPUDuration: 20 * time.Millisecond,
+			Profiles:    []string{cpuProfile, heapProfile, goroutineProfile},
+			Directory:   dir,
+			MaxSizeMiB:  defaultMaxSizeMiB,
+		},
+	}
+
+	pprofExt := newServer(config, componenttest.NewNopExtensionCreateSettings())
+	require.NoError(t, pprofExt.Start(context.Background(), componenttest.NewNopHost()))
+
+	require.Eventually(t, func() bool {
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		found := map[string]bool{}
+		for _, entry := range entries {
+			if strings.HasSuffix(entry.Name(), profileFileSuffix) {
+				found[strings.SplitN(entry.Name(), "-", 2)[0]] = true
+			}
+		}
+		return found[cpuProfile] && found[heapProfile] && found[goroutineProfile]
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, pprofExt.Shutdown(context.Background()))
+}
+
+func TestContinuousProfilingRotate(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Now()
+	names := []string{"heap-1.pb.gz", "heap-2.pb.gz", "heap-3.pb.gz"}
+	for i, name := range names {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, make([]byte, 512*1024), 0600))
+		modTime := now.Add(time.Duration(i-len(names)) * time.Minute)
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.txt"), make([]byte, 2*1024*1024), 0600))
+
+	cp := newContinuousProfiler(ContinuousProfilingSettings{Directory: dir, MaxSizeMiB: 1}, componenttest.NewNopExtensionCreateSettings())
+	require.NoError(t, cp.rotate())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var remaining []string
+	for _, entry := range entries {
+		remaining = append(remaining, entry.Name())
+	}
+	assert.ElementsMatch(t, []string{"heap-2.pb.gz", "heap-3.pb.gz", "other.txt"}, remaining)
+}
+
+type ingestRequest struct {
+	query       map[string]string
+	profile     bool
+	prevProfile bool
+}
+
+func TestContinuousProfilingPyroscope(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []ingestRequest
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/ingest", r.URL.Path)
+		if !assert.NoError(t, r.ParseMultipartForm(1<<20)) {
+			return
+		}
+		req := ingestRequest{query: map[string]string{}}
+		for key := range r.URL.Query() {
+			req.query[key] = r.URL.Query().Get(key)
+		}
+		_, req.profile = r.MultipartForm.File["profile"]
+		_, req.prevProfile = r.MultipartForm.File["prev_profile"]
+		mu.Lock()
+		requests = append(requests, req)
+		mu.Unlock()
+	}))
+	defer server.Close()
+
+	set := componenttest.NewNopExtensionCreateSettings()
+	set.BuildInfo = component.BuildInfo{Command: "otelcol-contrib", Version: "0.65.0"}
+	cp := newContinuousProfiler(ContinuousProfilingSettings{
+		Interval:    50 * time.Millisecond,
+		CPUDuration: 10 * time.Millisecond,
+		Profiles:    []string{cpuProfile, heapProfile},
+		Pyroscope: &PyroscopeSettings{
+			HTTPClientSettings: confighttp.HTTPClientSettings{Endpoint: server.URL + "/"},
+			ApplicationName:    "gateway",
+		},
+		Labels: map[string]string{"deployment.environment": "test", "service.name": "gateway"},
+	}, set)
+	require.NoError(t, cp.start(componenttest.NewNopHost()))
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(requests) >= 4
+	}, 5*time.Second, 10*time.Millisecond)
+	cp.shutdown()
+
+	hostname, err := os.Hostname()
+	require.NoError(t, err)
+	expectedName := "gateway{deployment.environment=test,host.name=" + hostname + ",service.name=gateway,service.version=0.65.0}"
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i, req := range requests[:4] {
+		assert.Equal(t, expectedName, req.query["name"])
+		assert.Equal(t, "pprof", req.query["format"])
+		assert.NotEmpty(t, req.query["from"])
+		assert.NotEmpty(t, req.query["until"])
+		assert.True(t, req.profile)
+		switch i {
+		case 0, 2:
+			assert.Equal(t, "100", req.query["sampleRate"])
+			assert.False(t, req.prevProfile)
+		case 1:
+			assert.False(t, req.prevProfile)
+		case 3:
+			assert.True(t, req.prevProfile)
+		}
+	}
+}
@@ -17,6 +17,7 @@ package pprofextension // import "github.com/open-telemetry/opentelemetry-collec
 import (
 	"context"
 	"errors"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
@@ -28,6 +29,10 @@ const (
 	typeStr = "pprof"
 
 	defaultEndpoint = "localhost:1777"
+
+	defaultInterval    = time.Minute
+	defaultCPUDuration = 10 * time.Second
+	defaultMaxSizeMiB  = 100
 )
 
 // NewFactory creates a factory for pprof extension.
@@ -46,6 +51,11 @@ func createDefaultConfig() component.ExtensionConfig {
 		TCPAddr: confignet.TCPAddr{
 			Endpoint: defaultEndpoint,
 		},
+		ContinuousProfiling: ContinuousProfilingSettings{
+			Interval:    defaultInterval,
+			CPUDuration: defaultCPUDuration,
+			MaxSizeMiB:  defaultMaxSizeMiB,
+		},
 	}
 }
 
@@ -55,5 +65,5 @@ func createExtension(_ context.Context, set component.ExtensionCreateSettings, c
 		return nil, errors.New("\"endpoint\" is required when using the \"pprof\" extension")
 	}
 
-	return newServer(*config, set.Logger), nil
+	return newServer(*config, set), nil
 }
@@ -33,6 +33,11 @@ func TestFactory_CreateDefaultConfig(t *testing.T) {
 	assert.Equal(t, &Config{
 		ExtensionSettings: config.NewExtensionSettings(component.NewID(typeStr)),
 		TCPAddr:           confignet.TCPAddr{Endpoint: defaultEndpoint},
+		ContinuousProfiling: ContinuousProfilingSettings{
+			Interval:    defaultInterval,
+			CPUDuration: defaultCPUDuration,
+			MaxSizeMiB:  defaultMaxSizeMiB,
+		},
 	},
 		cfg)
 
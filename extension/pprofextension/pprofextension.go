@@ -32,11 +32,12 @@ import (
 var running = atomic.NewBool(false)
 
 type pprofExtension struct {
-	config Config
-	logger *zap.Logger
-	file   *os.File
-	server http.Server
-	stopCh chan struct{}
+	config     Config
+	logger     *zap.Logger
+	file       *os.File
+	server     http.Server
+	stopCh     chan struct{}
+	continuous *continuousProfiler
 }
 
 func (p *pprofExtension) Start(_ context.Context, host component.Host) error {
@@ -90,6 +91,13 @@ func (p *pprofExtension) Start(_ context.Context, host component.Host) error {
 		}
 		p.file = f
 		startErr = pprof.StartCPUProfile(f)
+		if startErr != nil {
+			return startErr
+		}
+	}
+
+	if p.continuous != nil {
+		startErr = p.continuous.start(host)
 	}
 
 	return startErr
@@ -97,6 +105,9 @@ func (p *pprofExtension) Start(_ context.Context, host component.Host) error {
 
 func (p *pprofExtension) Shutdown(context.Context) error {
 	defer running.Store(false)
+	if p.continuous != nil {
+		p.continuous.shutdown()
+	}
 	if p.file != nil {
 		pprof.StopCPUProfile()
 		_ = p.file.Close() // ignore the error
@@ -108,9 +119,13 @@ func (p *pprofExtension) Shutdown(context.Context) error {
 	return err
 }
 
-func newServer(config Config, logger *zap.Logger) *pprofExtension {
-	return &pprofExtension{
+func newServer(config Config, set component.ExtensionCreateSettings) *pprofExtension {
+	p := &pprofExtension{
 		config: config,
-		logger: logger,
+		logger: set.Logger,
+	}
+	if config.ContinuousProfiling.enabled() {
+		p.continuous = newContinuousProfiler(config.ContinuousProfiling, set)
 	}
+	return p
 }
@@ -25,7 +25,6 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confignet"
-	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
 )
@@ -39,7 +38,7 @@ func TestPerformanceProfilerExtensionUsage(t *testing.T) {
 		MutexProfileFraction: 5,
 	}
 
-	pprofExt := newServer(config, zap.NewNop())
+	pprofExt := newServer(config, componenttest.NewNopExtensionCreateSettings())
 	require.NotNil(t, pprofExt)
 
 	require.NoError(t, pprofExt.Start(context.Background(), componenttest.NewNopHost()))
@@ -70,7 +69,7 @@ func TestPerformanceProfilerExtensionPortAlreadyInUse(t *testing.T) {
 			Endpoint: endpoint,
 		},
 	}
-	pprofExt := newServer(config, zap.NewNop())
+	pprofExt := newServer(config, componenttest.NewNopExtensionCreateSettings())
 	require.NotNil(t, pprofExt)
 
 	require.Error(t, pprofExt.Start(context.Background(), componenttest.NewNopHost()))
@@ -83,7 +82,7 @@ func TestPerformanceProfilerMultipleStarts(t *testing.T) {
 		},
 	}
 
-	pprofExt := newServer(config, zap.NewNop())
+	pprofExt := newServer(config, componenttest.NewNopExtensionCreateSettings())
 	require.NotNil(t, pprofExt)
 
 	require.NoError(t, pprofExt.Start(context.Background(), componenttest.NewNopHost()))
@@ -100,7 +99,7 @@ func TestPerformanceProfilerMultipleShutdowns(t *testing.T) {
 		},
 	}
 
-	pprofExt := newServer(config, zap.NewNop())
+	pprofExt := newServer(config, componenttest.NewNopExtensionCreateSettings())
 	require.NotNil(t, pprofExt)
 
 	require.NoError(t, pprofExt.Start(context.Background(), componenttest.NewNopHost()))
@@ -115,7 +114,7 @@ func TestPerformanceProfilerShutdownWithoutStart(t *testing.T) {
 		},
 	}
 
-	pprofExt := newServer(config, zap.NewNop())
+	pprofExt := newServer(config, componenttest.NewNopExtensionCreateSettings())
 	require.NotNil(t, pprofExt)
 
 	require.NoError(t, pprofExt.Shutdown(context.Background()))
@@ -136,7 +135,7 @@ func TestPerformanceProfilerLifecycleWithFile(t *testing.T) {
 		SaveToFile: tmpFile.Name(),
 	}
 
-	pprofExt := newServer(config, zap.NewNop())
+	pprofExt := newServer(config, componenttest.NewNopExtensionCreateSettings())
 	require.NotNil(t, pprofExt)
 
 	require.NoError(t, pprofExt.Start(context.Background(), componenttest.NewNopHost()))
@@ -16,6 +16,7 @@ package filestorage // import "github.com/open-telemetry/opentelemetry-collector
 
 import (
 	"context"
+	"crypto/cipher"
 	"errors"
 	"fmt"
 	"os"
@@ -30,6 +31,11 @@ import (
 
 var defaultBucket = []byte(`default`)
 
+// ErrStorageFull is returned by the operations that would increase the size of the
+// stored data beyond max_size_mib. The operations reducing it are still allowed, so
+// that the storage can be drained.
+var ErrStorageFull = errors.New("storage is full")
+
 const (
 	elapsedKey       = "elapsed"
 	directoryKey     = "directory"
@@ -46,6 +52,8 @@ type fileStorageClient struct {
 	openTimeout     time.Duration
 	cancel          context.CancelFunc
 	closed          bool
+	maxSizeBytes    int64
+	aead            cipher.AEAD
 }
 
 func bboltOptions(timeout time.Duration) *bbolt.Options {
@@ -57,7 +65,7 @@ func bboltOptions(timeout time.Duration) *bbolt.Options {
 	}
 }
 
-func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig) (*fileStorageClient, error) {
+func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compactionCfg *CompactionConfig, maxSizeMiB int64, aead cipher.AEAD) (*fileStorageClient, error) {
 	options := bboltOptions(timeout)
 	db, err := bbolt.Open(filePath, 0600, options)
 	if err != nil {
@@ -73,7 +81,14 @@ func newClient(logger *zap.Logger, filePath string, timeout time.Duration, compa
 		return nil, err
 	}
 
-	client := &fileStorageClient{logger: logger, db: db, compactionCfg: compactionCfg, openTimeout: timeout}
+	client := &fileStorageClient{
+		logger:        logger,
+		db:            db,
+		compactionCfg: compactionCfg,
+		openTimeout:   timeout,
+		maxSizeBytes:  maxSizeMiB * oneMiB,
+		aead:          aead,
+	}
 	if compactionCfg.OnRebound {
 		client.startCompactionLoop(context.Background())
 	}
@@ -110,21 +125,37 @@ func (c *fileStorageClient) Batch(ctx context.Context, ops ...storage.Operation)
 			return errors.New("storage not initialized")
 		}
 
+		if c.maxSizeBytes > 0 {
+			if err := c.checkSize(tx, bucket, ops); err != nil {
+				return err
+			}
+		}
+
 		var err error
 		for _, op := range ops {
 			switch op.Type {
 			case storage.Get:
 				value := bucket.Get([]byte(op.Key))
-				if value != nil {
+				switch {
+				case value == nil:
+					op.Value = nil
+				case c.aead != nil:
+					// decrypting the value makes a copy, which remains valid after the transaction
+					op.Value, err = openValue(c.aead, op.Key, value)
+				default:
 					// the output of Bucket.Get is only valid within a transaction, so we need to make a copy
 					// to be able to return the value
 					op.Value = make([]byte, len(value))
 					copy(op.Value, value)
-				} else {
-					op.Value = nil
 				}
 			case storage.Set:
-				err = bucket.Put([]byte(op.Key), op.Value)
+				value := op.Value
+				if c.aead != nil {
+					if value, err = sealValue(c.aead, op.Key, op.Value); err != nil {
+						return err
+					}
+				}
+				err = bucket.Put([]byte(op.Key), value)
 			case storage.Delete:
 				err = bucket.Delete([]byte(op.Key))
 			default:
@@ -144,6 +175,42 @@ func (c *fileStorageClient) Batch(ctx context.Context, ops ...storage.Operation)
 	return c.db.Update(batch)
 }
 
+// checkSize returns ErrStorageFull when the operations increase the size of the stored data
+// beyond the limit. The size is the allocated size of the database, excluding the free pages,
+// and the increase is the size of the keys and values set, excluding the values they replace
+// and the ones deleted. The encryption overhead is included in the size of the values.
+func (c *fileStorageClient) checkSize(tx *bbolt.Tx, bucket *bbolt.Bucket, ops []storage.Operation) error {
+	var overhead int64
+	if c.aead != nil {
+		overhead = int64(c.aead.NonceSize() + c.aead.Overhead())
+	}
+
+	var increase int64
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Set:
+			if value := bucket.Get([]byte(op.Key)); value != nil {
+				increase += int64(len(op.Value)) + overhead - int64(len(value))
+			} else {
+				increase += int64(len(op.Key)+len(op.Value)) + overhead
+			}
+		case storage.Delete:
+			if value := bucket.Get([]byte(op.Key)); value != nil {
+				increase -= int64(len(op.Key) + len(value))
+			}
+		}
+	}
+	if increase <= 0 {
+		return nil
+	}
+
+	dataSize := tx.Size() - int64(c.db.Stats().FreeAlloc)
+	if dataSize+increase > c.maxSizeBytes {
+		return fmt.Errorf("%w: %d bytes stored, %d bytes added, limit of %d bytes", ErrStorageFull, dataSize, increase, c.maxSizeBytes)
+	}
+	return nil
+}
+
 // Close will close the database
 func (c *fileStorageClient) Close(_ context.Context) error {
 	c.compactionMutex.Lock()
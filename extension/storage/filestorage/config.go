@@ -32,6 +32,23 @@ type Config struct {
 	Timeout   time.Duration `mapstructure:"timeout,omitempty"`
 
 	Compaction *CompactionConfig `mapstructure:"compaction,omitempty"`
+
+	// Encryption specifies that the stored values are encrypted, when set.
+	Encryption *EncryptionConfig `mapstructure:"encryption,omitempty"`
+
+	// MaxSizeMiB specifies the maximum size of the data stored by each client. The operations
+	// increasing the size of the stored data beyond this limit fail with ErrStorageFull.
+	// A value of zero disables the limit.
+	MaxSizeMiB int64 `mapstructure:"max_size_mib,omitempty"`
+}
+
+// EncryptionConfig defines configuration for the optional AES-GCM encryption of the stored values.
+// The key is the base64 encoding of 16, 24 or 32 bytes, to select AES-128, AES-192 or AES-256.
+type EncryptionConfig struct {
+	// KeyFile specifies the path of the file containing the key
+	KeyFile string `mapstructure:"key_file,omitempty"`
+	// KeyEnv specifies the name of the environment variable containing the key
+	KeyEnv string `mapstructure:"key_env,omitempty"`
 }
 
 // CompactionConfig defines configuration for optional file storage compaction.
@@ -91,5 +108,13 @@ func (cfg *Config) Validate() error {
 		return errors.New("compaction check interval must be positive when rebound compaction is set")
 	}
 
+	if cfg.MaxSizeMiB < 0 {
+		return errors.New("max size cannot be less than 0")
+	}
+
+	if cfg.Encryption != nil && (cfg.Encryption.KeyFile == "") == (cfg.Encryption.KeyEnv == "") {
+		return errors.New("exactly one of key_file and key_env must be set when encryption is set")
+	}
+
 	return nil
 }
@@ -60,6 +60,16 @@ func TestLoadConfig(t *testing.T) {
 				Timeout: 2 * time.Second,
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "encryption"),
+			expected: func() component.ExtensionConfig {
+				ret := NewFactory().CreateDefaultConfig().(*Config)
+				ret.Directory = "."
+				ret.Encryption = &EncryptionConfig{KeyEnv: "FILE_STORAGE_KEY"}
+				ret.MaxSizeMiB = 512
+				return ret
+			}(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -104,3 +114,24 @@ func TestHandleProvidingFilePathAsDirWithAnError(t *testing.T) {
 	require.Error(t, err)
 	require.EqualError(t, err, file.Name()+" is not a directory")
 }
+
+func TestValidateEncryptionAndMaxSize(t *testing.T) {
+	f := NewFactory()
+	cfg := f.CreateDefaultConfig().(*Config)
+	cfg.Directory = t.TempDir()
+	cfg.Compaction.Directory = cfg.Directory
+
+	cfg.MaxSizeMiB = -1
+	require.EqualError(t, component.ValidateConfig(cfg), "max size cannot be less than 0")
+	cfg.MaxSizeMiB = 0
+
+	const keyErr = "exactly one of key_file and key_env must be set when encryption is set"
+	cfg.Encryption = &EncryptionConfig{}
+	require.EqualError(t, component.ValidateConfig(cfg), keyErr)
+
+	cfg.Encryption = &EncryptionConfig{KeyFile: "key", KeyEnv: "KEY"}
+	require.EqualError(t, component.ValidateConfig(cfg), keyErr)
+
+	cfg.Encryption = &EncryptionConfig{KeyEnv: "KEY"}
+	require.NoError(t, component.ValidateConfig(cfg))
+}
@@ -16,6 +16,7 @@ package filestorage
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -226,6 +227,79 @@ func TestGetClientErrorsOnDeletedDirectory(t *testing.T) {
 	require.Nil(t, client)
 }
 
+func TestEncryptionKey(t *testing.T) {
+	ctx := context.Background()
+	f := NewFactory()
+	key := base64.StdEncoding.EncodeToString(make([]byte, 32))
+	keyFile := filepath.Join(t.TempDir(), "key")
+	require.NoError(t, os.WriteFile(keyFile, []byte(key+"\n"), 0600))
+	t.Setenv("FILE_STORAGE_TEST_KEY", key)
+	t.Setenv("FILE_STORAGE_TEST_SHORT_KEY", base64.StdEncoding.EncodeToString(make([]byte, 8)))
+	t.Setenv("FILE_STORAGE_TEST_INVALID_KEY", "not base64")
+
+	tests := []struct {
+		name        string
+		encryption  *EncryptionConfig
+		expectedErr string
+	}{
+		{
+			name:       "key_file",
+			encryption: &EncryptionConfig{KeyFile: keyFile},
+		},
+		{
+			name:       "key_env",
+			encryption: &EncryptionConfig{KeyEnv: "FILE_STORAGE_TEST_KEY"},
+		},
+		{
+			name:        "missing_key_file",
+			encryption:  &EncryptionConfig{KeyFile: filepath.Join(t.TempDir(), "missing")},
+			expectedErr: "failed to read encryption key file",
+		},
+		{
+			name:        "missing_key_env",
+			encryption:  &EncryptionConfig{KeyEnv: "FILE_STORAGE_TEST_MISSING_KEY"},
+			expectedErr: `encryption key environment variable "FILE_STORAGE_TEST_MISSING_KEY" is not set`,
+		},
+		{
+			name:        "invalid_key",
+			encryption:  &EncryptionConfig{KeyEnv: "FILE_STORAGE_TEST_INVALID_KEY"},
+			expectedErr: "failed to decode encryption key",
+		},
+		{
+			name:        "short_key",
+			encryption:  &EncryptionConfig{KeyEnv: "FILE_STORAGE_TEST_SHORT_KEY"},
+			expectedErr: "invalid encryption key",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := f.CreateDefaultConfig().(*Config)
+			cfg.Directory = t.TempDir()
+			cfg.Encryption = tt.encryption
+
+			extension, err := f.CreateExtension(ctx, componenttest.NewNopExtensionCreateSettings(), cfg)
+			if tt.expectedErr != "" {
+				require.ErrorContains(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			// The values are readable after reopening the storage with the same key
+			for i := 0; i < 2; i++ {
+				client, err := extension.(storage.Extension).GetClient(ctx, component.KindReceiver, newTestEntity("my_component"), "")
+				require.NoError(t, err)
+				if i == 0 {
+					require.NoError(t, client.Set(ctx, "key", []byte("value")))
+				}
+				data, err := client.Get(ctx, "key")
+				require.NoError(t, err)
+				require.Equal(t, []byte("value"), data)
+				require.NoError(t, client.Close(ctx))
+			}
+		})
+	}
+}
+
 func newTestExtension(t *testing.T) storage.Extension {
 	f := NewFactory()
 	cfg := f.CreateDefaultConfig().(*Config)
@@ -32,6 +32,7 @@ import (
 	"go.opentelemetry.io/collector/extension/ballastextension"
 	"go.opentelemetry.io/collector/extension/zpagesextension"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/apikeyauthextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/asapauthextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/basicauthextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/bearertokenauthextension"
@@ -107,6 +108,17 @@ func TestDefaultExtensions(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			extension: "apikeyauth",
+			getConfigFn: func() component.ExtensionConfig {
+				cfg := extFactories["apikeyauth"].CreateDefaultConfig().(*apikeyauthextension.Config)
+				// No need to clean up, t.TempDir will be deleted entirely.
+				fileName := filepath.Join(t.TempDir(), "keys.yaml")
+				require.NoError(t, os.WriteFile(fileName, []byte("keys: []"), 0600))
+				cfg.Filename = fileName
+				return cfg
+			},
+		},
 		{
 			extension: "bearertokenauth",
 			getConfigFn: func() component.ExtensionConfig {
@@ -115,6 +127,9 @@ func TestDefaultExtensions(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			extension: "mtlsauth",
+		},
 		{
 			extension: "memory_ballast",
 			getConfigFn: func() component.ExtensionConfig {
@@ -207,10 +222,16 @@ func TestDefaultExtensions(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			extension: "consul_observer",
+		},
 		{
 			extension:     "k8s_observer",
 			skipLifecycle: true, // Requires a K8s api to interfact with and validate
 		},
+		{
+			extension: "kafka_observer",
+		},
 		{
 			extension: "headers_setter",
 			getConfigFn: func() component.ExtensionConfig {
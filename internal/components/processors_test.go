@@ -33,6 +33,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/attraction"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/attributesprocessor"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/authorizationprocessor"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourceprocessor"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/spanprocessor"
 )
@@ -58,6 +59,16 @@ func TestDefaultProcessors(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			processor: "authorization",
+			getConfigFn: func() component.ProcessorConfig {
+				cfg := procFactories["authorization"].CreateDefaultConfig().(*authorizationprocessor.Config)
+				cfg.Policies = []authorizationprocessor.Policy{
+					{Name: "tenants", AuthAttribute: "username"},
+				}
+				return cfg
+			},
+		},
 		{
 			processor: "batch",
 		},
@@ -41,9 +41,11 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/chronyreceiver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filelogreceiver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/mongodbatlasreceiver"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlpfilereceiver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlpjsonfilereceiver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmpreceiver"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/syslogreceiver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/tcplogreceiver"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/udplogreceiver"
@@ -251,6 +253,14 @@ func TestDefaultReceivers(t *testing.T) {
 		{
 			receiver: "otlp",
 		},
+		{
+			receiver: "otlpfile",
+			getConfigFn: func() component.ReceiverConfig {
+				cfg := rcvrFactories["otlpfile"].CreateDefaultConfig().(*otlpfilereceiver.Config)
+				cfg.Include = []string{"/tmp/*.log"}
+				return cfg
+			},
+		},
 		{
 			receiver: "otlpjsonfile",
 			getConfigFn: func() component.ReceiverConfig {
@@ -282,6 +292,9 @@ func TestDefaultReceivers(t *testing.T) {
 			receiver:     "prometheus_exec",
 			skipLifecyle: true, // Requires running a subproccess that can not be easily set across platforms
 		},
+		{
+			receiver: "prometheusremotewrite",
+		},
 		{
 			receiver:     "pulsar",
 			skipLifecyle: true, // TODO It requires a running pulsar instance to start successfully.
@@ -329,6 +342,15 @@ func TestDefaultReceivers(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			receiver: "snmptrap",
+			getConfigFn: func() component.ReceiverConfig {
+				cfg := rcvrFactories["snmptrap"].CreateDefaultConfig().(*snmptrapreceiver.Config)
+				// The default port 162 requires elevated privileges.
+				cfg.Endpoint = "localhost:0"
+				return cfg
+			},
+		},
 		{
 			receiver: "splunk_hec",
 		},
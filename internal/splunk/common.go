@@ -39,6 +39,9 @@ const (
 	HecEventMetricType = "metric"
 	DefaultRawPath     = "/services/collector/raw"
 	DefaultHealthPath  = "/services/collector/health"
+	DefaultAckPath     = "/services/collector/ack"
+	// HTTPSplunkChannelHeader is the header identifying the channel of a request, required by indexer acknowledgement.
+	HTTPSplunkChannelHeader = "X-Splunk-Request-Channel"
 )
 
 // AccessTokenPassthroughConfig configures passing through access tokens.
@@ -47,6 +50,25 @@ type AccessTokenPassthroughConfig struct {
 	AccessTokenPassthrough bool `mapstructure:"access_token_passthrough"`
 }
 
+// EventResponse is the response of the HEC event endpoint. AckID is set when indexer acknowledgement
+// is enabled, see https://docs.splunk.com/Documentation/Splunk/latest/Data/AboutHECIDXAck.
+type EventResponse struct {
+	Text  string  `json:"text"`
+	Code  int     `json:"code"`
+	AckID *uint64 `json:"ackId,omitempty"`
+}
+
+// AckRequest is the body of a request to the indexer acknowledgement endpoint.
+type AckRequest struct {
+	Acks []uint64 `json:"acks"`
+}
+
+// AckResponse is the response of the indexer acknowledgement endpoint, telling for each ackId
+// whether its events were indexed.
+type AckResponse struct {
+	Acks map[uint64]bool `json:"acks"`
+}
+
 // Event represents a metric in Splunk HEC format
 type Event struct {
 	Time       *float64               `json:"time,omitempty"`       // optional epoch time - set to nil if the event timestamp is missing or unknown
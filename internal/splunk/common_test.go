@@ -125,3 +125,17 @@ func TestDecodeJsonWithInvalidNumberTime(t *testing.T) {
 	err := dec.Decode(&msg)
 	assert.Error(t, err)
 }
+
+func TestAckJSON(t *testing.T) {
+	var resp AckResponse
+	assert.NoError(t, json.Unmarshal([]byte(`{"acks":{"1":true,"3":false}}`), &resp))
+	assert.Equal(t, map[uint64]bool{1: true, 3: false}, resp.Acks)
+
+	b, err := json.Marshal(AckRequest{Acks: []uint64{1, 3}})
+	assert.NoError(t, err)
+	assert.Equal(t, `{"acks":[1,3]}`, string(b))
+
+	var eventResp EventResponse
+	assert.NoError(t, json.Unmarshal([]byte(`{"text":"Success","code":0,"ackId":7}`), &eventResp))
+	assert.Equal(t, uint64(7), *eventResp.AckID)
+}
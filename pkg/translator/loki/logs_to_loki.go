@@ -18,13 +18,14 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/push"
+	"github.com/prometheus/common/model"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 )
 
 type PushRequest struct {
-	*logproto.PushRequest
+	*push.PushRequest
 	Report *PushReport
 }
 
@@ -66,39 +67,13 @@ func LogsToLokiRequests(ld plog.Logs) map[string]PushRequest {
 		for j := 0; j < ills.Len(); j++ {
 			logs := ills.At(j).LogRecords()
 			for k := 0; k < logs.Len(); k++ {
-
-				// similarly, we may remove attributes, so change only our version
-				log := plog.NewLogRecord()
-				logs.At(k).CopyTo(log)
-
-				// we may remove attributes, so we make a copy and change our version
-				resource := pcommon.NewResource()
-				rls.At(i).Resource().CopyTo(resource)
-
-				// adds level attribute from log.severityNumber
-				addLogLevelAttributeAndHint(log)
+				log := logs.At(k)
+				resource := rls.At(i).Resource()
 
 				// resolve tenant and get/create a push request group
-				tenant := getTenantFromTenantHint(log.Attributes(), resource.Attributes())
-				group, ok := groups[tenant]
-				if !ok {
-					group = pushRequestGroup{
-						report:  &PushReport{},
-						streams: make(map[string]*logproto.Stream),
-					}
-					groups[tenant] = group
-				}
-
-				format := getFormatFromFormatHint(log.Attributes(), resource.Attributes())
-
-				mergedLabels := convertAttributesAndMerge(log.Attributes(), resource.Attributes())
-				// remove the attributes that were promoted to labels
-				removeAttributes(log.Attributes(), mergedLabels)
-				removeAttributes(resource.Attributes(), mergedLabels)
+				group := getOrCreatePushRequestGroup(groups, GetTenantFromTenantHint(log.Attributes(), resource.Attributes()))
 
-				// create the stream name based on the labels
-				labels := mergedLabels.String()
-				entry, err := convertLogToLokiEntry(log, resource, format)
+				entry, err := LogToLokiEntry(log, resource)
 				if err != nil {
 					// Couldn't convert so dropping log.
 					group.report.Errors = append(group.report.Errors, fmt.Errorf("failed to convert, dropping log: %w", err))
@@ -108,14 +83,16 @@ func LogsToLokiRequests(ld plog.Logs) map[string]PushRequest {
 
 				group.report.NumSubmitted++
 
+				// create the stream name based on the labels
+				labels := entry.Labels.String()
 				if stream, ok := group.streams[labels]; ok {
-					stream.Entries = append(stream.Entries, *entry)
+					stream.Entries = append(stream.Entries, *entry.Entry)
 					continue
 				}
 
-				group.streams[labels] = &logproto.Stream{
+				group.streams[labels] = &push.Stream{
 					Labels:  labels,
-					Entries: []logproto.Entry{*entry},
+					Entries: []push.Entry{*entry.Entry},
 				}
 			}
 		}
@@ -123,8 +100,8 @@ func LogsToLokiRequests(ld plog.Logs) map[string]PushRequest {
 
 	requests := make(map[string]PushRequest)
 	for tenant, g := range groups {
-		pr := &logproto.PushRequest{
-			Streams: make([]logproto.Stream, len(g.streams)),
+		pr := &push.PushRequest{
+			Streams: make([]push.Stream, len(g.streams)),
 		}
 
 		i := 0
@@ -140,6 +117,58 @@ func LogsToLokiRequests(ld plog.Logs) map[string]PushRequest {
 	return requests
 }
 
+func getOrCreatePushRequestGroup(groups map[string]pushRequestGroup, tenant string) pushRequestGroup {
+	group, ok := groups[tenant]
+	if !ok {
+		group = pushRequestGroup{
+			report:  &PushReport{},
+			streams: make(map[string]*push.Stream),
+		}
+		groups[tenant] = group
+	}
+	return group
+}
+
+// PushEntry is a Loki entry along with the labels of the stream it belongs to.
+type PushEntry struct {
+	Entry  *push.Entry
+	Labels model.LabelSet
+}
+
+// LogToLokiEntry converts a LogRecord into a Loki entry. The labels of its stream
+// are inferred from the same hints as in LogsToLokiRequests, and the attributes
+// promoted to labels are not part of the entry line. The given log record and
+// resource are not modified.
+func LogToLokiEntry(lr plog.LogRecord, rl pcommon.Resource) (*PushEntry, error) {
+	// we may remove attributes, so change only our version
+	log := plog.NewLogRecord()
+	lr.CopyTo(log)
+
+	// similarly, we may remove attributes, so we make a copy and change our version
+	resource := pcommon.NewResource()
+	rl.CopyTo(resource)
+
+	// adds level attribute from log.severityNumber
+	addLogLevelAttributeAndHint(log)
+
+	format := getFormatFromFormatHint(log.Attributes(), resource.Attributes())
+
+	mergedLabels := convertAttributesAndMerge(log.Attributes(), resource.Attributes())
+	// remove the attributes that were promoted to labels
+	removeAttributes(log.Attributes(), mergedLabels)
+	removeAttributes(resource.Attributes(), mergedLabels)
+
+	entry, err := convertLogToLokiEntry(log, resource, format)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PushEntry{
+		Entry:  entry,
+		Labels: mergedLabels,
+	}, nil
+}
+
 func getFormatFromFormatHint(logAttr pcommon.Map, resourceAttr pcommon.Map) string {
 	format := formatJSON
 	formatVal, found := resourceAttr.Get(hintFormat)
@@ -153,10 +182,10 @@ func getFormatFromFormatHint(logAttr pcommon.Map, resourceAttr pcommon.Map) stri
 	return format
 }
 
-// getTenantFromTenantHint extract an attribute based on the tenant hint.
-// it looks up for the attribute first in resource attributes and fallbacks to
+// GetTenantFromTenantHint extracts an attribute based on the tenant hint.
+// It looks up the attribute first in resource attributes and falls back to
 // record attributes if it is not found.
-func getTenantFromTenantHint(logAttr pcommon.Map, resourceAttr pcommon.Map) string {
+func GetTenantFromTenantHint(logAttr pcommon.Map, resourceAttr pcommon.Map) string {
 	var tenant string
 	hintAttr, found := resourceAttr.Get(hintTenant)
 	if !found {
@@ -176,7 +205,7 @@ func getTenantFromTenantHint(logAttr pcommon.Map, resourceAttr pcommon.Map) stri
 }
 
 type pushRequestGroup struct {
-	streams map[string]*logproto.Stream
+	streams map[string]*push.Stream
 	report  *PushReport
 }
 
@@ -195,10 +224,10 @@ type pushRequestGroup struct {
 // to make this decision, as it includes all of the errors that were encountered,
 // as well as the number of items dropped and submitted.
 // Deprecated: [v0.62.0] will be removed after v0.63.0. Use LogsToLokiRequests instead.
-func LogsToLoki(ld plog.Logs) (*logproto.PushRequest, *PushReport) {
+func LogsToLoki(ld plog.Logs) (*push.PushRequest, *PushReport) {
 	report := &PushReport{}
 
-	streams := make(map[string]*logproto.Stream)
+	streams := make(map[string]*push.Stream)
 	rls := ld.ResourceLogs()
 	for i := 0; i < rls.Len(); i++ {
 		ills := rls.At(i).ScopeLogs()
@@ -243,16 +272,16 @@ func LogsToLoki(ld plog.Logs) (*logproto.PushRequest, *PushReport) {
 					continue
 				}
 
-				streams[labels] = &logproto.Stream{
+				streams[labels] = &push.Stream{
 					Labels:  labels,
-					Entries: []logproto.Entry{*entry},
+					Entries: []push.Entry{*entry},
 				}
 			}
 		}
 	}
 
-	pr := &logproto.PushRequest{
-		Streams: make([]logproto.Stream, len(streams)),
+	pr := &push.PushRequest{
+		Streams: make([]push.Stream, len(streams)),
 	}
 
 	i := 0
@@ -18,7 +18,7 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/grafana/loki/pkg/logproto"
+	"github.com/grafana/loki/pkg/push"
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -52,11 +52,11 @@ func TestLogsToLokiRequestWithGroupingByTenant(t *testing.T) {
 			}(),
 			expected: map[string]PushRequest{
 				"1": {
-					PushRequest: &logproto.PushRequest{
-						Streams: []logproto.Stream{
+					PushRequest: &push.PushRequest{
+						Streams: []push.Stream{
 							{
 								Labels: `{exporter="OTLP", tenant.id="1"}`,
-								Entries: []logproto.Entry{
+								Entries: []push.Entry{
 									{
 										Line: `{"attributes":{"http.status":200}}`,
 									},
@@ -65,11 +65,11 @@ func TestLogsToLokiRequestWithGroupingByTenant(t *testing.T) {
 					},
 				},
 				"2": {
-					PushRequest: &logproto.PushRequest{
-						Streams: []logproto.Stream{
+					PushRequest: &push.PushRequest{
+						Streams: []push.Stream{
 							{
 								Labels: `{exporter="OTLP", tenant.id="2"}`,
-								Entries: []logproto.Entry{
+								Entries: []push.Entry{
 									{
 										Line: `{"attributes":{"http.status":200}}`,
 									},
@@ -104,11 +104,11 @@ func TestLogsToLokiRequestWithGroupingByTenant(t *testing.T) {
 			}(),
 			expected: map[string]PushRequest{
 				"11": {
-					PushRequest: &logproto.PushRequest{
-						Streams: []logproto.Stream{
+					PushRequest: &push.PushRequest{
+						Streams: []push.Stream{
 							{
 								Labels: `{exporter="OTLP", tenant.id="11"}`,
-								Entries: []logproto.Entry{
+								Entries: []push.Entry{
 									{
 										Line: `{"attributes":{"http.status":200}}`,
 									},
@@ -117,11 +117,11 @@ func TestLogsToLokiRequestWithGroupingByTenant(t *testing.T) {
 					},
 				},
 				"12": {
-					PushRequest: &logproto.PushRequest{
-						Streams: []logproto.Stream{
+					PushRequest: &push.PushRequest{
+						Streams: []push.Stream{
 							{
 								Labels: `{exporter="OTLP", tenant.id="12"}`,
-								Entries: []logproto.Entry{
+								Entries: []push.Entry{
 									{
 										Line: `{"attributes":{"http.status":200}}`,
 									},
@@ -147,11 +147,11 @@ func TestLogsToLokiRequestWithGroupingByTenant(t *testing.T) {
 			}(),
 			expected: map[string]PushRequest{
 				"": {
-					PushRequest: &logproto.PushRequest{
-						Streams: []logproto.Stream{
+					PushRequest: &push.PushRequest{
+						Streams: []push.Stream{
 							{
 								Labels: `{exporter="OTLP"}`,
-								Entries: []logproto.Entry{
+								Entries: []push.Entry{
 									{
 										Line: `{"attributes":{"http.status":200}}`,
 									},
@@ -190,11 +190,11 @@ func TestLogsToLokiRequestWithGroupingByTenant(t *testing.T) {
 			}(),
 			expected: map[string]PushRequest{
 				"21": {
-					PushRequest: &logproto.PushRequest{
-						Streams: []logproto.Stream{
+					PushRequest: &push.PushRequest{
+						Streams: []push.Stream{
 							{
 								Labels: `{exporter="OTLP", tenant.id="21"}`,
-								Entries: []logproto.Entry{
+								Entries: []push.Entry{
 									{
 										Line: `{"attributes":{"http.status":200}}`,
 									},
@@ -203,11 +203,11 @@ func TestLogsToLokiRequestWithGroupingByTenant(t *testing.T) {
 					},
 				},
 				"22": {
-					PushRequest: &logproto.PushRequest{
-						Streams: []logproto.Stream{
+					PushRequest: &push.PushRequest{
+						Streams: []push.Stream{
 							{
 								Labels: `{exporter="OTLP", tenant.id="22"}`,
-								Entries: []logproto.Entry{
+								Entries: []push.Entry{
 									{
 										Line: `{"attributes":{"http.status":200}}`,
 									},
@@ -521,3 +521,29 @@ func TestLogsToLoki(t *testing.T) {
 		})
 	}
 }
+
+func TestLogToLokiEntry(t *testing.T) {
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("host.name", "guarana")
+	resource.Attributes().PutStr("region.az", "eu-west-1a")
+
+	lr := plog.NewLogRecord()
+	lr.SetSeverityNumber(plog.SeverityNumberWarn)
+	lr.Attributes().PutStr(hintResources, "host.name")
+	lr.Attributes().PutStr(hintAttributes, "http.method")
+	lr.Attributes().PutStr("http.method", "GET")
+	lr.Attributes().PutInt("http.status", 200)
+
+	entry, err := LogToLokiEntry(lr, resource)
+	assert.NoError(t, err)
+	assert.Equal(t, `{exporter="OTLP", host.name="guarana", http.method="GET", level="WARN"}`, entry.Labels.String())
+	assert.Equal(t, `{"attributes":{"http.status":200},"resources":{"region.az":"eu-west-1a"}}`, entry.Entry.Line)
+
+	// the inputs are left untouched
+	assert.Equal(t, 2, resource.Attributes().Len())
+	assert.Equal(t, 4, lr.Attributes().Len())
+
+	lr.Attributes().PutStr(hintFormat, "xml")
+	_, err = LogToLokiEntry(lr, resource)
+	assert.Error(t, err)
+}
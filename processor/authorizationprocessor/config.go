@@ -0,0 +1,134 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorizationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/authorizationprocessor"
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	// actionReject rejects the whole request when one of its resources violates a policy.
+	actionReject = "reject"
+	// actionRewrite sets the resource attributes violating a policy to an allowed value.
+	actionRewrite = "rewrite"
+
+	signalTraces  = "traces"
+	signalMetrics = "metrics"
+	signalLogs    = "logs"
+)
+
+// Config defines the configuration for the authorization processor.
+type Config struct {
+	config.ProcessorSettings `mapstructure:",squash"`
+
+	// Policies are evaluated in order against the authentication data of the
+	// client, the first one matching the client applies. Requests from clients
+	// matching no policy are rejected.
+	Policies []Policy `mapstructure:"policies"`
+}
+
+// Policy defines what the clients having a given authentication attribute may send.
+type Policy struct {
+	// Name identifies the policy in the logs and errors.
+	Name string `mapstructure:"name"`
+
+	// AuthAttribute is the name of the attribute of the client authentication
+	// data this policy is matched against, e.g. `username` for the basicauth
+	// extension or `membership` for the oidc extension.
+	AuthAttribute string `mapstructure:"auth_attribute"`
+
+	// Values are the values of AuthAttribute matching this policy. When empty,
+	// any client having AuthAttribute matches.
+	Values []string `mapstructure:"values"`
+
+	// Signals are the signals the matching clients may send, any of `traces`,
+	// `metrics` and `logs`. When empty, all the signals are allowed.
+	Signals []string `mapstructure:"signals"`
+
+	// SignalsFromAuthAttribute is the name of the attribute of the client
+	// authentication data holding the signals the client may send, instead of
+	// Signals. Clients without this attribute may not send any signal.
+	SignalsFromAuthAttribute string `mapstructure:"signals_from_auth_attribute"`
+
+	// ResourceAttributes are the resource attributes enforced on the data sent
+	// by the matching clients.
+	ResourceAttributes []ResourceAttribute `mapstructure:"resource_attributes"`
+
+	// Action is what happens when a resource attribute is missing or has a value
+	// that isn't allowed, `reject` (default) or `rewrite`.
+	Action string `mapstructure:"action"`
+}
+
+// ResourceAttribute defines the allowed values of a resource attribute.
+type ResourceAttribute struct {
+	// Key of the resource attribute.
+	Key string `mapstructure:"key"`
+
+	// Value is the only allowed value of the resource attribute.
+	Value string `mapstructure:"value"`
+
+	// FromAuthAttribute is the name of the attribute of the client
+	// authentication data holding the allowed values of the resource attribute.
+	FromAuthAttribute string `mapstructure:"from_auth_attribute"`
+}
+
+var _ component.ProcessorConfig = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	if len(cfg.Policies) == 0 {
+		return errors.New("at least one policy must be configured")
+	}
+	for i, policy := range cfg.Policies {
+		if err := policy.validate(); err != nil {
+			return fmt.Errorf("policies[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (p *Policy) validate() error {
+	if p.AuthAttribute == "" {
+		return errors.New("auth_attribute must be specified")
+	}
+	if len(p.Signals) > 0 && p.SignalsFromAuthAttribute != "" {
+		return errors.New("only one of signals or signals_from_auth_attribute can be specified")
+	}
+	for _, signal := range p.Signals {
+		switch signal {
+		case signalTraces, signalMetrics, signalLogs:
+		default:
+			return fmt.Errorf("unknown signal %q, must be one of %q, %q or %q", signal, signalTraces, signalMetrics, signalLogs)
+		}
+	}
+	switch p.Action {
+	case "", actionReject, actionRewrite:
+	default:
+		return fmt.Errorf("unknown action %q, must be %q or %q", p.Action, actionReject, actionRewrite)
+	}
+	for _, attr := range p.ResourceAttributes {
+		if attr.Key == "" {
+			return errors.New("resource_attributes: key must be specified")
+		}
+		if (attr.Value == "") == (attr.FromAuthAttribute == "") {
+			return fmt.Errorf("resource_attributes: exactly one of value or from_auth_attribute must be specified for %q", attr.Key)
+		}
+	}
+	return nil
+}
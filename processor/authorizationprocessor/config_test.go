@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorizationprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id          component.ID
+		expected    component.ProcessorConfig
+		expectedErr string
+	}{
+		{
+			id: component.NewIDWithName(typeStr, ""),
+			expected: &Config{
+				ProcessorSettings: config.NewProcessorSettings(component.NewID(typeStr)),
+				Policies: []Policy{
+					{
+						Name:          "admins",
+						AuthAttribute: "membership",
+						Values:        []string{"admins"},
+					},
+					{
+						Name:          "tenants",
+						AuthAttribute: "username",
+						Signals:       []string{"traces", "logs"},
+						ResourceAttributes: []ResourceAttribute{
+							{Key: "tenant.id", FromAuthAttribute: "username"},
+							{Key: "deployment.environment", Value: "production"},
+						},
+						Action: actionRewrite,
+					},
+				},
+			},
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "empty"),
+			expectedErr: "at least one policy must be configured",
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "missing_auth_attribute"),
+			expectedErr: "policies[0]: auth_attribute must be specified",
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "unknown_signal"),
+			expectedErr: `policies[0]: unknown signal "profiles", must be one of "traces", "metrics" or "logs"`,
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "signals_and_signals_from_auth_attribute"),
+			expectedErr: "policies[0]: only one of signals or signals_from_auth_attribute can be specified",
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "unknown_action"),
+			expectedErr: `policies[0]: unknown action "drop", must be "reject" or "rewrite"`,
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "missing_key"),
+			expectedErr: "policies[0]: resource_attributes: key must be specified",
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "value_and_from_auth_attribute"),
+			expectedErr: `policies[0]: resource_attributes: exactly one of value or from_auth_attribute must be specified for "tenant.id"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalProcessorConfig(sub, cfg))
+
+			if tt.expectedErr != "" {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.expectedErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
@@ -0,0 +1,201 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorizationprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/authorizationprocessor"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+var (
+	errUnauthenticated = errors.New("the request is not authenticated")
+	errNoPolicy        = errors.New("no authorization policy matches the client")
+)
+
+type authorizationProcessor struct {
+	policies []Policy
+	logger   *zap.Logger
+}
+
+func newAuthorizationProcessor(cfg *Config, logger *zap.Logger) *authorizationProcessor {
+	return &authorizationProcessor{
+		policies: cfg.Policies,
+		logger:   logger,
+	}
+}
+
+func (ap *authorizationProcessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	rss := td.ResourceSpans()
+	resources := make([]pcommon.Resource, rss.Len())
+	for i := 0; i < rss.Len(); i++ {
+		resources[i] = rss.At(i).Resource()
+	}
+	return td, ap.authorize(ctx, signalTraces, resources)
+}
+
+func (ap *authorizationProcessor) processMetrics(ctx context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	rms := md.ResourceMetrics()
+	resources := make([]pcommon.Resource, rms.Len())
+	for i := 0; i < rms.Len(); i++ {
+		resources[i] = rms.At(i).Resource()
+	}
+	return md, ap.authorize(ctx, signalMetrics, resources)
+}
+
+func (ap *authorizationProcessor) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	rls := ld.ResourceLogs()
+	resources := make([]pcommon.Resource, rls.Len())
+	for i := 0; i < rls.Len(); i++ {
+		resources[i] = rls.At(i).Resource()
+	}
+	return ld, ap.authorize(ctx, signalLogs, resources)
+}
+
+// authorize checks the client of the request against the policies, and
+// enforces the resource attributes of the matching policy on the resources.
+// The returned errors are permanent, the request must not be retried.
+func (ap *authorizationProcessor) authorize(ctx context.Context, signal string, resources []pcommon.Resource) error {
+	auth := client.FromContext(ctx).Auth
+	if auth == nil {
+		ap.logger.Debug("Rejecting request", zap.String("signal", signal), zap.Error(errUnauthenticated))
+		return consumererror.NewPermanent(errUnauthenticated)
+	}
+
+	policy := ap.matchPolicy(auth)
+	if policy == nil {
+		ap.logger.Debug("Rejecting request", zap.String("signal", signal), zap.Error(errNoPolicy))
+		return consumererror.NewPermanent(errNoPolicy)
+	}
+
+	if err := policy.enforce(auth, signal, resources); err != nil {
+		ap.logger.Debug("Rejecting request", zap.String("signal", signal), zap.String("policy", policy.Name), zap.Error(err))
+		return consumererror.NewPermanent(err)
+	}
+	return nil
+}
+
+// matchPolicy returns the first policy matching the client, nil if none does.
+func (ap *authorizationProcessor) matchPolicy(auth client.AuthData) *Policy {
+	for i := range ap.policies {
+		policy := &ap.policies[i]
+		values := authValues(auth.GetAttribute(policy.AuthAttribute))
+		if len(values) == 0 {
+			continue
+		}
+		if len(policy.Values) == 0 || containsAny(policy.Values, values) {
+			return policy
+		}
+	}
+	return nil
+}
+
+func (p *Policy) enforce(auth client.AuthData, signal string, resources []pcommon.Resource) error {
+	allowedSignals := p.Signals
+	if p.SignalsFromAuthAttribute != "" {
+		allowedSignals = authValues(auth.GetAttribute(p.SignalsFromAuthAttribute))
+		if len(allowedSignals) == 0 {
+			return fmt.Errorf("signal %q is not allowed by policy %q, the client has no %q auth attribute", signal, p.Name, p.SignalsFromAuthAttribute)
+		}
+	}
+	if len(allowedSignals) > 0 && !containsAny(allowedSignals, []string{signal}) {
+		return fmt.Errorf("signal %q is not allowed by policy %q", signal, p.Name)
+	}
+	if len(p.ResourceAttributes) == 0 {
+		return nil
+	}
+
+	allowed := make([][]string, len(p.ResourceAttributes))
+	for i, attr := range p.ResourceAttributes {
+		if attr.Value != "" {
+			allowed[i] = []string{attr.Value}
+			continue
+		}
+		allowed[i] = authValues(auth.GetAttribute(attr.FromAuthAttribute))
+		if len(allowed[i]) == 0 {
+			return fmt.Errorf("the client has no %q auth attribute to check the resource attribute %q against", attr.FromAuthAttribute, attr.Key)
+		}
+	}
+
+	// Check all the resources before rewriting any, so that rejected requests
+	// are left untouched.
+	if p.Action != actionRewrite {
+		for _, resource := range resources {
+			for i, attr := range p.ResourceAttributes {
+				if !hasAllowedValue(resource, attr.Key, allowed[i]) {
+					return fmt.Errorf("resource attribute %q is missing or has a value not allowed by policy %q", attr.Key, p.Name)
+				}
+			}
+		}
+		return nil
+	}
+
+	for _, resource := range resources {
+		for i, attr := range p.ResourceAttributes {
+			if !hasAllowedValue(resource, attr.Key, allowed[i]) {
+				resource.Attributes().PutStr(attr.Key, allowed[i][0])
+			}
+		}
+	}
+	return nil
+}
+
+func hasAllowedValue(resource pcommon.Resource, key string, allowed []string) bool {
+	value, ok := resource.Attributes().Get(key)
+	return ok && containsAny(allowed, []string{value.AsString()})
+}
+
+// authValues converts the value of an authentication attribute, e.g. a
+// username or a list of OIDC groups, to a list of strings.
+func authValues(value interface{}) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		if v == "" {
+			return nil
+		}
+		return []string{v}
+	case []string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, e := range v {
+			values = append(values, fmt.Sprint(e))
+		}
+		return values
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
+
+func containsAny(list []string, values []string) bool {
+	for _, l := range list {
+		for _, v := range values {
+			if l == v {
+				return true
+			}
+		}
+	}
+	return false
+}
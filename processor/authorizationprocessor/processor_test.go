@@ -0,0 +1,252 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authorizationprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+type authData map[string]interface{}
+
+func (a authData) GetAttribute(name string) interface{} {
+	return a[name]
+}
+
+func (a authData) GetAttributeNames() []string {
+	names := make([]string, 0, len(a))
+	for name := range a {
+		names = append(names, name)
+	}
+	return names
+}
+
+func contextWithAuth(auth client.AuthData) context.Context {
+	return client.NewContext(context.Background(), client.Info{Auth: auth})
+}
+
+func tracesWithTenants(tenants ...string) ptrace.Traces {
+	td := ptrace.NewTraces()
+	for _, tenant := range tenants {
+		rs := td.ResourceSpans().AppendEmpty()
+		if tenant != "" {
+			rs.Resource().Attributes().PutStr("tenant.id", tenant)
+		}
+		rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	}
+	return td
+}
+
+func tenantPolicy(action string) Policy {
+	return Policy{
+		Name:          "tenants",
+		AuthAttribute: "username",
+		Signals:       []string{signalTraces},
+		ResourceAttributes: []ResourceAttribute{
+			{Key: "tenant.id", FromAuthAttribute: "username"},
+		},
+		Action: action,
+	}
+}
+
+func TestProcessTraces(t *testing.T) {
+	tests := []struct {
+		name        string
+		policies    []Policy
+		ctx         context.Context
+		traces      ptrace.Traces
+		expectedErr string
+		expected    []string
+	}{
+		{
+			name:        "unauthenticated",
+			policies:    []Policy{tenantPolicy(actionReject)},
+			ctx:         context.Background(),
+			traces:      tracesWithTenants("tenant-a"),
+			expectedErr: "the request is not authenticated",
+		},
+		{
+			name:        "no matching policy",
+			policies:    []Policy{{Name: "admins", AuthAttribute: "membership", Values: []string{"admins"}}},
+			ctx:         contextWithAuth(authData{"membership": []string{"developers"}}),
+			traces:      tracesWithTenants("tenant-a"),
+			expectedErr: "no authorization policy matches the client",
+		},
+		{
+			name:     "matching group",
+			policies: []Policy{{Name: "admins", AuthAttribute: "membership", Values: []string{"admins"}}},
+			ctx:      contextWithAuth(authData{"membership": []interface{}{"developers", "admins"}}),
+			traces:   tracesWithTenants("tenant-a", "tenant-b"),
+			expected: []string{"tenant-a", "tenant-b"},
+		},
+		{
+			name: "first matching policy applies",
+			policies: []Policy{
+				{Name: "admins", AuthAttribute: "membership", Values: []string{"admins"}},
+				tenantPolicy(actionReject),
+			},
+			ctx:         contextWithAuth(authData{"username": "tenant-a"}),
+			traces:      tracesWithTenants("tenant-b"),
+			expectedErr: `resource attribute "tenant.id" is missing or has a value not allowed by policy "tenants"`,
+		},
+		{
+			name: "signal not allowed",
+			policies: []Policy{{
+				Name:          "metrics-only",
+				AuthAttribute: "username",
+				Signals:       []string{signalMetrics},
+			}},
+			ctx:         contextWithAuth(authData{"username": "tenant-a"}),
+			traces:      tracesWithTenants("tenant-a"),
+			expectedErr: `signal "traces" is not allowed by policy "metrics-only"`,
+		},
+		{
+			name: "signal not allowed by auth attribute",
+			policies: []Policy{{
+				Name:                     "keys",
+				AuthAttribute:            "key_id",
+				SignalsFromAuthAttribute: "signals",
+			}},
+			ctx:         contextWithAuth(authData{"key_id": "team-a", "signals": []string{"metrics", "logs"}}),
+			traces:      tracesWithTenants("tenant-a"),
+			expectedErr: `signal "traces" is not allowed by policy "keys"`,
+		},
+		{
+			name: "missing signals auth attribute",
+			policies: []Policy{{
+				Name:                     "keys",
+				AuthAttribute:            "key_id",
+				SignalsFromAuthAttribute: "signals",
+			}},
+			ctx:         contextWithAuth(authData{"key_id": "team-a"}),
+			traces:      tracesWithTenants("tenant-a"),
+			expectedErr: `signal "traces" is not allowed by policy "keys", the client has no "signals" auth attribute`,
+		},
+		{
+			name: "signal allowed by auth attribute",
+			policies: []Policy{{
+				Name:                     "keys",
+				AuthAttribute:            "key_id",
+				SignalsFromAuthAttribute: "signals",
+			}},
+			ctx:      contextWithAuth(authData{"key_id": "team-a", "signals": []string{"traces"}}),
+			traces:   tracesWithTenants("tenant-a"),
+			expected: []string{"tenant-a"},
+		},
+		{
+			name:     "allowed resource attribute",
+			policies: []Policy{tenantPolicy(actionReject)},
+			ctx:      contextWithAuth(authData{"username": "tenant-a"}),
+			traces:   tracesWithTenants("tenant-a", "tenant-a"),
+			expected: []string{"tenant-a", "tenant-a"},
+		},
+		{
+			name:        "rejected resource attribute",
+			policies:    []Policy{tenantPolicy(actionReject)},
+			ctx:         contextWithAuth(authData{"username": "tenant-a"}),
+			traces:      tracesWithTenants("tenant-a", ""),
+			expectedErr: `resource attribute "tenant.id" is missing or has a value not allowed by policy "tenants"`,
+		},
+		{
+			name:     "rewritten resource attributes",
+			policies: []Policy{tenantPolicy(actionRewrite)},
+			ctx:      contextWithAuth(authData{"username": "tenant-a"}),
+			traces:   tracesWithTenants("tenant-a", "tenant-b", ""),
+			expected: []string{"tenant-a", "tenant-a", "tenant-a"},
+		},
+		{
+			name: "missing auth attribute for resource attribute",
+			policies: []Policy{{
+				Name:          "groups",
+				AuthAttribute: "membership",
+				ResourceAttributes: []ResourceAttribute{
+					{Key: "tenant.id", FromAuthAttribute: "tenant"},
+				},
+				Action: actionRewrite,
+			}},
+			ctx:         contextWithAuth(authData{"membership": "tenants"}),
+			traces:      tracesWithTenants("tenant-a"),
+			expectedErr: `the client has no "tenant" auth attribute to check the resource attribute "tenant.id" against`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Policies = tt.policies
+			require.NoError(t, cfg.Validate())
+
+			sink := new(consumertest.TracesSink)
+			tp, err := createTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, sink)
+			require.NoError(t, err)
+
+			err = tp.ConsumeTraces(tt.ctx, tt.traces)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, "Permanent error: "+tt.expectedErr)
+				assert.True(t, consumererror.IsPermanent(err))
+				assert.Empty(t, sink.AllTraces())
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, sink.AllTraces(), 1)
+
+			rss := sink.AllTraces()[0].ResourceSpans()
+			var tenants []string
+			for i := 0; i < rss.Len(); i++ {
+				tenant, ok := rss.At(i).Resource().Attributes().Get("tenant.id")
+				require.True(t, ok)
+				tenants = append(tenants, tenant.Str())
+			}
+			assert.Equal(t, tt.expected, tenants)
+		})
+	}
+}
+
+func TestProcessMetricsAndLogs(t *testing.T) {
+	ap := newAuthorizationProcessor(&Config{Policies: []Policy{tenantPolicy(actionRewrite)}}, zap.NewNop())
+	ctx := contextWithAuth(authData{"username": "tenant-a"})
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty()
+	_, err := ap.processMetrics(ctx, md)
+	assert.EqualError(t, err, `Permanent error: signal "metrics" is not allowed by policy "tenants"`)
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty()
+	_, err = ap.processLogs(ctx, ld)
+	assert.EqualError(t, err, `Permanent error: signal "logs" is not allowed by policy "tenants"`)
+
+	ap.policies[0].Signals = nil
+	md, err = ap.processMetrics(ctx, md)
+	require.NoError(t, err)
+	tenant, _ := md.ResourceMetrics().At(0).Resource().Attributes().Get("tenant.id")
+	assert.Equal(t, "tenant-a", tenant.Str())
+
+	ld, err = ap.processLogs(ctx, ld)
+	require.NoError(t, err)
+	tenant, _ = ld.ResourceLogs().At(0).Resource().Attributes().Get("tenant.id")
+	assert.Equal(t, "tenant-a", tenant.Str())
+}
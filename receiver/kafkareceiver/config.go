@@ -15,6 +15,7 @@
 package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
 
 import (
+	"errors"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -43,6 +44,25 @@ type MessageMarking struct {
 	OnError bool `mapstructure:"on_error"`
 }
 
+// DeadLetter defines the topic the messages that can't be processed are produced to.
+type DeadLetter struct {
+	// The name of the kafka topic the messages failing to be unmarshaled, or returning a permanent
+	// error from the pipeline, are produced to before being marked. Disabled if empty.
+	Topic string `mapstructure:"topic"`
+}
+
+// HeaderExtraction defines the kafka message headers copied to the received data.
+type HeaderExtraction struct {
+	// The names of the headers to copy.
+	Headers []string `mapstructure:"headers"`
+
+	// If true, the headers are added to the client.Info metadata of the context passed to the pipeline.
+	ClientMetadata bool `mapstructure:"client_metadata"`
+
+	// If true, the headers are added as kafka.header.<name> resource attributes.
+	ResourceAttributes bool `mapstructure:"resource_attributes"`
+}
+
 // Config defines configuration for Kafka receiver.
 type Config struct {
 	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
@@ -70,11 +90,23 @@ type Config struct {
 
 	// Controls the way the messages are marked as consumed
 	MessageMarking MessageMarking `mapstructure:"message_marking"`
+
+	// Controls the dead letter topic
+	DeadLetter DeadLetter `mapstructure:"dead_letter"`
+
+	// Controls the headers copied to the received data
+	HeaderExtraction HeaderExtraction `mapstructure:"header_extraction"`
 }
 
 var _ component.ReceiverConfig = (*Config)(nil)
 
 // Validate checks the receiver configuration is valid
 func (cfg *Config) Validate() error {
+	if len(cfg.HeaderExtraction.Headers) > 0 && !cfg.HeaderExtraction.ClientMetadata && !cfg.HeaderExtraction.ResourceAttributes {
+		return errors.New("header_extraction requires client_metadata or resource_attributes to be enabled")
+	}
+	if cfg.DeadLetter.Topic != "" && cfg.DeadLetter.Topic == cfg.Topic {
+		return errors.New("dead_letter::topic must be different from topic")
+	}
 	return nil
 }
@@ -101,6 +101,13 @@ func TestLoadConfig(t *testing.T) {
 					Enable:   true,
 					Interval: 1 * time.Second,
 				},
+				DeadLetter: DeadLetter{
+					Topic: "logs_dlq",
+				},
+				HeaderExtraction: HeaderExtraction{
+					Headers:        []string{"tenant"},
+					ClientMetadata: true,
+				},
 			},
 		},
 	}
@@ -119,3 +126,16 @@ func TestLoadConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigValidate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.HeaderExtraction.Headers = []string{"tenant"}
+	assert.EqualError(t, cfg.Validate(), "header_extraction requires client_metadata or resource_attributes to be enabled")
+	cfg.HeaderExtraction.ResourceAttributes = true
+	assert.NoError(t, cfg.Validate())
+
+	cfg.DeadLetter.Topic = cfg.Topic
+	assert.EqualError(t, cfg.Validate(), "dead_letter::topic must be different from topic")
+}
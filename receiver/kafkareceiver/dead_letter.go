@@ -0,0 +1,130 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
+
+import (
+	"strconv"
+
+	"github.com/Shopify/sarama"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+)
+
+// Headers added to the messages produced to the dead letter topic, along with the original headers.
+const (
+	deadLetterErrorHeader     = "otel.error"
+	deadLetterTopicHeader     = "otel.original.topic"
+	deadLetterPartitionHeader = "otel.original.partition"
+	deadLetterOffsetHeader    = "otel.original.offset"
+)
+
+// deadLetterProducer produces the messages that can't be processed to the dead letter topic.
+type deadLetterProducer struct {
+	id       component.ID
+	producer sarama.SyncProducer
+	topic    string
+	logger   *zap.Logger
+}
+
+// newDeadLetterProducer returns nil if no dead letter topic is configured.
+func newDeadLetterProducer(config Config, set component.ReceiverCreateSettings) (*deadLetterProducer, error) {
+	if config.DeadLetter.Topic == "" {
+		return nil, nil
+	}
+
+	c := sarama.NewConfig()
+	c.ClientID = config.ClientID
+	c.Metadata.Full = config.Metadata.Full
+	c.Metadata.Retry.Max = config.Metadata.Retry.Max
+	c.Metadata.Retry.Backoff = config.Metadata.Retry.Backoff
+	// These setting are required by the sarama.SyncProducer implementation.
+	c.Producer.Return.Successes = true
+	c.Producer.Return.Errors = true
+	// The message is marked once produced, so it must not be lost by the brokers.
+	c.Producer.RequiredAcks = sarama.WaitForAll
+	if config.ProtocolVersion != "" {
+		version, err := sarama.ParseKafkaVersion(config.ProtocolVersion)
+		if err != nil {
+			return nil, err
+		}
+		c.Version = version
+	}
+	if err := kafkaexporter.ConfigureAuthentication(config.Authentication, c); err != nil {
+		return nil, err
+	}
+	producer, err := sarama.NewSyncProducer(config.Brokers, c)
+	if err != nil {
+		return nil, err
+	}
+	return &deadLetterProducer{
+		id:       config.ID(),
+		producer: producer,
+		topic:    config.DeadLetter.Topic,
+		logger:   set.Logger,
+	}, nil
+}
+
+// handle produces the message to the dead letter topic, then marks it, if err is permanent.
+// It returns false if the message wasn't produced to the dead letter topic, in which case
+// the error must be handled as if there was no dead letter topic.
+func (d *deadLetterProducer) handle(session sarama.ConsumerGroupSession, message *sarama.ConsumerMessage, err error) bool {
+	if d == nil || !consumererror.IsPermanent(err) {
+		return false
+	}
+
+	headers := make([]sarama.RecordHeader, 0, len(message.Headers)+4)
+	for _, header := range message.Headers {
+		if header != nil {
+			headers = append(headers, *header)
+		}
+	}
+	headers = append(headers,
+		sarama.RecordHeader{Key: []byte(deadLetterErrorHeader), Value: []byte(err.Error())},
+		sarama.RecordHeader{Key: []byte(deadLetterTopicHeader), Value: []byte(message.Topic)},
+		sarama.RecordHeader{Key: []byte(deadLetterPartitionHeader), Value: []byte(strconv.FormatInt(int64(message.Partition), 10))},
+		sarama.RecordHeader{Key: []byte(deadLetterOffsetHeader), Value: []byte(strconv.FormatInt(message.Offset, 10))},
+	)
+	msg := &sarama.ProducerMessage{
+		Topic:   d.topic,
+		Value:   sarama.ByteEncoder(message.Value),
+		Headers: headers,
+	}
+	if message.Key != nil {
+		msg.Key = sarama.ByteEncoder(message.Key)
+	}
+	if _, _, sendErr := d.producer.SendMessage(msg); sendErr != nil {
+		d.logger.Error("failed to produce message to the dead letter topic", zap.String("topic", d.topic), zap.Error(sendErr))
+		return false
+	}
+
+	_ = stats.RecordWithTags(
+		session.Context(),
+		[]tag.Mutator{tag.Upsert(tagInstanceName, d.id.String())},
+		statDeadLetterCount.M(1))
+	session.MarkMessage(message, "")
+	return true
+}
+
+func (d *deadLetterProducer) close() error {
+	if d == nil {
+		return nil
+	}
+	return d.producer.Close()
+}
@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
+
+import (
+	"context"
+
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/collector/client"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// headerAttributePrefix prefixes the names of the headers added as resource attributes.
+const headerAttributePrefix = "kafka.header."
+
+// headerExtractor copies the configured kafka message headers to the received data.
+type headerExtractor struct {
+	headers            []string
+	clientMetadata     bool
+	resourceAttributes bool
+}
+
+func newHeaderExtractor(cfg HeaderExtraction) headerExtractor {
+	return headerExtractor{
+		headers:            cfg.Headers,
+		clientMetadata:     cfg.ClientMetadata,
+		resourceAttributes: cfg.ResourceAttributes,
+	}
+}
+
+// values returns the values of the configured headers of the message.
+func (h headerExtractor) values(message *sarama.ConsumerMessage) map[string][]string {
+	if len(h.headers) == 0 {
+		return nil
+	}
+	values := map[string][]string{}
+	for _, header := range message.Headers {
+		if header == nil {
+			continue
+		}
+		key := string(header.Key)
+		for _, name := range h.headers {
+			if key == name {
+				values[name] = append(values[name], string(header.Value))
+			}
+		}
+	}
+	return values
+}
+
+// context returns ctx with the values in its client.Info metadata, if enabled.
+func (h headerExtractor) context(ctx context.Context, values map[string][]string) context.Context {
+	if !h.clientMetadata || len(values) == 0 {
+		return ctx
+	}
+	info := client.FromContext(ctx)
+	info.Metadata = client.NewMetadata(values)
+	return client.NewContext(ctx, info)
+}
+
+// putAttributes adds the first value of each header to the resource, if enabled.
+func (h headerExtractor) putAttributes(resource pcommon.Resource, values map[string][]string) {
+	if !h.resourceAttributes {
+		return
+	}
+	for name, v := range values {
+		resource.Attributes().PutStr(headerAttributePrefix+name, v[0])
+	}
+}
+
+func (h headerExtractor) extractTraces(ctx context.Context, message *sarama.ConsumerMessage, traces ptrace.Traces) context.Context {
+	values := h.values(message)
+	rss := traces.ResourceSpans()
+	for i := 0; i < rss.Len() && len(values) > 0; i++ {
+		h.putAttributes(rss.At(i).Resource(), values)
+	}
+	return h.context(ctx, values)
+}
+
+func (h headerExtractor) extractMetrics(ctx context.Context, message *sarama.ConsumerMessage, metrics pmetric.Metrics) context.Context {
+	values := h.values(message)
+	rms := metrics.ResourceMetrics()
+	for i := 0; i < rms.Len() && len(values) > 0; i++ {
+		h.putAttributes(rms.At(i).Resource(), values)
+	}
+	return h.context(ctx, values)
+}
+
+func (h headerExtractor) extractLogs(ctx context.Context, message *sarama.ConsumerMessage, logs plog.Logs) context.Context {
+	values := h.values(message)
+	rls := logs.ResourceLogs()
+	for i := 0; i < rls.Len() && len(values) > 0; i++ {
+		h.putAttributes(rls.At(i).Resource(), values)
+	}
+	return h.context(ctx, values)
+}
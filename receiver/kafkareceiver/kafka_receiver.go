@@ -17,6 +17,7 @@ package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collect
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"sync"
 
 	"github.com/Shopify/sarama"
@@ -24,7 +25,9 @@ import (
 	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
@@ -49,6 +52,8 @@ type kafkaTracesConsumer struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	deadLetter        *deadLetterProducer
+	headers           headerExtractor
 }
 
 // kafkaMetricsConsumer uses sarama to consume and handle messages from kafka.
@@ -64,6 +69,8 @@ type kafkaMetricsConsumer struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	deadLetter        *deadLetterProducer
+	headers           headerExtractor
 }
 
 // kafkaLogsConsumer uses sarama to consume and handle messages from kafka.
@@ -79,6 +86,8 @@ type kafkaLogsConsumer struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	deadLetter        *deadLetterProducer
+	headers           headerExtractor
 }
 
 var _ component.Receiver = (*kafkaTracesConsumer)(nil)
@@ -110,6 +119,11 @@ func newTracesReceiver(config Config, set component.ReceiverCreateSettings, unma
 	if err != nil {
 		return nil, err
 	}
+	deadLetter, err := newDeadLetterProducer(config, set)
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
 	return &kafkaTracesConsumer{
 		id:                config.ID(),
 		consumerGroup:     client,
@@ -119,6 +133,8 @@ func newTracesReceiver(config Config, set component.ReceiverCreateSettings, unma
 		settings:          set,
 		autocommitEnabled: config.AutoCommit.Enable,
 		messageMarking:    config.MessageMarking,
+		deadLetter:        deadLetter,
+		headers:           newHeaderExtractor(config.HeaderExtraction),
 	}, nil
 }
 
@@ -142,6 +158,8 @@ func (c *kafkaTracesConsumer) Start(_ context.Context, host component.Host) erro
 		obsrecv:           obsrecv,
 		autocommitEnabled: c.autocommitEnabled,
 		messageMarking:    c.messageMarking,
+		deadLetter:        c.deadLetter,
+		headers:           c.headers,
 	}
 	go func() {
 		if err := c.consumeLoop(ctx, consumerGroup); err != nil {
@@ -170,7 +188,7 @@ func (c *kafkaTracesConsumer) consumeLoop(ctx context.Context, handler sarama.Co
 
 func (c *kafkaTracesConsumer) Shutdown(context.Context) error {
 	c.cancelConsumeLoop()
-	return c.consumerGroup.Close()
+	return multierr.Combine(c.consumerGroup.Close(), c.deadLetter.close())
 }
 
 func newMetricsReceiver(config Config, set component.ReceiverCreateSettings, unmarshalers map[string]MetricsUnmarshaler, nextConsumer consumer.Metrics) (*kafkaMetricsConsumer, error) {
@@ -201,6 +219,11 @@ func newMetricsReceiver(config Config, set component.ReceiverCreateSettings, unm
 	if err != nil {
 		return nil, err
 	}
+	deadLetter, err := newDeadLetterProducer(config, set)
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
 	return &kafkaMetricsConsumer{
 		id:                config.ID(),
 		consumerGroup:     client,
@@ -210,6 +233,8 @@ func newMetricsReceiver(config Config, set component.ReceiverCreateSettings, unm
 		settings:          set,
 		autocommitEnabled: config.AutoCommit.Enable,
 		messageMarking:    config.MessageMarking,
+		deadLetter:        deadLetter,
+		headers:           newHeaderExtractor(config.HeaderExtraction),
 	}, nil
 }
 
@@ -233,6 +258,8 @@ func (c *kafkaMetricsConsumer) Start(_ context.Context, host component.Host) err
 		obsrecv:           obsrecv,
 		autocommitEnabled: c.autocommitEnabled,
 		messageMarking:    c.messageMarking,
+		deadLetter:        c.deadLetter,
+		headers:           c.headers,
 	}
 	go func() {
 		if err := c.consumeLoop(ctx, metricsConsumerGroup); err != nil {
@@ -261,7 +288,7 @@ func (c *kafkaMetricsConsumer) consumeLoop(ctx context.Context, handler sarama.C
 
 func (c *kafkaMetricsConsumer) Shutdown(context.Context) error {
 	c.cancelConsumeLoop()
-	return c.consumerGroup.Close()
+	return multierr.Combine(c.consumerGroup.Close(), c.deadLetter.close())
 }
 
 func newLogsReceiver(config Config, set component.ReceiverCreateSettings, unmarshalers map[string]LogsUnmarshaler, nextConsumer consumer.Logs) (*kafkaLogsConsumer, error) {
@@ -289,6 +316,11 @@ func newLogsReceiver(config Config, set component.ReceiverCreateSettings, unmars
 	if err != nil {
 		return nil, err
 	}
+	deadLetter, err := newDeadLetterProducer(config, set)
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
 	return &kafkaLogsConsumer{
 		id:                config.ID(),
 		consumerGroup:     client,
@@ -298,6 +330,8 @@ func newLogsReceiver(config Config, set component.ReceiverCreateSettings, unmars
 		settings:          set,
 		autocommitEnabled: config.AutoCommit.Enable,
 		messageMarking:    config.MessageMarking,
+		deadLetter:        deadLetter,
+		headers:           newHeaderExtractor(config.HeaderExtraction),
 	}, nil
 }
 
@@ -322,6 +356,8 @@ func (c *kafkaLogsConsumer) Start(_ context.Context, host component.Host) error
 		obsrecv:           obsrecv,
 		autocommitEnabled: c.autocommitEnabled,
 		messageMarking:    c.messageMarking,
+		deadLetter:        c.deadLetter,
+		headers:           c.headers,
 	}
 	go func() {
 		if err := c.consumeLoop(ctx, logsConsumerGroup); err != nil {
@@ -350,7 +386,7 @@ func (c *kafkaLogsConsumer) consumeLoop(ctx context.Context, handler sarama.Cons
 
 func (c *kafkaLogsConsumer) Shutdown(context.Context) error {
 	c.cancelConsumeLoop()
-	return c.consumerGroup.Close()
+	return multierr.Combine(c.consumerGroup.Close(), c.deadLetter.close())
 }
 
 type tracesConsumerGroupHandler struct {
@@ -366,6 +402,8 @@ type tracesConsumerGroupHandler struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	deadLetter        *deadLetterProducer
+	headers           headerExtractor
 }
 
 type metricsConsumerGroupHandler struct {
@@ -381,6 +419,8 @@ type metricsConsumerGroupHandler struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	deadLetter        *deadLetterProducer
+	headers           headerExtractor
 }
 
 type logsConsumerGroupHandler struct {
@@ -396,6 +436,8 @@ type logsConsumerGroupHandler struct {
 
 	autocommitEnabled bool
 	messageMarking    MessageMarking
+	deadLetter        *deadLetterProducer
+	headers           headerExtractor
 }
 
 var _ sarama.ConsumerGroupHandler = (*tracesConsumerGroupHandler)(nil)
@@ -432,7 +474,11 @@ func (c *tracesConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSe
 		}
 
 		ctx := c.obsrecv.StartTracesOp(session.Context())
-		statsTags := []tag.Mutator{tag.Upsert(tagInstanceName, c.id.String())}
+		statsTags := []tag.Mutator{
+			tag.Upsert(tagInstanceName, c.id.String()),
+			tag.Upsert(tagTopic, message.Topic),
+			tag.Upsert(tagPartition, strconv.Itoa(int(message.Partition))),
+		}
 		_ = stats.RecordWithTags(ctx, statsTags,
 			statMessageCount.M(1),
 			statMessageOffset.M(message.Offset),
@@ -441,6 +487,9 @@ func (c *tracesConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSe
 		traces, err := c.unmarshaler.Unmarshal(message.Value)
 		if err != nil {
 			c.logger.Error("failed to unmarshal message", zap.Error(err))
+			if c.deadLetter.handle(session, message, consumererror.NewPermanent(err)) {
+				continue
+			}
 			if c.messageMarking.After && c.messageMarking.OnError {
 				session.MarkMessage(message, "")
 			}
@@ -448,9 +497,13 @@ func (c *tracesConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSe
 		}
 
 		spanCount := traces.SpanCount()
-		err = c.nextConsumer.ConsumeTraces(session.Context(), traces)
+		consumeCtx := c.headers.extractTraces(session.Context(), message, traces)
+		err = c.nextConsumer.ConsumeTraces(consumeCtx, traces)
 		c.obsrecv.EndTracesOp(ctx, c.unmarshaler.Encoding(), spanCount, err)
 		if err != nil {
+			if c.deadLetter.handle(session, message, err) {
+				continue
+			}
 			if c.messageMarking.After && c.messageMarking.OnError {
 				session.MarkMessage(message, "")
 			}
@@ -497,7 +550,11 @@ func (c *metricsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupS
 		}
 
 		ctx := c.obsrecv.StartMetricsOp(session.Context())
-		statsTags := []tag.Mutator{tag.Upsert(tagInstanceName, c.id.String())}
+		statsTags := []tag.Mutator{
+			tag.Upsert(tagInstanceName, c.id.String()),
+			tag.Upsert(tagTopic, message.Topic),
+			tag.Upsert(tagPartition, strconv.Itoa(int(message.Partition))),
+		}
 		_ = stats.RecordWithTags(ctx, statsTags,
 			statMessageCount.M(1),
 			statMessageOffset.M(message.Offset),
@@ -506,6 +563,9 @@ func (c *metricsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupS
 		metrics, err := c.unmarshaler.Unmarshal(message.Value)
 		if err != nil {
 			c.logger.Error("failed to unmarshal message", zap.Error(err))
+			if c.deadLetter.handle(session, message, consumererror.NewPermanent(err)) {
+				continue
+			}
 			if c.messageMarking.After && c.messageMarking.OnError {
 				session.MarkMessage(message, "")
 			}
@@ -513,9 +573,13 @@ func (c *metricsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupS
 		}
 
 		dataPointCount := metrics.DataPointCount()
-		err = c.nextConsumer.ConsumeMetrics(session.Context(), metrics)
+		consumeCtx := c.headers.extractMetrics(session.Context(), message, metrics)
+		err = c.nextConsumer.ConsumeMetrics(consumeCtx, metrics)
 		c.obsrecv.EndMetricsOp(ctx, c.unmarshaler.Encoding(), dataPointCount, err)
 		if err != nil {
+			if c.deadLetter.handle(session, message, err) {
+				continue
+			}
 			if c.messageMarking.After && c.messageMarking.OnError {
 				session.MarkMessage(message, "")
 			}
@@ -567,7 +631,11 @@ func (c *logsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSess
 		ctx := c.obsrecv.StartLogsOp(session.Context())
 		_ = stats.RecordWithTags(
 			ctx,
-			[]tag.Mutator{tag.Upsert(tagInstanceName, c.id.String())},
+			[]tag.Mutator{
+				tag.Upsert(tagInstanceName, c.id.String()),
+				tag.Upsert(tagTopic, message.Topic),
+				tag.Upsert(tagPartition, strconv.Itoa(int(message.Partition))),
+			},
 			statMessageCount.M(1),
 			statMessageOffset.M(message.Offset),
 			statMessageOffsetLag.M(claim.HighWaterMarkOffset()-message.Offset-1))
@@ -575,16 +643,23 @@ func (c *logsConsumerGroupHandler) ConsumeClaim(session sarama.ConsumerGroupSess
 		logs, err := c.unmarshaler.Unmarshal(message.Value)
 		if err != nil {
 			c.logger.Error("failed to unmarshal message", zap.Error(err))
+			if c.deadLetter.handle(session, message, consumererror.NewPermanent(err)) {
+				continue
+			}
 			if c.messageMarking.After && c.messageMarking.OnError {
 				session.MarkMessage(message, "")
 			}
 			return err
 		}
 
-		err = c.nextConsumer.ConsumeLogs(session.Context(), logs)
+		consumeCtx := c.headers.extractLogs(session.Context(), message, logs)
+		err = c.nextConsumer.ConsumeLogs(consumeCtx, logs)
 		// TODO
 		c.obsrecv.EndLogsOp(ctx, c.unmarshaler.Encoding(), logs.LogRecordCount(), err)
 		if err != nil {
+			if c.deadLetter.handle(session, message, err) {
+				continue
+			}
 			if c.messageMarking.After && c.messageMarking.OnError {
 				session.MarkMessage(message, "")
 			}
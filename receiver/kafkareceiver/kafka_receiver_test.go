@@ -22,11 +22,16 @@ import (
 	"time"
 
 	"github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/obsreport"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -623,6 +628,221 @@ func TestLogsConsumerGroupHandler_error_nextConsumer(t *testing.T) {
 	wg.Wait()
 }
 
+func TestTracesConsumerGroupHandler_deadLetter_unmarshal(t *testing.T) {
+	view.Unregister(MetricViews()...)
+	views := MetricViews()
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	producer := mocks.NewSyncProducer(t, sarama.NewConfig())
+	var produced *sarama.ProducerMessage
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		produced = msg
+		return nil
+	})
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverCreateSettings: componenttest.NewNopReceiverCreateSettings()})
+	require.NoError(t, err)
+	c := tracesConsumerGroupHandler{
+		unmarshaler:  newPdataTracesUnmarshaler(&ptrace.ProtoUnmarshaler{}, defaultEncoding),
+		logger:       zap.NewNop(),
+		ready:        make(chan bool),
+		nextConsumer: consumertest.NewNop(),
+		obsrecv:      obsrecv,
+		deadLetter: &deadLetterProducer{
+			producer: producer,
+			topic:    "dlq",
+			logger:   zap.NewNop(),
+		},
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	groupClaim := &testConsumerGroupClaim{
+		messageChan: make(chan *sarama.ConsumerMessage),
+	}
+	go func() {
+		assert.NoError(t, c.ConsumeClaim(testConsumerGroupSession{}, groupClaim))
+		wg.Done()
+	}()
+	groupClaim.messageChan <- &sarama.ConsumerMessage{
+		Topic:     testTopic,
+		Partition: testPartition,
+		Offset:    3,
+		Key:       []byte("key"),
+		Value:     []byte("!@#"),
+		Headers:   []*sarama.RecordHeader{{Key: []byte("tenant"), Value: []byte("acme")}},
+	}
+	close(groupClaim.messageChan)
+	wg.Wait()
+	require.NoError(t, producer.Close())
+
+	require.NotNil(t, produced)
+	assert.Equal(t, "dlq", produced.Topic)
+	key, err := produced.Key.Encode()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("key"), key)
+	value, err := produced.Value.Encode()
+	require.NoError(t, err)
+	assert.Equal(t, []byte("!@#"), value)
+	headers := map[string]string{}
+	for _, header := range produced.Headers {
+		headers[string(header.Key)] = string(header.Value)
+	}
+	assert.Equal(t, "acme", headers["tenant"])
+	assert.Equal(t, testTopic, headers[deadLetterTopicHeader])
+	assert.Equal(t, "5", headers[deadLetterPartitionHeader])
+	assert.Equal(t, "3", headers[deadLetterOffsetHeader])
+	assert.NotEmpty(t, headers[deadLetterErrorHeader])
+
+	viewData, err := view.RetrieveData(statDeadLetterCount.Name())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(viewData))
+	assert.Equal(t, float64(1), viewData[0].Data.(*view.SumData).Value)
+
+	viewData, err = view.RetrieveData(statMessageOffsetLag.Name())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(viewData))
+	assert.NotContains(t, viewData[0].Tags, tag.Tag{Key: tagPartition, Value: "5"})
+
+	viewData, err = view.RetrieveData("kafka_receiver_partition_offset_lag")
+	require.NoError(t, err)
+	require.Equal(t, 1, len(viewData))
+	assert.Contains(t, viewData[0].Tags, tag.Tag{Key: tagPartition, Value: "5"})
+	assert.Contains(t, viewData[0].Tags, tag.Tag{Key: tagTopic, Value: testTopic})
+}
+
+func TestLogsConsumerGroupHandler_deadLetter_nextConsumer(t *testing.T) {
+	tests := []struct {
+		name          string
+		consumerError error
+		sendError     error
+		expectedError string
+	}{
+		{
+			name:          "permanent",
+			consumerError: consumererror.NewPermanent(errors.New("bad data")),
+		},
+		{
+			name:          "retryable",
+			consumerError: errors.New("failed to consume"),
+			expectedError: "failed to consume",
+		},
+		{
+			name:          "dead_letter_failure",
+			consumerError: consumererror.NewPermanent(errors.New("bad data")),
+			sendError:     errors.New("failed to produce"),
+			expectedError: "Permanent error: bad data",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			producer := mocks.NewSyncProducer(t, sarama.NewConfig())
+			if consumererror.IsPermanent(tt.consumerError) {
+				if tt.sendError != nil {
+					producer.ExpectSendMessageAndFail(tt.sendError)
+				} else {
+					producer.ExpectSendMessageAndSucceed()
+				}
+			}
+			obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverCreateSettings: componenttest.NewNopReceiverCreateSettings()})
+			require.NoError(t, err)
+			c := logsConsumerGroupHandler{
+				unmarshaler:  newPdataLogsUnmarshaler(&plog.ProtoUnmarshaler{}, defaultEncoding),
+				logger:       zap.NewNop(),
+				ready:        make(chan bool),
+				nextConsumer: consumertest.NewErr(tt.consumerError),
+				obsrecv:      obsrecv,
+				deadLetter: &deadLetterProducer{
+					producer: producer,
+					topic:    "dlq",
+					logger:   zap.NewNop(),
+				},
+			}
+
+			wg := sync.WaitGroup{}
+			wg.Add(1)
+			groupClaim := &testConsumerGroupClaim{
+				messageChan: make(chan *sarama.ConsumerMessage),
+			}
+			go func() {
+				e := c.ConsumeClaim(testConsumerGroupSession{}, groupClaim)
+				if tt.expectedError != "" {
+					assert.EqualError(t, e, tt.expectedError)
+				} else {
+					assert.NoError(t, e)
+				}
+				wg.Done()
+			}()
+
+			bts, err := (&plog.ProtoMarshaler{}).MarshalLogs(testdata.GenerateLogsOneLogRecord())
+			require.NoError(t, err)
+			groupClaim.messageChan <- &sarama.ConsumerMessage{Value: bts}
+			close(groupClaim.messageChan)
+			wg.Wait()
+			require.NoError(t, producer.Close())
+		})
+	}
+}
+
+func TestMetricsConsumerGroupHandler_headerExtraction(t *testing.T) {
+	var (
+		receivedInfo    client.Info
+		receivedMetrics pmetric.Metrics
+	)
+	next, err := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		receivedInfo = client.FromContext(ctx)
+		receivedMetrics = md
+		return nil
+	})
+	require.NoError(t, err)
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{ReceiverCreateSettings: componenttest.NewNopReceiverCreateSettings()})
+	require.NoError(t, err)
+	c := metricsConsumerGroupHandler{
+		unmarshaler:  newPdataMetricsUnmarshaler(&pmetric.ProtoUnmarshaler{}, defaultEncoding),
+		logger:       zap.NewNop(),
+		ready:        make(chan bool),
+		nextConsumer: next,
+		obsrecv:      obsrecv,
+		headers: newHeaderExtractor(HeaderExtraction{
+			Headers:            []string{"tenant", "missing"},
+			ClientMetadata:     true,
+			ResourceAttributes: true,
+		}),
+	}
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	groupClaim := &testConsumerGroupClaim{
+		messageChan: make(chan *sarama.ConsumerMessage),
+	}
+	go func() {
+		assert.NoError(t, c.ConsumeClaim(testConsumerGroupSession{}, groupClaim))
+		wg.Done()
+	}()
+
+	bts, err := (&pmetric.ProtoMarshaler{}).MarshalMetrics(testdata.GenerateMetricsOneMetric())
+	require.NoError(t, err)
+	groupClaim.messageChan <- &sarama.ConsumerMessage{
+		Value: bts,
+		Headers: []*sarama.RecordHeader{
+			{Key: []byte("tenant"), Value: []byte("acme")},
+			{Key: []byte("other"), Value: []byte("value")},
+		},
+	}
+	close(groupClaim.messageChan)
+	wg.Wait()
+
+	assert.Equal(t, []string{"acme"}, receivedInfo.Metadata.Get("tenant"))
+	assert.Empty(t, receivedInfo.Metadata.Get("other"))
+	assert.Empty(t, receivedInfo.Metadata.Get("missing"))
+	attrs := receivedMetrics.ResourceMetrics().At(0).Resource().Attributes()
+	tenant, ok := attrs.Get("kafka.header.tenant")
+	require.True(t, ok)
+	assert.Equal(t, "acme", tenant.Str())
+	_, ok = attrs.Get("kafka.header.other")
+	assert.False(t, ok)
+}
+
 type testConsumerGroupClaim struct {
 	messageChan chan *sarama.ConsumerMessage
 }
@@ -22,6 +22,8 @@ import (
 
 var (
 	tagInstanceName, _ = tag.NewKey("name")
+	tagTopic, _        = tag.NewKey("topic")
+	tagPartition, _    = tag.NewKey("partition")
 
 	statMessageCount     = stats.Int64("kafka_receiver_messages", "Number of received messages", stats.UnitDimensionless)
 	statMessageOffset    = stats.Int64("kafka_receiver_current_offset", "Current message offset", stats.UnitDimensionless)
@@ -29,11 +31,14 @@ var (
 
 	statPartitionStart = stats.Int64("kafka_receiver_partition_start", "Number of started partitions", stats.UnitDimensionless)
 	statPartitionClose = stats.Int64("kafka_receiver_partition_close", "Number of finished partitions", stats.UnitDimensionless)
+
+	statDeadLetterCount = stats.Int64("kafka_receiver_dead_letter_messages", "Number of messages produced to the dead letter topic", stats.UnitDimensionless)
 )
 
 // MetricViews return metric views for Kafka receiver.
 func MetricViews() []*view.View {
 	tagKeys := []tag.Key{tagInstanceName}
+	partitionTagKeys := []tag.Key{tagInstanceName, tagTopic, tagPartition}
 
 	countMessages := &view.View{
 		Name:        statMessageCount.Name(),
@@ -59,6 +64,22 @@ func MetricViews() []*view.View {
 		Aggregation: view.LastValue(),
 	}
 
+	lastValuePartitionOffset := &view.View{
+		Name:        "kafka_receiver_partition_current_offset",
+		Measure:     statMessageOffset,
+		Description: "Current message offset of each partition",
+		TagKeys:     partitionTagKeys,
+		Aggregation: view.LastValue(),
+	}
+
+	lastValuePartitionOffsetLag := &view.View{
+		Name:        "kafka_receiver_partition_offset_lag",
+		Measure:     statMessageOffsetLag,
+		Description: "Current offset lag of each partition",
+		TagKeys:     partitionTagKeys,
+		Aggregation: view.LastValue(),
+	}
+
 	countPartitionStart := &view.View{
 		Name:        statPartitionStart.Name(),
 		Measure:     statPartitionStart,
@@ -75,11 +96,22 @@ func MetricViews() []*view.View {
 		Aggregation: view.Sum(),
 	}
 
+	countDeadLetter := &view.View{
+		Name:        statDeadLetterCount.Name(),
+		Measure:     statDeadLetterCount,
+		Description: statDeadLetterCount.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.Sum(),
+	}
+
 	return []*view.View{
 		countMessages,
 		lastValueOffset,
 		lastValueOffsetLag,
 		countPartitionStart,
 		countPartitionClose,
+		countDeadLetter,
+		lastValuePartitionOffset,
+		lastValuePartitionOffsetLag,
 	}
 }
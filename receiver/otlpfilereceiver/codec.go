@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpfilereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlpfilereceiver"
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	// the format of encoded telemetry data
+	formatTypeJSON  = "json"
+	formatTypeProto = "proto"
+
+	// the type of compression codec
+	compressionZSTD   = "zstd"
+	compressionGZIP   = "gzip"
+	compressionSnappy = "snappy"
+
+	// the size of the length preceding each encoded object
+	lengthPrefixSize = 4
+
+	// the framing of encoded objects: each object is preceded by a record header
+	framingRecord = "record"
+)
+
+// The types of the records, written in the record headers by the file exporter.
+const (
+	recordTypeTraces byte = iota + 1
+	recordTypeMetrics
+	recordTypeLogs
+
+	// a record header is the type of the record followed by its length, as a
+	// 4 bytes big endian unsigned integer
+	recordHeaderSize = 1 + lengthPrefixSize
+)
+
+var tracesUnmarshalers = map[string]ptrace.Unmarshaler{
+	formatTypeJSON:  &ptrace.JSONUnmarshaler{},
+	formatTypeProto: &ptrace.ProtoUnmarshaler{},
+}
+var metricsUnmarshalers = map[string]pmetric.Unmarshaler{
+	formatTypeJSON:  &pmetric.JSONUnmarshaler{},
+	formatTypeProto: &pmetric.ProtoUnmarshaler{},
+}
+var logsUnmarshalers = map[string]plog.Unmarshaler{
+	formatTypeJSON:  &plog.JSONUnmarshaler{},
+	formatTypeProto: &plog.ProtoUnmarshaler{},
+}
+
+// decompressFunc defines how to decompress an encoded object.
+type decompressFunc func(src []byte) ([]byte, error)
+
+var zstdDecoder, _ = zstd.NewReader(nil)
+
+var decompressors = map[string]decompressFunc{
+	"":                noneDecompress,
+	compressionZSTD:   zstdDecompress,
+	compressionGZIP:   gzipDecompress,
+	compressionSnappy: snappyDecompress,
+}
+
+// noneDecompress returns src
+func noneDecompress(src []byte) ([]byte, error) {
+	return src, nil
+}
+
+// zstdDecompress decompresses a buffer with zstd
+func zstdDecompress(src []byte) ([]byte, error) {
+	return zstdDecoder.DecodeAll(src, nil)
+}
+
+// gzipDecompress decompresses a buffer with gzip
+func gzipDecompress(src []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(src))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	return io.ReadAll(zr)
+}
+
+// snappyDecompress decompresses a buffer in the snappy block format
+func snappyDecompress(src []byte) ([]byte, error) {
+	return snappy.Decode(nil, src)
+}
+
+// buildSplitFunc returns how the file exporter delimited the encoded objects:
+// framed records are preceded by a record header, otherwise uncompressed JSON is
+// written one object per line, and everything else is preceded by its length as
+// a 4 bytes big endian unsigned integer.
+func buildSplitFunc(cfg *Config) bufio.SplitFunc {
+	if cfg.Framing == framingRecord {
+		return splitRecords
+	}
+	if cfg.FormatType == formatTypeJSON && cfg.Compression == "" {
+		return splitLines
+	}
+	return splitLengthPrefixed
+}
+
+// splitLines splits newline terminated objects. An unterminated last line is
+// still being written and is left for the next poll.
+func splitLines(data []byte, _ bool) (int, []byte, error) {
+	i := bytes.IndexByte(data, '\n')
+	switch {
+	case i < 0:
+		return 0, nil, nil
+	case i == 0:
+		// Skip empty lines.
+		return 1, nil, nil
+	default:
+		return i + 1, data[:i], nil
+	}
+}
+
+// splitLengthPrefixed splits length prefixed objects. An incomplete last object
+// is still being written and is left for the next poll.
+func splitLengthPrefixed(data []byte, _ bool) (int, []byte, error) {
+	if len(data) < lengthPrefixSize {
+		return 0, nil, nil
+	}
+	end := lengthPrefixSize + int(binary.BigEndian.Uint32(data))
+	if len(data) < end {
+		return 0, nil, nil
+	}
+	return end, data[lengthPrefixSize:end], nil
+}
+
+// splitRecords splits records preceded by a record header, which is kept in the
+// token to tell the signal of the record. An incomplete last record is still being
+// written and is left for the next poll.
+func splitRecords(data []byte, _ bool) (int, []byte, error) {
+	if len(data) < recordHeaderSize {
+		return 0, nil, nil
+	}
+	end := recordHeaderSize + int(binary.BigEndian.Uint32(data[1:recordHeaderSize]))
+	if len(data) < end {
+		return 0, nil, nil
+	}
+	return end, data[:end], nil
+}
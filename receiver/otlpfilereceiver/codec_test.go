@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpfilereceiver
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scanAll(t *testing.T, split bufio.SplitFunc, data []byte) ([]string, int) {
+	var tokens []string
+	consumed := 0
+	for {
+		advance, token, err := split(data[consumed:], true)
+		require.NoError(t, err)
+		if advance == 0 {
+			return tokens, consumed
+		}
+		consumed += advance
+		if token != nil {
+			tokens = append(tokens, string(token))
+		}
+	}
+}
+
+func appendLengthPrefixed(data []byte, msg []byte) []byte {
+	size := make([]byte, lengthPrefixSize)
+	binary.BigEndian.PutUint32(size, uint32(len(msg)))
+	return append(append(data, size...), msg...)
+}
+
+func TestSplitLines(t *testing.T) {
+	tokens, consumed := scanAll(t, splitLines, []byte("{\"a\":1}\n\n{\"b\":2}\n{\"c\""))
+	assert.Equal(t, []string{`{"a":1}`, `{"b":2}`}, tokens)
+	// The incomplete last line is left for the next poll.
+	assert.Equal(t, 17, consumed)
+}
+
+func TestSplitLengthPrefixed(t *testing.T) {
+	var data []byte
+	for _, msg := range []string{"first", "", "second"} {
+		data = appendLengthPrefixed(data, []byte(msg))
+	}
+	complete := len(data)
+	data = appendLengthPrefixed(data, []byte("truncated"))
+	data = data[:len(data)-4]
+
+	tokens, consumed := scanAll(t, splitLengthPrefixed, data)
+	assert.Equal(t, []string{"first", "", "second"}, tokens)
+	assert.Equal(t, complete, consumed)
+
+	tokens, consumed = scanAll(t, splitLengthPrefixed, []byte{0, 0})
+	assert.Empty(t, tokens)
+	assert.Zero(t, consumed)
+}
+
+func TestSplitRecords(t *testing.T) {
+	var data []byte
+	for i, msg := range []string{"traces", "", "logs"} {
+		data = append(data, recordTypeTraces+byte(i))
+		data = appendLengthPrefixed(data, []byte(msg))
+	}
+	complete := len(data)
+	data = append(data, recordTypeMetrics)
+	data = appendLengthPrefixed(data, []byte("truncated"))
+	data = data[:len(data)-4]
+
+	tokens, consumed := scanAll(t, splitRecords, data)
+	assert.Equal(t, []string{"\x01\x00\x00\x00\x06traces", "\x02\x00\x00\x00\x00", "\x03\x00\x00\x00\x04logs"}, tokens)
+	assert.Equal(t, complete, consumed)
+
+	tokens, consumed = scanAll(t, splitRecords, []byte{recordTypeTraces, 0, 0, 0})
+	assert.Empty(t, tokens)
+	assert.Zero(t, consumed)
+}
+
+func TestBuildSplitFunc(t *testing.T) {
+	lines := []byte("a\nb\n")
+	for _, tt := range []struct {
+		cfg   Config
+		lines bool
+	}{
+		{cfg: Config{FormatType: formatTypeJSON}, lines: true},
+		{cfg: Config{FormatType: formatTypeJSON, Compression: compressionGZIP}},
+		{cfg: Config{FormatType: formatTypeProto}},
+	} {
+		tokens, _ := scanAll(t, buildSplitFunc(&tt.cfg), lines)
+		assert.Equal(t, tt.lines, len(tokens) == 2, tt.cfg)
+	}
+}
+
+func TestDecompressErrors(t *testing.T) {
+	garbage := bytes.Repeat([]byte{0xff}, 16)
+	for _, compression := range []string{compressionZSTD, compressionGZIP, compressionSnappy} {
+		_, err := decompressors[compression](garbage)
+		assert.Error(t, err, compression)
+	}
+}
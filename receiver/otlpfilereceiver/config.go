@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpfilereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlpfilereceiver"
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+)
+
+// Config defines configuration for the OTLP file receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+	fileconsumer.Config     `mapstructure:",squash"`
+
+	// StorageID is the ID of the storage extension used to persist the file offsets.
+	StorageID *component.ID `mapstructure:"storage"`
+
+	// FormatType is the format the files were written with by the file exporter.
+	// Options:
+	// - json[default]:  OTLP json bytes.
+	// - proto:  OTLP binary protobuf bytes.
+	FormatType string `mapstructure:"format"`
+
+	// Compression is the compression the files were written with by the file exporter.
+	// Supported compression algorithms: `zstd`, `gzip` and `snappy`.
+	Compression string `mapstructure:"compression"`
+
+	// Framing is the framing the files were written with by the file exporter,
+	// either the default one or `record`. Files written by several pipelines
+	// must be framed with `record`, so that the signal of each object is known.
+	Framing string `mapstructure:"framing"`
+}
+
+var _ component.ReceiverConfig = (*Config)(nil)
+
+// Validate checks if the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	if len(cfg.Include) == 0 {
+		return fmt.Errorf("'include' must not be empty")
+	}
+	if _, ok := tracesUnmarshalers[cfg.FormatType]; !ok {
+		return fmt.Errorf("format %q is not supported", cfg.FormatType)
+	}
+	if _, ok := decompressors[cfg.Compression]; !ok {
+		return fmt.Errorf("compression %q is not supported", cfg.Compression)
+	}
+	if cfg.Framing != "" && cfg.Framing != framingRecord {
+		return fmt.Errorf("framing %q is not supported", cfg.Framing)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpfilereceiver
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := createDefaultConfig()
+	assert.NotNil(t, cfg, "failed to create default config")
+	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
+}
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	storageID := component.NewID("file_storage")
+	tests := []struct {
+		id           component.ID
+		expected     func(*Config)
+		errorMessage string
+	}{
+		{
+			id: component.NewID(typeStr),
+			expected: func(cfg *Config) {
+				cfg.Include = []string{"/var/log/otel/*.json"}
+			},
+		},
+		{
+			id: component.NewIDWithName(typeStr, "proto"),
+			expected: func(cfg *Config) {
+				cfg.Include = []string{"/var/log/otel/*.pb"}
+				cfg.Exclude = []string{"/var/log/otel/example.pb"}
+				cfg.StorageID = &storageID
+				cfg.FormatType = formatTypeProto
+				cfg.Compression = compressionZSTD
+				cfg.Framing = framingRecord
+				cfg.PollInterval = time.Second
+			},
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "format_error"),
+			errorMessage: `format "text" is not supported`,
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "compression_error"),
+			errorMessage: `compression "lz4" is not supported`,
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "framing_error"),
+			errorMessage: `framing "lines" is not supported`,
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "include_error"),
+			errorMessage: "'include' must not be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalReceiverConfig(sub, cfg))
+
+			if tt.expected == nil {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.errorMessage)
+				return
+			}
+
+			expected := createDefaultConfig().(*Config)
+			tt.expected(expected)
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, expected, cfg)
+		})
+	}
+}
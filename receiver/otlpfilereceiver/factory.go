@@ -0,0 +1,161 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpfilereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlpfilereceiver"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/obsreport"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+const (
+	// The value of "type" key in configuration.
+	typeStr = "otlpfile"
+	// The stability level of the receiver.
+	stability = component.StabilityLevelDevelopment
+	transport = "file"
+
+	// the file exporter writes whole batches as a single object
+	defaultMaxLogSize = 64 * 1024 * 1024
+)
+
+// NewFactory creates a factory for the OTLP file receiver.
+func NewFactory() component.ReceiverFactory {
+	return component.NewReceiverFactory(
+		typeStr,
+		createDefaultConfig,
+		component.WithTracesReceiver(createTracesReceiver, stability),
+		component.WithMetricsReceiver(createMetricsReceiver, stability),
+		component.WithLogsReceiver(createLogsReceiver, stability))
+}
+
+func createDefaultConfig() component.ReceiverConfig {
+	fileConfig := fileconsumer.NewConfig()
+	fileConfig.StartAt = "beginning"
+	fileConfig.MaxLogSize = helper.ByteSize(defaultMaxLogSize)
+	return &Config{
+		ReceiverSettings: config.NewReceiverSettings(component.NewID(typeStr)),
+		Config:           *fileConfig,
+		FormatType:       formatTypeJSON,
+	}
+}
+
+func createTracesReceiver(
+	_ context.Context,
+	set component.ReceiverCreateSettings,
+	cfg component.ReceiverConfig,
+	nextConsumer consumer.Traces,
+) (component.TracesReceiver, error) {
+	conf := cfg.(*Config)
+	obsrecv, err := newObsReceiver(conf, set)
+	if err != nil {
+		return nil, err
+	}
+	unmarshaler := tracesUnmarshalers[conf.FormatType]
+	return newFileReceiver(conf, set, component.DataTypeTraces, func(ctx context.Context, buf []byte) error {
+		ctx = obsrecv.StartTracesOp(ctx)
+		td, err := unmarshaler.UnmarshalTraces(buf)
+		switch {
+		case err != nil:
+			obsrecv.EndTracesOp(ctx, conf.FormatType, 0, err)
+			return consumererror.NewPermanent(err)
+		case td.ResourceSpans().Len() == 0:
+			// Nothing to consume, e.g. an object of another signal in a JSON file without record framing.
+			obsrecv.EndTracesOp(ctx, conf.FormatType, 0, nil)
+			return nil
+		default:
+			err = nextConsumer.ConsumeTraces(ctx, td)
+			obsrecv.EndTracesOp(ctx, conf.FormatType, td.SpanCount(), err)
+			return err
+		}
+	})
+}
+
+func createMetricsReceiver(
+	_ context.Context,
+	set component.ReceiverCreateSettings,
+	cfg component.ReceiverConfig,
+	nextConsumer consumer.Metrics,
+) (component.MetricsReceiver, error) {
+	conf := cfg.(*Config)
+	obsrecv, err := newObsReceiver(conf, set)
+	if err != nil {
+		return nil, err
+	}
+	unmarshaler := metricsUnmarshalers[conf.FormatType]
+	return newFileReceiver(conf, set, component.DataTypeMetrics, func(ctx context.Context, buf []byte) error {
+		ctx = obsrecv.StartMetricsOp(ctx)
+		md, err := unmarshaler.UnmarshalMetrics(buf)
+		switch {
+		case err != nil:
+			obsrecv.EndMetricsOp(ctx, conf.FormatType, 0, err)
+			return consumererror.NewPermanent(err)
+		case md.ResourceMetrics().Len() == 0:
+			// Nothing to consume, e.g. an object of another signal in a JSON file without record framing.
+			obsrecv.EndMetricsOp(ctx, conf.FormatType, 0, nil)
+			return nil
+		default:
+			err = nextConsumer.ConsumeMetrics(ctx, md)
+			obsrecv.EndMetricsOp(ctx, conf.FormatType, md.DataPointCount(), err)
+			return err
+		}
+	})
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	set component.ReceiverCreateSettings,
+	cfg component.ReceiverConfig,
+	nextConsumer consumer.Logs,
+) (component.LogsReceiver, error) {
+	conf := cfg.(*Config)
+	obsrecv, err := newObsReceiver(conf, set)
+	if err != nil {
+		return nil, err
+	}
+	unmarshaler := logsUnmarshalers[conf.FormatType]
+	return newFileReceiver(conf, set, component.DataTypeLogs, func(ctx context.Context, buf []byte) error {
+		ctx = obsrecv.StartLogsOp(ctx)
+		ld, err := unmarshaler.UnmarshalLogs(buf)
+		switch {
+		case err != nil:
+			obsrecv.EndLogsOp(ctx, conf.FormatType, 0, err)
+			return consumererror.NewPermanent(err)
+		case ld.ResourceLogs().Len() == 0:
+			// Nothing to consume, e.g. an object of another signal in a JSON file without record framing.
+			obsrecv.EndLogsOp(ctx, conf.FormatType, 0, nil)
+			return nil
+		default:
+			err = nextConsumer.ConsumeLogs(ctx, ld)
+			obsrecv.EndLogsOp(ctx, conf.FormatType, ld.LogRecordCount(), err)
+			return err
+		}
+	})
+}
+
+func newObsReceiver(cfg *Config, set component.ReceiverCreateSettings) (*obsreport.Receiver, error) {
+	return obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             cfg.ID(),
+		Transport:              transport,
+		ReceiverCreateSettings: set,
+	})
+}
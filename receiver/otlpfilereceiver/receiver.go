@@ -0,0 +1,198 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpfilereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlpfilereceiver"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.uber.org/atomic"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/operator/helper"
+)
+
+// retryInterval is the time to wait before consuming again an object whose
+// consumption failed.
+const retryInterval = time.Second
+
+// consumeFunc consumes a decompressed encoded object. The consumption is retried
+// until it succeeds, unless the error is permanent.
+type consumeFunc func(ctx context.Context, buf []byte) error
+
+// recordTypes are the record types of the signals, when the records are framed.
+var recordTypes = map[component.DataType]byte{
+	component.DataTypeTraces:  recordTypeTraces,
+	component.DataTypeMetrics: recordTypeMetrics,
+	component.DataTypeLogs:    recordTypeLogs,
+}
+
+type fileReceiver struct {
+	cfg           *Config
+	logger        *zap.Logger
+	dataType      component.DataType
+	recordType    byte
+	decompress    decompressFunc
+	consume       consumeFunc
+	retryInterval time.Duration
+	input         *fileconsumer.Manager
+
+	storageClient storage.Client
+	// aborted is set when an object wasn't consumed because the receiver was
+	// stopped, from then on the offsets are no longer stored.
+	aborted *atomic.Bool
+}
+
+func newFileReceiver(cfg *Config, set component.ReceiverCreateSettings, dataType component.DataType, consume consumeFunc) (*fileReceiver, error) {
+	r := &fileReceiver{
+		cfg:           cfg,
+		logger:        set.Logger,
+		dataType:      dataType,
+		recordType:    recordTypes[dataType],
+		decompress:    decompressors[cfg.Compression],
+		consume:       consume,
+		retryInterval: retryInterval,
+		aborted:       atomic.NewBool(false),
+	}
+
+	// The objects are binary and delimited by the file exporter, they must not be
+	// decoded as text nor flushed before being complete.
+	fileConfig := cfg.Config
+	fileConfig.Splitter = helper.SplitterConfig{
+		EncodingConfig: helper.EncodingConfig{Encoding: "nop"},
+	}
+	input, err := fileConfig.BuildWithSplitFunc(set.Logger.Sugar(), r.emit, buildSplitFunc(cfg))
+	if err != nil {
+		return nil, err
+	}
+	r.input = input
+	return r, nil
+}
+
+// emit consumes an object, and only returns once it is consumed so that the read
+// offset doesn't move past objects that failed to be consumed. The objects which
+// can't be decompressed or decoded are dropped, and the records of the other
+// signals are skipped.
+func (r *fileReceiver) emit(ctx context.Context, attrs *fileconsumer.FileAttributes, token []byte) {
+	if r.cfg.Framing == framingRecord {
+		switch recordType := token[0]; recordType {
+		case r.recordType:
+			token = token[recordHeaderSize:]
+		case recordTypeTraces, recordTypeMetrics, recordTypeLogs:
+			return
+		default:
+			r.logger.Error("Unknown record type, dropping it", zap.String("file", attrs.Path), zap.Uint8("record_type", recordType))
+			return
+		}
+	}
+
+	buf, err := r.decompress(token)
+	if err != nil {
+		r.logger.Error("Failed to decompress telemetry", zap.String("file", attrs.Path), zap.Error(err))
+		return
+	}
+	for {
+		err = r.consume(ctx, buf)
+		if err == nil {
+			return
+		}
+		if consumererror.IsPermanent(err) {
+			r.logger.Error("Failed to consume telemetry, dropping it", zap.String("file", attrs.Path), zap.Error(err))
+			return
+		}
+		r.logger.Warn("Failed to consume telemetry, retrying", zap.String("file", attrs.Path), zap.Error(err))
+		select {
+		case <-ctx.Done():
+			// The file consumer moves past the object once emitted, so the offsets
+			// of this poll must not be stored for the object to be read again.
+			r.aborted.Store(true)
+			r.logger.Warn("Receiver stopped before telemetry was consumed, it will be read again on the next start",
+				zap.String("file", attrs.Path))
+			return
+		case <-time.After(r.retryInterval):
+		}
+	}
+}
+
+func (r *fileReceiver) Start(ctx context.Context, host component.Host) error {
+	client, err := getStorageClient(ctx, host, r.cfg.StorageID, r.cfg.ID(), r.dataType)
+	if err != nil {
+		return err
+	}
+	r.storageClient = client
+	r.aborted.Store(false)
+	return r.input.Start(&offsetsPersister{Persister: client, aborted: r.aborted})
+}
+
+func (r *fileReceiver) Shutdown(ctx context.Context) error {
+	var errs error
+	if r.input != nil {
+		errs = multierr.Append(errs, r.input.Stop())
+	}
+	if r.storageClient != nil {
+		errs = multierr.Append(errs, r.storageClient.Close(ctx))
+	}
+	return errs
+}
+
+// getStorageClient returns a client of the storage extension, which is named after
+// the signal so that the pipelines of a receiver track their offsets separately.
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, componentID component.ID, dataType component.DataType) (storage.Client, error) {
+	if storageID == nil {
+		return storage.NewNopClient(), nil
+	}
+
+	extension, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindReceiver, componentID, string(dataType))
+}
+
+// offsetsPersister stores the offsets of the file consumer, until an object
+// couldn't be consumed before the receiver was stopped. The offsets of the
+// previous poll are then kept, so that the objects of the last poll are read
+// again on the next start rather than lost.
+type offsetsPersister struct {
+	operator.Persister
+	aborted *atomic.Bool
+}
+
+func (p *offsetsPersister) Set(ctx context.Context, key string, value []byte) error {
+	if p.aborted.Load() {
+		return nil
+	}
+	return p.Persister.Set(ctx, key, value)
+}
+
+func (p *offsetsPersister) Delete(ctx context.Context, key string) error {
+	if p.aborted.Load() {
+		return nil
+	}
+	return p.Persister.Delete(ctx, key)
+}
@@ -0,0 +1,340 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpfilereceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+)
+
+var roundTripCases = []struct {
+	format      string
+	compression string
+}{
+	{format: formatTypeJSON},
+	{format: formatTypeJSON, compression: compressionZSTD},
+	{format: formatTypeJSON, compression: compressionGZIP},
+	{format: formatTypeJSON, compression: compressionSnappy},
+	{format: formatTypeProto},
+	{format: formatTypeProto, compression: compressionZSTD},
+	{format: formatTypeProto, compression: compressionGZIP},
+	{format: formatTypeProto, compression: compressionSnappy},
+}
+
+func newExporterConfig(t *testing.T, path string, format string, compression string) *fileexporter.Config {
+	return newFramedExporterConfig(t, path, format, compression, "")
+}
+
+func newFramedExporterConfig(t *testing.T, path string, format string, compression string, framing string) *fileexporter.Config {
+	cfg := fileexporter.NewFactory().CreateDefaultConfig().(*fileexporter.Config)
+	cfg.Path = path
+	cfg.Rotation = nil
+	cfg.FormatType = format
+	cfg.Compression = compression
+	cfg.Framing = framing
+	require.NoError(t, cfg.Validate())
+	return cfg
+}
+
+func newReceiverConfig(dir string, format string, compression string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Include = []string{filepath.Join(dir, "*")}
+	cfg.PollInterval = 10 * time.Millisecond
+	cfg.FormatType = format
+	cfg.Compression = compression
+	return cfg
+}
+
+func TestTracesRoundTrip(t *testing.T) {
+	for _, tt := range roundTripCases {
+		t.Run(fmt.Sprintf("%s_%s", tt.format, tt.compression), func(t *testing.T) {
+			dir := t.TempDir()
+			exp, err := fileexporter.NewFactory().CreateTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(),
+				newExporterConfig(t, filepath.Join(dir, "traces"), tt.format, tt.compression))
+			require.NoError(t, err)
+			require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+			td := testdata.GenerateTracesTwoSpansSameResource()
+			require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+			require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+			require.NoError(t, exp.Shutdown(context.Background()))
+
+			sink := new(consumertest.TracesSink)
+			rcv, err := NewFactory().CreateTracesReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(),
+				newReceiverConfig(dir, tt.format, tt.compression), sink)
+			require.NoError(t, err)
+			require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+			require.Eventually(t, func() bool { return len(sink.AllTraces()) == 2 }, 5*time.Second, 10*time.Millisecond)
+			require.NoError(t, rcv.Shutdown(context.Background()))
+
+			for _, got := range sink.AllTraces() {
+				assert.Equal(t, td, got)
+			}
+		})
+	}
+}
+
+func TestMetricsRoundTrip(t *testing.T) {
+	for _, tt := range roundTripCases {
+		t.Run(fmt.Sprintf("%s_%s", tt.format, tt.compression), func(t *testing.T) {
+			dir := t.TempDir()
+			exp, err := fileexporter.NewFactory().CreateMetricsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(),
+				newExporterConfig(t, filepath.Join(dir, "metrics"), tt.format, tt.compression))
+			require.NoError(t, err)
+			require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+			md := testdata.GenerateMetricsManyMetricsSameResource(5)
+			require.NoError(t, exp.ConsumeMetrics(context.Background(), md))
+			require.NoError(t, exp.Shutdown(context.Background()))
+
+			sink := new(consumertest.MetricsSink)
+			rcv, err := NewFactory().CreateMetricsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(),
+				newReceiverConfig(dir, tt.format, tt.compression), sink)
+			require.NoError(t, err)
+			require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+			require.Eventually(t, func() bool { return len(sink.AllMetrics()) == 1 }, 5*time.Second, 10*time.Millisecond)
+			require.NoError(t, rcv.Shutdown(context.Background()))
+
+			assert.Equal(t, md, sink.AllMetrics()[0])
+		})
+	}
+}
+
+func TestLogsRoundTrip(t *testing.T) {
+	for _, tt := range roundTripCases {
+		t.Run(fmt.Sprintf("%s_%s", tt.format, tt.compression), func(t *testing.T) {
+			dir := t.TempDir()
+			exp, err := fileexporter.NewFactory().CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(),
+				newExporterConfig(t, filepath.Join(dir, "logs"), tt.format, tt.compression))
+			require.NoError(t, err)
+			require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+			ld := testdata.GenerateLogsManyLogRecordsSameResource(5)
+			require.NoError(t, exp.ConsumeLogs(context.Background(), ld))
+			require.NoError(t, exp.Shutdown(context.Background()))
+
+			sink := new(consumertest.LogsSink)
+			rcv, err := NewFactory().CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(),
+				newReceiverConfig(dir, tt.format, tt.compression), sink)
+			require.NoError(t, err)
+			require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+			require.Eventually(t, func() bool { return len(sink.AllLogs()) == 1 }, 5*time.Second, 10*time.Millisecond)
+			require.NoError(t, rcv.Shutdown(context.Background()))
+
+			assert.Equal(t, ld, sink.AllLogs()[0])
+		})
+	}
+}
+
+func TestOffsetsAreStored(t *testing.T) {
+	dir := t.TempDir()
+	storageDir := t.TempDir()
+	storageID := component.NewID("file_storage")
+	newHost := func() component.Host {
+		return storagetest.NewStorageHost().
+			WithExtension(storageID, storagetest.NewFileBackedStorageExtension("file_storage", storageDir))
+	}
+
+	exp, err := fileexporter.NewFactory().CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(),
+		newExporterConfig(t, filepath.Join(dir, "logs"), formatTypeProto, compressionGZIP))
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { require.NoError(t, exp.Shutdown(context.Background())) }()
+
+	cfg := newReceiverConfig(dir, formatTypeProto, compressionGZIP)
+	cfg.StorageID = &storageID
+	ld := testdata.GenerateLogsOneLogRecord()
+
+	require.NoError(t, exp.ConsumeLogs(context.Background(), ld))
+	sink := new(consumertest.LogsSink)
+	rcv, err := NewFactory().CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcv.Start(context.Background(), newHost()))
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, rcv.Shutdown(context.Background()))
+
+	// After a restart, only the objects written in the meantime are read.
+	require.NoError(t, exp.ConsumeLogs(context.Background(), testdata.GenerateLogsTwoLogRecordsSameResource()))
+	sink.Reset()
+	rcv, err = NewFactory().CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcv.Start(context.Background(), newHost()))
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 2 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, rcv.Shutdown(context.Background()))
+	assert.Len(t, sink.AllLogs(), 1)
+}
+
+func TestSharedFileSkipsOtherSignals(t *testing.T) {
+	for _, tt := range roundTripCases {
+		t.Run(fmt.Sprintf("%s_%s", tt.format, tt.compression), func(t *testing.T) {
+			dir := t.TempDir()
+			expCfg := newFramedExporterConfig(t, filepath.Join(dir, "all"), tt.format, tt.compression, framingRecord)
+			factory := fileexporter.NewFactory()
+			traces, err := factory.CreateTracesExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), expCfg)
+			require.NoError(t, err)
+			metrics, err := factory.CreateMetricsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), expCfg)
+			require.NoError(t, err)
+			logs, err := factory.CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), expCfg)
+			require.NoError(t, err)
+			require.NoError(t, traces.Start(context.Background(), componenttest.NewNopHost()))
+			require.NoError(t, traces.ConsumeTraces(context.Background(), testdata.GenerateTracesOneSpan()))
+			require.NoError(t, metrics.ConsumeMetrics(context.Background(), testdata.GenerateMetricsOneMetric()))
+			require.NoError(t, logs.ConsumeLogs(context.Background(), testdata.GenerateLogsOneLogRecord()))
+			require.NoError(t, traces.Shutdown(context.Background()))
+
+			// The records of the other signals are skipped, rather than dropped as invalid.
+			core, observed := observer.New(zap.ErrorLevel)
+			set := componenttest.NewNopReceiverCreateSettings()
+			set.Logger = zap.New(core)
+			rcvCfg := newReceiverConfig(dir, tt.format, tt.compression)
+			rcvCfg.Framing = framingRecord
+			tracesSink := new(consumertest.TracesSink)
+			tracesRcv, err := NewFactory().CreateTracesReceiver(context.Background(), set, rcvCfg, tracesSink)
+			require.NoError(t, err)
+			metricsSink := new(consumertest.MetricsSink)
+			metricsRcv, err := NewFactory().CreateMetricsReceiver(context.Background(), set, rcvCfg, metricsSink)
+			require.NoError(t, err)
+			logsSink := new(consumertest.LogsSink)
+			logsRcv, err := NewFactory().CreateLogsReceiver(context.Background(), set, rcvCfg, logsSink)
+			require.NoError(t, err)
+
+			for _, rcv := range []component.Receiver{tracesRcv, metricsRcv, logsRcv} {
+				require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+			}
+			require.Eventually(t, func() bool {
+				return tracesSink.SpanCount() == 1 && metricsSink.DataPointCount() == 2 && logsSink.LogRecordCount() == 1
+			}, 5*time.Second, 10*time.Millisecond)
+			for _, rcv := range []component.Receiver{tracesRcv, metricsRcv, logsRcv} {
+				require.NoError(t, rcv.Shutdown(context.Background()))
+			}
+			assert.Len(t, tracesSink.AllTraces(), 1)
+			assert.Len(t, metricsSink.AllMetrics(), 1)
+			assert.Len(t, logsSink.AllLogs(), 1)
+			assert.Zero(t, observed.Len())
+		})
+	}
+}
+
+// failingLogsSink fails to consume the first logs it receives.
+type failingLogsSink struct {
+	consumertest.LogsSink
+	mu     sync.Mutex
+	failed bool
+}
+
+func (s *failingLogsSink) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.failed {
+		s.failed = true
+		return errors.New("temporary failure")
+	}
+	return s.LogsSink.ConsumeLogs(ctx, ld)
+}
+
+func TestFailedConsumeIsRetried(t *testing.T) {
+	dir := t.TempDir()
+	exp, err := fileexporter.NewFactory().CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(),
+		newExporterConfig(t, filepath.Join(dir, "logs"), formatTypeProto, ""))
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exp.ConsumeLogs(context.Background(), testdata.GenerateLogsOneLogRecord()))
+	require.NoError(t, exp.ConsumeLogs(context.Background(), testdata.GenerateLogsTwoLogRecordsSameResource()))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	sink := new(failingLogsSink)
+	rcv, err := NewFactory().CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(),
+		newReceiverConfig(dir, formatTypeProto, ""), sink)
+	require.NoError(t, err)
+	rcv.(*fileReceiver).retryInterval = 10 * time.Millisecond
+	require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 3 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, rcv.Shutdown(context.Background()))
+
+	// The first object is consumed once its consumption succeeds, before the next one.
+	require.Len(t, sink.AllLogs(), 2)
+	assert.Equal(t, 1, sink.AllLogs()[0].LogRecordCount())
+	assert.Equal(t, 2, sink.AllLogs()[1].LogRecordCount())
+}
+
+func TestStoppedConsumeIsReadAgain(t *testing.T) {
+	dir := t.TempDir()
+	storageDir := t.TempDir()
+	storageID := component.NewID("file_storage")
+	newHost := func() component.Host {
+		return storagetest.NewStorageHost().
+			WithExtension(storageID, storagetest.NewFileBackedStorageExtension("file_storage", storageDir))
+	}
+
+	exp, err := fileexporter.NewFactory().CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(),
+		newExporterConfig(t, filepath.Join(dir, "logs"), formatTypeProto, ""))
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exp.ConsumeLogs(context.Background(), testdata.GenerateLogsOneLogRecord()))
+	require.NoError(t, exp.ConsumeLogs(context.Background(), testdata.GenerateLogsTwoLogRecordsSameResource()))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	cfg := newReceiverConfig(dir, formatTypeProto, "")
+	cfg.StorageID = &storageID
+
+	// The second object is never consumed before the receiver is stopped.
+	failing := new(consumertest.LogsSink)
+	rcv, err := NewFactory().CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg,
+		consumerFunc(func(ctx context.Context, ld plog.Logs) error {
+			if ld.LogRecordCount() == 2 {
+				return errors.New("temporary failure")
+			}
+			return failing.ConsumeLogs(ctx, ld)
+		}))
+	require.NoError(t, err)
+	rcv.(*fileReceiver).retryInterval = 10 * time.Millisecond
+	require.NoError(t, rcv.Start(context.Background(), newHost()))
+	require.Eventually(t, func() bool { return failing.LogRecordCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, rcv.Shutdown(context.Background()))
+
+	// The objects of the last poll are read again after a restart.
+	sink := new(consumertest.LogsSink)
+	rcv, err = NewFactory().CreateLogsReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcv.Start(context.Background(), newHost()))
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 3 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, rcv.Shutdown(context.Background()))
+}
+
+type consumerFunc func(ctx context.Context, ld plog.Logs) error
+
+func (f consumerFunc) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return f(ctx, ld)
+}
+
+func (f consumerFunc) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{}
+}
@@ -61,8 +61,17 @@ type Config struct {
 	UseStartTimeMetric   bool   `mapstructure:"use_start_time_metric"`
 	StartTimeMetricRegex string `mapstructure:"start_time_metric_regex"`
 
+	// EnableProtobufNegotiation makes the scrapers advertise the Prometheus protobuf exposition format in their
+	// Accept header. Native (sparse) histograms are only exposed through this format, and are converted
+	// to exponential histograms.
+	EnableProtobufNegotiation bool `mapstructure:"enable_protobuf_negotiation"`
+
 	TargetAllocator *targetAllocator `mapstructure:"target_allocator"`
 
+	// TargetSharding restricts the discovered targets this receiver scrapes, to spread the scrape
+	// load across several collectors without running an external target allocator.
+	TargetSharding *targetSharding `mapstructure:"target_sharding"`
+
 	// ConfigPlaceholder is just an entry to make the configuration pass a check
 	// that requires that all keys present in the config actually exist on the
 	// structure, ie.: it will error if an unknown key is present.
@@ -80,6 +89,17 @@ type targetAllocator struct {
 	HTTPSDConfig      *promHTTP.SDConfig `mapstructure:"-"`
 }
 
+type targetSharding struct {
+	// NodeName keeps only the targets whose NodeLabel has this value. Typically set to the node name
+	// of the collector pod when running as a DaemonSet.
+	NodeName string `mapstructure:"node_name"`
+	// NodeLabel is the discovered label compared with NodeName, defaults to __meta_kubernetes_pod_node_name.
+	NodeLabel string `mapstructure:"node_label"`
+	// ShardCount and ShardIndex keep only the targets whose address hash modulo ShardCount equals ShardIndex.
+	ShardCount uint64 `mapstructure:"shard_count"`
+	ShardIndex uint64 `mapstructure:"shard_index"`
+}
+
 var _ component.ReceiverConfig = (*Config)(nil)
 var _ confmap.Unmarshaler = (*Config)(nil)
 
@@ -155,6 +175,16 @@ func (cfg *Config) Validate() error {
 			return err
 		}
 	}
+
+	if cfg.TargetSharding != nil {
+		if cfg.TargetAllocator != nil {
+			return errors.New("target_sharding and target_allocator are mutually exclusive")
+		}
+		err := cfg.validateTargetShardingConfig()
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -254,6 +284,26 @@ func (cfg *Config) validateTargetAllocatorConfig() error {
 	return nil
 }
 
+func (cfg *Config) validateTargetShardingConfig() error {
+	sharding := cfg.TargetSharding
+	switch {
+	case sharding.NodeName != "" && sharding.ShardCount != 0:
+		return errors.New("target_sharding: node_name and shard_count are mutually exclusive")
+	case sharding.NodeName != "":
+		// ensure the node name was resolved, an unset environment variable would drop every target
+		if strings.Contains(sharding.NodeName, "${") {
+			return fmt.Errorf("target_sharding: node_name %q is not a valid node name", sharding.NodeName)
+		}
+	case sharding.ShardCount != 0:
+		if sharding.ShardIndex >= sharding.ShardCount {
+			return fmt.Errorf("target_sharding: shard_index %d must be lower than shard_count %d", sharding.ShardIndex, sharding.ShardCount)
+		}
+	default:
+		return errors.New("target_sharding: one of node_name or shard_count must be set")
+	}
+	return nil
+}
+
 // Unmarshal a config.Parser into the config struct.
 func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
 	if componentParser == nil {
@@ -52,6 +52,7 @@ func TestLoadConfig(t *testing.T) {
 	assert.Equal(t, time.Duration(r1.PrometheusConfig.ScrapeConfigs[0].ScrapeInterval), 5*time.Second)
 	assert.Equal(t, r1.UseStartTimeMetric, true)
 	assert.Equal(t, r1.StartTimeMetricRegex, "^(.+_)*process_start_time_seconds$")
+	assert.True(t, r1.EnableProtobufNegotiation)
 
 	assert.Equal(t, "http://my-targetallocator-service", r1.TargetAllocator.Endpoint)
 	assert.Equal(t, 30*time.Second, r1.TargetAllocator.Interval)
@@ -318,3 +319,60 @@ func TestFileSDConfigYamlNilTargetGroup(t *testing.T) {
 	gotErrMsg := err.Error()
 	require.Equal(t, wantErrMsg, gotErrMsg)
 }
+
+func TestLoadTargetShardingConfig(t *testing.T) {
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config_target_sharding.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id          component.ID
+		expected    *targetSharding
+		errorString string
+	}{
+		{
+			id:       component.NewIDWithName(typeStr, "node"),
+			expected: &targetSharding{NodeName: "node-1"},
+		},
+		{
+			id:       component.NewIDWithName(typeStr, "customlabel"),
+			expected: &targetSharding{NodeName: "node-1", NodeLabel: "__meta_consul_node"},
+		},
+		{
+			id:       component.NewIDWithName(typeStr, "hash"),
+			expected: &targetSharding{ShardCount: 3, ShardIndex: 2},
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "both"),
+			errorString: "target_sharding: node_name and shard_count are mutually exclusive",
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "none"),
+			errorString: "target_sharding: one of node_name or shard_count must be set",
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "outofrange"),
+			errorString: "target_sharding: shard_index 3 must be lower than shard_count 3",
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "allocator"),
+			errorString: "target_sharding and target_allocator are mutually exclusive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			cfg := NewFactory().CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalReceiverConfig(sub, cfg))
+
+			if tt.errorString != "" {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.errorString)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg.(*Config).TargetSharding)
+		})
+	}
+}
@@ -21,6 +21,7 @@ import (
 	"strings"
 
 	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/histogram"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/value"
 	"github.com/prometheus/prometheus/scrape"
@@ -59,7 +60,9 @@ type metricGroup struct {
 	hasSum       bool
 	value        float64
 	complexValue []*dataPoint
-	exemplars    pmetric.ExemplarSlice
+	// hValue holds the native histogram of the group, only set for exponential histograms.
+	hValue    *histogram.Histogram
+	exemplars pmetric.ExemplarSlice
 }
 
 func newMetricFamily(metricName string, mc scrape.MetricMetadataStore, logger *zap.Logger) *metricFamily {
@@ -89,6 +92,20 @@ func (mf *metricFamily) includesMetric(metricName string) bool {
 	return metricName == mf.name
 }
 
+// toExponentialHistogram switches the family to an exponential histogram. Prometheus native histograms share
+// the "histogram" metadata type with classic ones, so the family type can only be told apart once a native
+// histogram sample (or its staleness marker) is appended.
+func (mf *metricFamily) toExponentialHistogram() {
+	if mf.mtype == pmetric.MetricTypeExponentialHistogram {
+		return
+	}
+	mf.mtype = pmetric.MetricTypeExponentialHistogram
+	mf.isMonotonic = true
+	for _, mg := range mf.groupOrders {
+		mg.mtype = mf.mtype
+	}
+}
+
 func (mg *metricGroup) sortPoints() {
 	sort.Slice(mg.complexValue, func(i, j int) bool {
 		return mg.complexValue[i].boundary < mg.complexValue[j].boundary
@@ -147,6 +164,64 @@ func (mg *metricGroup) toDistributionPoint(dest pmetric.HistogramDataPointSlice)
 	mg.setExemplars(point.Exemplars())
 }
 
+func (mg *metricGroup) toExponentialHistogramDataPoint(dest pmetric.ExponentialHistogramDataPointSlice) {
+	if mg.hValue == nil {
+		return
+	}
+	h := mg.hValue
+
+	point := dest.AppendEmpty()
+
+	if value.IsStaleNaN(h.Sum) {
+		point.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	} else {
+		point.SetScale(h.Schema)
+		point.SetCount(h.Count)
+		point.SetSum(h.Sum)
+		// OTLP has no equivalent of the zero threshold, only the zero bucket population is kept.
+		point.SetZeroCount(h.ZeroCount)
+		convertBucketsLayout(h.PositiveSpans, h.PositiveBuckets, point.Positive())
+		convertBucketsLayout(h.NegativeSpans, h.NegativeBuckets, point.Negative())
+	}
+
+	// The timestamp MUST be in retrieved from milliseconds and converted to nanoseconds.
+	tsNanos := timestampFromMs(mg.ts)
+	point.SetStartTimestamp(tsNanos) // metrics_adjuster adjusts the startTimestamp to the initial scrape timestamp
+	point.SetTimestamp(tsNanos)
+	populateAttributes(pmetric.MetricTypeExponentialHistogram, mg.ls, point.Attributes())
+	mg.setExemplars(point.Exemplars())
+}
+
+// convertBucketsLayout converts the sparse span/delta bucket layout of a Prometheus native histogram
+// into the dense offset/count layout of an OTLP exponential histogram.
+func convertBucketsLayout(spans []histogram.Span, deltas []int64, buckets pmetric.ExponentialHistogramDataPointBuckets) {
+	if len(spans) == 0 {
+		return
+	}
+
+	// Prometheus bucket i covers (base^(i-1), base^i] while OTLP bucket i covers (base^i, base^(i+1)],
+	// so Prometheus indexes are one above the OTLP ones.
+	buckets.SetOffset(spans[0].Offset - 1)
+
+	bucketCounts := buckets.BucketCounts()
+	bucketIdx := 0
+	count := int64(0)
+	for i, span := range spans {
+		if i > 0 {
+			// Offsets of the following spans are gaps of empty buckets since the previous span.
+			for j := int32(0); j < span.Offset; j++ {
+				bucketCounts.Append(0)
+			}
+		}
+		for j := uint32(0); j < span.Length && bucketIdx < len(deltas); j++ {
+			// Bucket counts are deltas from the previous bucket, the first one being absolute.
+			count += deltas[bucketIdx]
+			bucketIdx++
+			bucketCounts.Append(uint64(count))
+		}
+	}
+}
+
 func (mg *metricGroup) setExemplars(exemplars pmetric.ExemplarSlice) {
 	if mg == nil {
 		return
@@ -260,6 +335,10 @@ func (mf *metricFamily) addSeries(seriesRef uint64, metricName string, ls labels
 	switch mf.mtype {
 	case pmetric.MetricTypeHistogram, pmetric.MetricTypeSummary:
 		switch {
+		case mf.mtype == pmetric.MetricTypeHistogram && metricName == mf.name && value.IsStaleNaN(v):
+			// A staleness marker for the series of a native histogram, which carries no suffix nor 'le' label.
+			mf.toExponentialHistogram()
+			mg.hValue = &histogram.Histogram{Sum: v}
 		case strings.HasSuffix(metricName, metricsSuffixSum):
 			mg.sum = v
 			mg.hasSum = true
@@ -275,6 +354,12 @@ func (mf *metricFamily) addSeries(seriesRef uint64, metricName string, ls labels
 			}
 			mg.complexValue = append(mg.complexValue, &dataPoint{value: v, boundary: boundary})
 		}
+	case pmetric.MetricTypeExponentialHistogram:
+		// Float samples of native histograms can only be staleness markers.
+		if !value.IsStaleNaN(v) {
+			return fmt.Errorf("unexpected float sample for native histogram metric %v", metricName)
+		}
+		mg.hValue = &histogram.Histogram{Sum: v}
 	default:
 		mg.value = v
 	}
@@ -282,6 +367,18 @@ func (mf *metricFamily) addSeries(seriesRef uint64, metricName string, ls labels
 	return nil
 }
 
+func (mf *metricFamily) addExponentialHistogramSeries(seriesRef uint64, metricName string, ls labels.Labels, t int64, h *histogram.Histogram) error {
+	mg := mf.loadMetricGroupOrCreate(seriesRef, ls, t)
+	if mg.ts != t {
+		return fmt.Errorf("inconsistent timestamps on metric points for metric %v", metricName)
+	}
+	if mf.mtype != pmetric.MetricTypeExponentialHistogram {
+		return fmt.Errorf("metric %v is not a native histogram", metricName)
+	}
+	mg.hValue = h
+	return nil
+}
+
 func (mf *metricFamily) appendMetric(metrics pmetric.MetricSlice, normalizer *prometheus.Normalizer) {
 	metric := pmetric.NewMetric()
 	// Trims type's and unit's suffixes from metric name
@@ -301,6 +398,15 @@ func (mf *metricFamily) appendMetric(metrics pmetric.MetricSlice, normalizer *pr
 		}
 		pointCount = hdpL.Len()
 
+	case pmetric.MetricTypeExponentialHistogram:
+		histogram := metric.SetEmptyExponentialHistogram()
+		histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		hdpL := histogram.DataPoints()
+		for _, mg := range mf.groupOrders {
+			mg.toExponentialHistogramDataPoint(hdpL)
+		}
+		pointCount = hdpL.Len()
+
 	case pmetric.MetricTypeSummary:
 		summary := metric.SetEmptySummary()
 		sdpL := summary.DataPoints()
@@ -22,6 +22,7 @@ import (
 
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/histogram"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/metadata"
 	"github.com/prometheus/prometheus/model/value"
@@ -138,6 +139,41 @@ func (t *transaction) Append(ref storage.SeriesRef, ls labels.Labels, atMs int64
 	return 0, curMF.addSeries(t.getSeriesRef(ls, curMF.mtype), metricName, ls, atMs, val)
 }
 
+// AppendHistogram appends a native (sparse) histogram, which is only exposed through the protobuf
+// exposition format, as an exponential histogram data point. It always returns 0 to disable label caching.
+func (t *transaction) AppendHistogram(ref storage.SeriesRef, ls labels.Labels, atMs int64, h *histogram.Histogram) (storage.SeriesRef, error) {
+	select {
+	case <-t.ctx.Done():
+		return 0, errTransactionAborted
+	default:
+	}
+
+	if len(t.externalLabels) != 0 {
+		ls = append(ls, t.externalLabels...)
+		sort.Sort(ls)
+	}
+
+	if t.isNew {
+		if err := t.initTransaction(ls); err != nil {
+			return 0, err
+		}
+	}
+
+	if dupLabel, hasDup := ls.HasDuplicateLabelNames(); hasDup {
+		return 0, fmt.Errorf("invalid sample: non-unique label names: %q", dupLabel)
+	}
+
+	metricName := ls.Get(model.MetricNameLabel)
+	if metricName == "" {
+		return 0, errMetricNameNotFound
+	}
+
+	curMF := t.getOrCreateMetricFamily(metricName)
+	curMF.toExponentialHistogram()
+
+	return 0, curMF.addExponentialHistogramSeries(t.getSeriesRef(ls, curMF.mtype), metricName, ls, atMs, h)
+}
+
 func (t *transaction) getOrCreateMetricFamily(mn string) *metricFamily {
 	curMf, ok := t.families[mn]
 	if !ok {
@@ -17,13 +17,16 @@ package internal
 import (
 	"context"
 	"errors"
+	"math"
 	"testing"
 	"time"
 
 	"github.com/prometheus/common/model"
 	"github.com/prometheus/prometheus/model/exemplar"
+	"github.com/prometheus/prometheus/model/histogram"
 	"github.com/prometheus/prometheus/model/labels"
 	"github.com/prometheus/prometheus/model/metadata"
+	"github.com/prometheus/prometheus/model/value"
 	"github.com/prometheus/prometheus/scrape"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -177,6 +180,78 @@ func TestTransactionAppendHistogramNoLe(t *testing.T) {
 	require.ErrorIs(t, err, errEmptyLeLabel)
 }
 
+func TestTransactionAppendNativeHistogram(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, componenttest.NewNopReceiverCreateSettings(), nopObsRecv(t), featuregate.GetRegistry())
+
+	lbls := labels.FromStrings(
+		model.InstanceLabel, "0.0.0.0:8855",
+		model.JobLabel, "test",
+		model.MetricNameLabel, "hist_test",
+		"foo", "bar",
+	)
+
+	_, err := tr.AppendHistogram(0, lbls, ts, &histogram.Histogram{
+		Schema:        1,
+		ZeroThreshold: 0.001,
+		ZeroCount:     2,
+		Count:         12,
+		Sum:           42.5,
+		// Buckets 0 and 1, then buckets 4 and 5.
+		PositiveSpans:   []histogram.Span{{Offset: 0, Length: 2}, {Offset: 2, Length: 2}},
+		PositiveBuckets: []int64{1, 2, -1, 3},
+		NegativeSpans:   []histogram.Span{{Offset: -2, Length: 1}},
+		NegativeBuckets: []int64{3},
+	})
+	require.NoError(t, err)
+	require.NoError(t, tr.Commit())
+
+	want := pmetric.NewMetrics()
+	m := want.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("hist_test")
+	eh := m.SetEmptyExponentialHistogram()
+	eh.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	pt := eh.DataPoints().AppendEmpty()
+	pt.SetScale(1)
+	pt.SetZeroCount(2)
+	pt.SetCount(12)
+	pt.SetSum(42.5)
+	pt.Positive().SetOffset(-1)
+	pt.Positive().BucketCounts().FromRaw([]uint64{1, 3, 0, 0, 2, 5})
+	pt.Negative().SetOffset(-3)
+	pt.Negative().BucketCounts().FromRaw([]uint64{3})
+	pt.SetTimestamp(tsNanos)
+	pt.SetStartTimestamp(startTimestamp)
+	pt.Attributes().PutStr("foo", "bar")
+
+	mds := sink.AllMetrics()
+	require.Len(t, mds, 1)
+	assertEquivalentMetrics(t, want, mds[0])
+}
+
+func TestTransactionAppendNativeHistogramStaleness(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, componenttest.NewNopReceiverCreateSettings(), nopObsRecv(t), featuregate.GetRegistry())
+
+	lbls := labels.FromStrings(
+		model.InstanceLabel, "0.0.0.0:8855",
+		model.JobLabel, "test",
+		model.MetricNameLabel, "hist_test",
+	)
+
+	// Stale native histogram series are reported with a float staleness marker on the bare metric name.
+	_, err := tr.Append(0, lbls, ts, math.Float64frombits(value.StaleNaN))
+	require.NoError(t, err)
+	require.NoError(t, tr.Commit())
+
+	mds := sink.AllMetrics()
+	require.Len(t, mds, 1)
+	m := mds[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	require.Equal(t, pmetric.MetricTypeExponentialHistogram, m.Type())
+	require.Equal(t, 1, m.ExponentialHistogram().DataPoints().Len())
+	assert.True(t, m.ExponentialHistogram().DataPoints().At(0).Flags().NoRecordedValue())
+}
+
 func TestTransactionAppendSummaryNoQuantile(t *testing.T) {
 	sink := new(consumertest.MetricsSink)
 	tr := newTransaction(scrapeCtx, &startTimeAdjuster{startTime: startTimestamp}, sink, nil, componenttest.NewNopReceiverCreateSettings(), nopObsRecv(t), featuregate.GetRegistry())
@@ -1430,6 +1505,11 @@ func (s *startTimeAdjuster) AdjustMetrics(metrics pmetric.Metrics) error {
 					for l := 0; l < dps.Len(); l++ {
 						dps.At(l).SetStartTimestamp(s.startTime)
 					}
+				case pmetric.MetricTypeExponentialHistogram:
+					dps := metric.ExponentialHistogram().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						dps.At(l).SetStartTimestamp(s.startTime)
+					}
 				}
 			}
 		}
@@ -280,13 +280,25 @@ func (r *pReceiver) initPrometheusComponents(ctx context.Context, host component
 	if err != nil {
 		return err
 	}
-	r.scrapeManager = scrape.NewManager(&scrape.Options{PassMetadataInContext: true}, logger, store)
+	r.scrapeManager = scrape.NewManager(&scrape.Options{
+		PassMetadataInContext:     true,
+		EnableProtobufNegotiation: r.cfg.EnableProtobufNegotiation,
+	}, logger, store)
+
+	syncCh := r.discoveryManager.SyncCh()
+	if r.cfg.TargetSharding != nil {
+		r.settings.Logger.Info("Sharding discovered targets",
+			zap.String("nodeName", r.cfg.TargetSharding.NodeName),
+			zap.Uint64("shardCount", r.cfg.TargetSharding.ShardCount),
+			zap.Uint64("shardIndex", r.cfg.TargetSharding.ShardIndex))
+		syncCh = r.cfg.TargetSharding.filterTargets(ctx, syncCh)
+	}
 
 	go func() {
 		// The scrape manager needs to wait for the configuration to be loaded before beginning
 		<-r.configLoaded
 		r.settings.Logger.Info("Starting scrape manager")
-		if err := r.scrapeManager.Run(r.discoveryManager.SyncCh()); err != nil {
+		if err := r.scrapeManager.Run(syncCh); err != nil {
 			r.settings.Logger.Error("Scrape manager failed", zap.Error(err))
 			host.ReportFatalError(err)
 		}
@@ -0,0 +1,199 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	gokitlog "github.com/go-kit/log"
+	"github.com/prometheus/common/model"
+	promcfg "github.com/prometheus/prometheus/config"
+	"github.com/prometheus/prometheus/discovery/targetgroup"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/relabel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/featuregate"
+)
+
+func TestTargetShardingFilterGroups(t *testing.T) {
+	tsets := map[string][]*targetgroup.Group{
+		"job": {
+			{
+				Source: "node-label-on-group",
+				Labels: model.LabelSet{defaultNodeLabel: "node-1"},
+				Targets: []model.LabelSet{
+					{model.AddressLabel: "10.0.0.1:8080"},
+					{model.AddressLabel: "10.0.0.2:8080", defaultNodeLabel: "node-2"},
+				},
+			},
+			{
+				Source: "node-label-on-targets",
+				Targets: []model.LabelSet{
+					{model.AddressLabel: "10.0.0.3:8080", defaultNodeLabel: "node-1"},
+					{model.AddressLabel: "10.0.0.4:8080", defaultNodeLabel: "node-2"},
+				},
+			},
+			{
+				Source: "no-node-label",
+				Targets: []model.LabelSet{
+					{model.AddressLabel: "10.0.0.5:8080"},
+				},
+			},
+		},
+	}
+
+	byNode := (&targetSharding{NodeName: "node-1"}).filterGroups(tsets)
+	require.Len(t, byNode["job"], 3)
+	assert.Equal(t, []model.LabelSet{{model.AddressLabel: "10.0.0.1:8080"}}, byNode["job"][0].Targets)
+	assert.Equal(t, "node-label-on-group", byNode["job"][0].Source)
+	assert.Equal(t, tsets["job"][0].Labels, byNode["job"][0].Labels)
+	assert.Equal(t, []model.LabelSet{{model.AddressLabel: "10.0.0.3:8080", defaultNodeLabel: "node-1"}}, byNode["job"][1].Targets)
+	// the group is kept without targets so that previously discovered targets are dropped
+	assert.Empty(t, byNode["job"][2].Targets)
+	assert.Equal(t, "no-node-label", byNode["job"][2].Source)
+	// the discovered groups are left untouched
+	assert.Len(t, tsets["job"][0].Targets, 2)
+
+	byCustomLabel := (&targetSharding{NodeName: "node-a", NodeLabel: "zone"}).filterGroups(map[string][]*targetgroup.Group{
+		"job": {{Targets: []model.LabelSet{{"zone": "node-a"}, {"zone": "node-b"}}}},
+	})
+	assert.Equal(t, []model.LabelSet{{"zone": "node-a"}}, byCustomLabel["job"][0].Targets)
+
+	// every target belongs to exactly one shard
+	const shardCount = 3
+	seen := map[model.LabelValue]int{}
+	for i := uint64(0); i < shardCount; i++ {
+		byHash := (&targetSharding{ShardCount: shardCount, ShardIndex: i}).filterGroups(tsets)
+		for _, group := range byHash["job"] {
+			for _, target := range group.Targets {
+				seen[target[model.AddressLabel]]++
+				assert.Equal(t, i, shardHash(string(target[model.AddressLabel]))%shardCount)
+			}
+		}
+	}
+	assert.Len(t, seen, 5)
+	for address, count := range seen {
+		assert.Equalf(t, 1, count, "target %s scraped by several shards", address)
+	}
+}
+
+func TestTargetShardingFilterTargets(t *testing.T) {
+	in := make(chan map[string][]*targetgroup.Group)
+	out := (&targetSharding{NodeName: "node-1"}).filterTargets(context.Background(), in)
+
+	in <- map[string][]*targetgroup.Group{
+		"job": {{Targets: []model.LabelSet{{defaultNodeLabel: "node-1"}, {defaultNodeLabel: "node-2"}}}},
+	}
+	tsets := <-out
+	assert.Equal(t, []model.LabelSet{{defaultNodeLabel: "node-1"}}, tsets["job"][0].Targets)
+
+	close(in)
+	select {
+	case _, ok := <-out:
+		assert.False(t, ok)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the filtered targets channel isn't closed with the discovered targets channel")
+	}
+}
+
+func TestShardHashMatchesHashmod(t *testing.T) {
+	hashmod := &relabel.Config{
+		Action:       relabel.HashMod,
+		SourceLabels: model.LabelNames{model.AddressLabel},
+		Separator:    ";",
+		Modulus:      10,
+		TargetLabel:  "shard",
+		Regex:        relabel.MustNewRegexp("(.*)"),
+	}
+	for _, address := range []string{"foo", "10.0.0.1:8080", "localhost:9090", "my-service.default.svc:8443"} {
+		lbls := relabel.Process(labels.FromStrings(model.AddressLabel, address), hashmod)
+		assert.Equal(t, lbls.Get("shard"), strconv.FormatUint(shardHash(address)%10, 10), address)
+	}
+}
+
+func TestTargetShardingFileSD(t *testing.T) {
+	handler := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte("# TYPE test_gauge gauge\ntest_gauge 1\n"))
+	})
+	local := httptest.NewServer(handler)
+	defer local.Close()
+	remote := httptest.NewServer(handler)
+	defer remote.Close()
+	localURL, err := url.Parse(local.URL)
+	require.NoError(t, err)
+	remoteURL, err := url.Parse(remote.URL)
+	require.NoError(t, err)
+
+	sdFile := filepath.Join(t.TempDir(), "targets.yaml")
+	require.NoError(t, os.WriteFile(sdFile, []byte(fmt.Sprintf(`
+- targets: ['%s']
+  labels:
+    __meta_kubernetes_pod_node_name: node-1
+- targets: ['%s']
+  labels:
+    __meta_kubernetes_pod_node_name: node-2
+`, localURL.Host, remoteURL.Host)), 0600))
+
+	pCfg, err := promcfg.Load(fmt.Sprintf(`
+scrape_configs:
+  - job_name: sharded
+    scrape_interval: 100ms
+    scrape_timeout: 50ms
+    file_sd_configs:
+      - files: ['%s']
+`, sdFile), false, gokitlog.NewNopLogger())
+	require.NoError(t, err)
+
+	cms := new(consumertest.MetricsSink)
+	receiver := newPrometheusReceiver(componenttest.NewNopReceiverCreateSettings(), &Config{
+		ReceiverSettings: config.NewReceiverSettings(component.NewID(typeStr)),
+		PrometheusConfig: pCfg,
+		TargetSharding:   &targetSharding{NodeName: "node-1"},
+	}, cms, featuregate.GetRegistry())
+
+	require.NoError(t, receiver.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, receiver.Shutdown(context.Background()))
+	})
+
+	require.Eventually(t, func() bool {
+		return cms.DataPointCount() > 0
+	}, 30*time.Second, 100*time.Millisecond)
+
+	targets := flattenTargets(receiver.scrapeManager.TargetsAll())
+	require.Len(t, targets, 1)
+	assert.Equal(t, localURL.Host, targets[0].URL().Host)
+	for _, md := range cms.AllMetrics() {
+		rms := md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			instance, _ := rms.At(i).Resource().Attributes().Get("service.instance.id")
+			assert.Equal(t, localURL.Host, instance.Str())
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver"
+
+import (
+	"context"
+	"crypto/md5" // #nosec G501 -- used for sharding, not for security, matching the Prometheus hashmod relabel action
+	"encoding/binary"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/discovery/targetgroup"
+)
+
+const defaultNodeLabel = "__meta_kubernetes_pod_node_name"
+
+// keep reports whether the discovered target, made of its own labels and the labels of its group,
+// belongs to this shard.
+func (s *targetSharding) keep(target model.LabelSet, group model.LabelSet) bool {
+	if s.NodeName != "" {
+		nodeLabel := model.LabelName(s.NodeLabel)
+		if nodeLabel == "" {
+			nodeLabel = defaultNodeLabel
+		}
+		return lookupLabel(nodeLabel, target, group) == model.LabelValue(s.NodeName)
+	}
+	return shardHash(string(lookupLabel(model.AddressLabel, target, group)))%s.ShardCount == s.ShardIndex
+}
+
+// filterGroups returns copies of the groups holding only the targets of this shard.
+// Groups left without targets are kept so that the scrape manager drops their previous targets.
+func (s *targetSharding) filterGroups(tsets map[string][]*targetgroup.Group) map[string][]*targetgroup.Group {
+	filtered := make(map[string][]*targetgroup.Group, len(tsets))
+	for job, groups := range tsets {
+		jobGroups := make([]*targetgroup.Group, 0, len(groups))
+		for _, group := range groups {
+			if group == nil {
+				continue
+			}
+			targets := make([]model.LabelSet, 0, len(group.Targets))
+			for _, target := range group.Targets {
+				if s.keep(target, group.Labels) {
+					targets = append(targets, target)
+				}
+			}
+			jobGroups = append(jobGroups, &targetgroup.Group{
+				Targets: targets,
+				Labels:  group.Labels,
+				Source:  group.Source,
+			})
+		}
+		filtered[job] = jobGroups
+	}
+	return filtered
+}
+
+// filterTargets forwards the target sets received on in, restricted to this shard, until ctx is done.
+// The returned channel is closed once in is closed.
+func (s *targetSharding) filterTargets(ctx context.Context, in <-chan map[string][]*targetgroup.Group) <-chan map[string][]*targetgroup.Group {
+	out := make(chan map[string][]*targetgroup.Group)
+	go func() {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case tsets, ok := <-in:
+				if !ok {
+					close(out)
+					return
+				}
+				select {
+				case out <- s.filterGroups(tsets):
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return out
+}
+
+// lookupLabel returns the value of the target label, falling back to the label of its group.
+func lookupLabel(name model.LabelName, target model.LabelSet, group model.LabelSet) model.LabelValue {
+	if v, ok := target[name]; ok {
+		return v
+	}
+	return group[name]
+}
+
+// shardHash hashes the value the same way as the Prometheus hashmod relabel action, so that both
+// can be used together.
+func shardHash(value string) uint64 {
+	sum := md5.Sum([]byte(value)) // #nosec G401
+	return binary.BigEndian.Uint64(sum[md5.Size-8:])
+}
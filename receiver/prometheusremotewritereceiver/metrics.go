@@ -0,0 +1,530 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"math"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+const (
+	metricsSuffixBucket = "_bucket"
+	metricsSuffixCount  = "_count"
+	metricsSuffixSum    = "_sum"
+	metricSuffixTotal   = "_total"
+
+	targetInfoMetricName = "target_info"
+
+	labelSeparator = '\xff'
+)
+
+// resourceKey identifies the resource a series belongs to.
+type resourceKey struct {
+	job      string
+	instance string
+}
+
+type resourceGroup struct {
+	key         resourceKey
+	targetInfo  []prompb.Label
+	families    map[string]*metricFamily
+	familyOrder []*metricFamily
+}
+
+type metricFamily struct {
+	name        string
+	mtype       pmetric.MetricType
+	isMonotonic bool
+	metadata    prompb.MetricMetadata
+	groups      map[groupKey]*metricGroup
+	groupOrder  []*metricGroup
+}
+
+type groupKey struct {
+	signature string
+	ts        int64
+}
+
+// metricGroup holds a single data point of a family: all the series of a histogram or a summary
+// sharing the same labels and timestamp are merged into the same group.
+type metricGroup struct {
+	attributes []prompb.Label
+	ts         int64
+	value      float64
+	count      float64
+	hasCount   bool
+	sum        float64
+	hasSum     bool
+	buckets    []boundedValue
+}
+
+type boundedValue struct {
+	boundary float64
+	value    float64
+}
+
+// converter builds metrics from the series of a remote write request.
+type converter struct {
+	metadata map[string]prompb.MetricMetadata
+	// histograms and summaries hold the families found through their 'le' and 'quantile' labels,
+	// used to attach the _count and _sum series when no metadata was sent.
+	histograms     map[string]bool
+	summaries      map[string]bool
+	resources      map[resourceKey]*resourceGroup
+	resourceOrders []*resourceGroup
+}
+
+// fromWriteRequest converts the series of a remote write request to metrics, with one resource per job and instance.
+// The metric types are taken from the request metadata when sent, and otherwise guessed from the series names and labels.
+func fromWriteRequest(wr *prompb.WriteRequest) pmetric.Metrics {
+	c := &converter{
+		metadata:   make(map[string]prompb.MetricMetadata, len(wr.Metadata)),
+		histograms: make(map[string]bool),
+		summaries:  make(map[string]bool),
+		resources:  make(map[resourceKey]*resourceGroup),
+	}
+	for _, md := range wr.Metadata {
+		c.metadata[md.MetricFamilyName] = md
+	}
+	for i := range wr.Timeseries {
+		ls := wr.Timeseries[i].Labels
+		name := getLabel(ls, model.MetricNameLabel)
+		switch {
+		case strings.HasSuffix(name, metricsSuffixBucket) && getLabel(ls, model.BucketLabel) != "":
+			c.histograms[strings.TrimSuffix(name, metricsSuffixBucket)] = true
+		case getLabel(ls, model.QuantileLabel) != "":
+			c.summaries[name] = true
+		}
+	}
+
+	for i := range wr.Timeseries {
+		c.addSeries(&wr.Timeseries[i])
+	}
+	return c.toMetrics()
+}
+
+func (c *converter) addSeries(ts *prompb.TimeSeries) {
+	name := getLabel(ts.Labels, model.MetricNameLabel)
+	if name == "" {
+		return
+	}
+
+	rg := c.getOrCreateResource(resourceKey{
+		job:      getLabel(ts.Labels, model.JobLabel),
+		instance: getLabel(ts.Labels, model.InstanceLabel),
+	})
+
+	// The labels of target_info are resource attributes, see
+	// https://github.com/open-telemetry/opentelemetry-specification/blob/main/specification/compatibility/prometheus_and_openmetrics.md#resource-attributes-1
+	if name == targetInfoMetricName {
+		rg.targetInfo = append(rg.targetInfo, filterLabels(ts.Labels, model.MetricNameLabel, model.JobLabel, model.InstanceLabel)...)
+		return
+	}
+
+	mf := rg.getOrCreateFamily(c.resolveFamily(name, ts.Labels))
+
+	var attributes []prompb.Label
+	switch mf.mtype {
+	case pmetric.MetricTypeHistogram:
+		attributes = filterLabels(ts.Labels, model.MetricNameLabel, model.JobLabel, model.InstanceLabel, model.BucketLabel)
+	case pmetric.MetricTypeSummary:
+		attributes = filterLabels(ts.Labels, model.MetricNameLabel, model.JobLabel, model.InstanceLabel, model.QuantileLabel)
+	default:
+		attributes = filterLabels(ts.Labels, model.MetricNameLabel, model.JobLabel, model.InstanceLabel)
+	}
+	signature := labelsSignature(attributes)
+
+	for _, s := range ts.Samples {
+		mg := mf.getOrCreateGroup(groupKey{signature: signature, ts: s.Timestamp}, attributes)
+		switch mf.mtype {
+		case pmetric.MetricTypeHistogram, pmetric.MetricTypeSummary:
+			switch {
+			case name == mf.name+metricsSuffixCount:
+				mg.count = s.Value
+				mg.hasCount = true
+			case name == mf.name+metricsSuffixSum:
+				mg.sum = s.Value
+				mg.hasSum = true
+			default:
+				boundaryLabel := model.BucketLabel
+				if mf.mtype == pmetric.MetricTypeSummary {
+					boundaryLabel = model.QuantileLabel
+				}
+				boundary, err := strconv.ParseFloat(getLabel(ts.Labels, boundaryLabel), 64)
+				if err != nil {
+					// Buckets and quantiles without a valid boundary can't be placed, drop them.
+					continue
+				}
+				mg.buckets = append(mg.buckets, boundedValue{boundary: boundary, value: s.Value})
+			}
+		default:
+			mg.value = s.Value
+		}
+	}
+}
+
+// resolveFamily returns the family name and type of a series. Histograms and summaries are made of several series,
+// which are merged in the family of their base name.
+func (c *converter) resolveFamily(name string, ls []prompb.Label) (string, pmetric.MetricType, bool, prompb.MetricMetadata) {
+	if md, ok := c.metadata[name]; ok {
+		mtype, isMonotonic := convToMetricType(md.Type)
+		return name, mtype, isMonotonic, md
+	}
+	for _, suffix := range []string{metricsSuffixBucket, metricsSuffixCount, metricsSuffixSum, metricSuffixTotal} {
+		if !strings.HasSuffix(name, suffix) {
+			continue
+		}
+		familyName := strings.TrimSuffix(name, suffix)
+		md, ok := c.metadata[familyName]
+		if !ok {
+			continue
+		}
+		isFamilySeries := false
+		switch md.Type {
+		case prompb.MetricMetadata_HISTOGRAM:
+			isFamilySeries = suffix != metricSuffixTotal
+		case prompb.MetricMetadata_SUMMARY:
+			isFamilySeries = suffix == metricsSuffixCount || suffix == metricsSuffixSum
+		case prompb.MetricMetadata_COUNTER:
+			// OpenMetrics counters are named after their family, without the _total suffix of their series.
+			isFamilySeries = suffix == metricSuffixTotal
+		}
+		if isFamilySeries {
+			mtype, isMonotonic := convToMetricType(md.Type)
+			return familyName, mtype, isMonotonic, md
+		}
+	}
+
+	// Without metadata, the type is guessed from the Prometheus naming conventions.
+	switch {
+	case strings.HasSuffix(name, metricsSuffixBucket) && getLabel(ls, model.BucketLabel) != "":
+		return strings.TrimSuffix(name, metricsSuffixBucket), pmetric.MetricTypeHistogram, true, prompb.MetricMetadata{}
+	case c.summaries[name]:
+		return name, pmetric.MetricTypeSummary, true, prompb.MetricMetadata{}
+	case strings.HasSuffix(name, metricsSuffixCount), strings.HasSuffix(name, metricsSuffixSum):
+		familyName := strings.TrimSuffix(strings.TrimSuffix(name, metricsSuffixCount), metricsSuffixSum)
+		if c.histograms[familyName] {
+			return familyName, pmetric.MetricTypeHistogram, true, prompb.MetricMetadata{}
+		}
+		if c.summaries[familyName] {
+			return familyName, pmetric.MetricTypeSummary, true, prompb.MetricMetadata{}
+		}
+	case strings.HasSuffix(name, metricSuffixTotal):
+		return name, pmetric.MetricTypeSum, true, prompb.MetricMetadata{}
+	}
+	return name, pmetric.MetricTypeGauge, false, prompb.MetricMetadata{}
+}
+
+// convToMetricType returns the data type and if it is monotonic.
+func convToMetricType(metricType prompb.MetricMetadata_MetricType) (pmetric.MetricType, bool) {
+	switch metricType {
+	case prompb.MetricMetadata_COUNTER:
+		return pmetric.MetricTypeSum, true
+	case prompb.MetricMetadata_HISTOGRAM:
+		return pmetric.MetricTypeHistogram, true
+	case prompb.MetricMetadata_SUMMARY:
+		return pmetric.MetricTypeSummary, true
+	case prompb.MetricMetadata_INFO, prompb.MetricMetadata_STATESET:
+		return pmetric.MetricTypeSum, false
+	default:
+		// including unknown and gauge histograms, which have no OTLP equivalent.
+		return pmetric.MetricTypeGauge, false
+	}
+}
+
+func (c *converter) getOrCreateResource(key resourceKey) *resourceGroup {
+	rg, ok := c.resources[key]
+	if !ok {
+		rg = &resourceGroup{
+			key:      key,
+			families: make(map[string]*metricFamily),
+		}
+		c.resources[key] = rg
+		c.resourceOrders = append(c.resourceOrders, rg)
+	}
+	return rg
+}
+
+func (rg *resourceGroup) getOrCreateFamily(name string, mtype pmetric.MetricType, isMonotonic bool, md prompb.MetricMetadata) *metricFamily {
+	mf, ok := rg.families[name]
+	if !ok {
+		mf = &metricFamily{
+			name:        name,
+			mtype:       mtype,
+			isMonotonic: isMonotonic,
+			metadata:    md,
+			groups:      make(map[groupKey]*metricGroup),
+		}
+		rg.families[name] = mf
+		rg.familyOrder = append(rg.familyOrder, mf)
+	}
+	return mf
+}
+
+func (mf *metricFamily) getOrCreateGroup(key groupKey, attributes []prompb.Label) *metricGroup {
+	mg, ok := mf.groups[key]
+	if !ok {
+		mg = &metricGroup{
+			attributes: attributes,
+			ts:         key.ts,
+		}
+		mf.groups[key] = mg
+		// maintaining data insertion order is helpful to generate stable/reproducible metric output
+		mf.groupOrder = append(mf.groupOrder, mg)
+	}
+	return mg
+}
+
+func (c *converter) toMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, rg := range c.resourceOrders {
+		rm := pmetric.NewResourceMetrics()
+		rg.fillResource(rm.Resource())
+		metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+		for _, mf := range rg.familyOrder {
+			mf.appendMetric(metrics)
+		}
+		if metrics.Len() > 0 {
+			rm.MoveTo(md.ResourceMetrics().AppendEmpty())
+		}
+	}
+	return md
+}
+
+func (rg *resourceGroup) fillResource(resource pcommon.Resource) {
+	attrs := resource.Attributes()
+	if rg.key.job != "" {
+		attrs.PutStr(conventions.AttributeServiceName, rg.key.job)
+	}
+	if rg.key.instance != "" {
+		attrs.PutStr(conventions.AttributeServiceInstanceID, rg.key.instance)
+		if host, port, err := net.SplitHostPort(rg.key.instance); err == nil {
+			attrs.PutStr(conventions.AttributeNetHostName, host)
+			attrs.PutStr(conventions.AttributeNetHostPort, port)
+		}
+	}
+	for _, l := range rg.targetInfo {
+		attrs.PutStr(l.Name, l.Value)
+	}
+}
+
+func (mf *metricFamily) appendMetric(metrics pmetric.MetricSlice) {
+	metric := pmetric.NewMetric()
+	metric.SetName(mf.name)
+	metric.SetDescription(mf.metadata.Help)
+	metric.SetUnit(mf.metadata.Unit)
+
+	pointCount := 0
+
+	switch mf.mtype {
+	case pmetric.MetricTypeHistogram:
+		histogram := metric.SetEmptyHistogram()
+		histogram.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		dps := histogram.DataPoints()
+		for _, mg := range mf.groupOrder {
+			mg.toHistogramDataPoint(dps)
+		}
+		pointCount = dps.Len()
+
+	case pmetric.MetricTypeSummary:
+		summary := metric.SetEmptySummary()
+		dps := summary.DataPoints()
+		for _, mg := range mf.groupOrder {
+			mg.toSummaryDataPoint(dps)
+		}
+		pointCount = dps.Len()
+
+	case pmetric.MetricTypeSum:
+		sum := metric.SetEmptySum()
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		sum.SetIsMonotonic(mf.isMonotonic)
+		dps := sum.DataPoints()
+		for _, mg := range mf.groupOrder {
+			mg.toNumberDataPoint(dps)
+		}
+		pointCount = dps.Len()
+
+	default:
+		gauge := metric.SetEmptyGauge()
+		dps := gauge.DataPoints()
+		for _, mg := range mf.groupOrder {
+			mg.toNumberDataPoint(dps)
+		}
+		pointCount = dps.Len()
+	}
+
+	if pointCount == 0 {
+		return
+	}
+	metric.MoveTo(metrics.AppendEmpty())
+}
+
+func (mg *metricGroup) toNumberDataPoint(dest pmetric.NumberDataPointSlice) {
+	point := dest.AppendEmpty()
+	point.SetTimestamp(timestampFromMs(mg.ts))
+	if value.IsStaleNaN(mg.value) {
+		point.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+	} else {
+		point.SetDoubleValue(mg.value)
+	}
+	populateAttributes(mg.attributes, point.Attributes())
+}
+
+func (mg *metricGroup) toHistogramDataPoint(dest pmetric.HistogramDataPointSlice) {
+	if len(mg.buckets) == 0 {
+		return
+	}
+	sort.Slice(mg.buckets, func(i, j int) bool {
+		return mg.buckets[i].boundary < mg.buckets[j].boundary
+	})
+
+	count := mg.count
+	if !mg.hasCount {
+		// The +Inf bucket holds the total count when the _count series was not sent.
+		last := mg.buckets[len(mg.buckets)-1]
+		if !math.IsInf(last.boundary, 1) {
+			return
+		}
+		count = last.value
+	}
+	if !math.IsInf(mg.buckets[len(mg.buckets)-1].boundary, 1) {
+		mg.buckets = append(mg.buckets, boundedValue{boundary: math.Inf(1), value: count})
+	}
+
+	point := dest.AppendEmpty()
+	point.SetTimestamp(timestampFromMs(mg.ts))
+	populateAttributes(mg.attributes, point.Attributes())
+
+	if value.IsStaleNaN(mg.sum) || value.IsStaleNaN(count) {
+		point.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+
+	// for OTLP the bounds won't include +inf
+	bounds := make([]float64, len(mg.buckets)-1)
+	bucketCounts := make([]uint64, len(mg.buckets))
+	for i, b := range mg.buckets {
+		if i != len(mg.buckets)-1 {
+			bounds[i] = b.boundary
+		}
+		// Prometheus buckets are cumulative, OTLP ones aren't.
+		adjustedCount := b.value
+		if i != 0 {
+			adjustedCount -= mg.buckets[i-1].value
+		}
+		bucketCounts[i] = uint64(adjustedCount)
+	}
+	point.ExplicitBounds().FromRaw(bounds)
+	point.BucketCounts().FromRaw(bucketCounts)
+	point.SetCount(uint64(count))
+	if mg.hasSum {
+		point.SetSum(mg.sum)
+	}
+}
+
+func (mg *metricGroup) toSummaryDataPoint(dest pmetric.SummaryDataPointSlice) {
+	if !mg.hasCount {
+		return
+	}
+	sort.Slice(mg.buckets, func(i, j int) bool {
+		return mg.buckets[i].boundary < mg.buckets[j].boundary
+	})
+
+	point := dest.AppendEmpty()
+	point.SetTimestamp(timestampFromMs(mg.ts))
+	populateAttributes(mg.attributes, point.Attributes())
+
+	if value.IsStaleNaN(mg.sum) || value.IsStaleNaN(mg.count) {
+		point.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+		return
+	}
+
+	point.SetCount(uint64(mg.count))
+	if mg.hasSum {
+		point.SetSum(mg.sum)
+	}
+	quantileValues := point.QuantileValues()
+	for _, b := range mg.buckets {
+		quantile := quantileValues.AppendEmpty()
+		quantile.SetQuantile(b.boundary)
+		quantile.SetValue(b.value)
+	}
+}
+
+func populateAttributes(ls []prompb.Label, dest pcommon.Map) {
+	dest.EnsureCapacity(len(ls))
+	for _, l := range ls {
+		if l.Value == "" {
+			// empty label values should be omitted
+			continue
+		}
+		dest.PutStr(l.Name, l.Value)
+	}
+}
+
+func getLabel(ls []prompb.Label, name string) string {
+	for _, l := range ls {
+		if l.Name == name {
+			return l.Value
+		}
+	}
+	return ""
+}
+
+// filterLabels returns the labels sorted by name, without the given ones.
+func filterLabels(ls []prompb.Label, exclude ...string) []prompb.Label {
+	filtered := make([]prompb.Label, 0, len(ls))
+	for _, l := range ls {
+		excluded := false
+		for _, e := range exclude {
+			if l.Name == e {
+				excluded = true
+				break
+			}
+		}
+		if !excluded {
+			filtered = append(filtered, l)
+		}
+	}
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].Name < filtered[j].Name
+	})
+	return filtered
+}
+
+func labelsSignature(ls []prompb.Label) string {
+	var b strings.Builder
+	for _, l := range ls {
+		b.WriteString(l.Name)
+		b.WriteByte(labelSeparator)
+		b.WriteString(l.Value)
+		b.WriteByte(labelSeparator)
+	}
+	return b.String()
+}
+
+func timestampFromMs(timeAtMs int64) pcommon.Timestamp {
+	return pcommon.Timestamp(timeAtMs * 1e6)
+}
@@ -0,0 +1,248 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"math"
+	"testing"
+
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const ts = int64(1669905000000)
+
+func series(name string, v float64, labelPairs ...string) prompb.TimeSeries {
+	ls := []prompb.Label{
+		{Name: "__name__", Value: name},
+		{Name: "instance", Value: "localhost:8080"},
+		{Name: "job", Value: "test"},
+	}
+	for i := 0; i < len(labelPairs); i += 2 {
+		ls = append(ls, prompb.Label{Name: labelPairs[i], Value: labelPairs[i+1]})
+	}
+	return prompb.TimeSeries{
+		Labels:  ls,
+		Samples: []prompb.Sample{{Value: v, Timestamp: ts}},
+	}
+}
+
+func TestFromWriteRequestResource(t *testing.T) {
+	md := fromWriteRequest(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series("gauge_test", 1),
+			series("target_info", 1, "k8s_pod_name", "my-pod"),
+			{
+				Labels: []prompb.Label{
+					{Name: "__name__", Value: "gauge_test"},
+					{Name: "instance", Value: "other:8080"},
+					{Name: "job", Value: "test"},
+				},
+				Samples: []prompb.Sample{{Value: 2, Timestamp: ts}},
+			},
+		},
+	})
+
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+	attrs := md.ResourceMetrics().At(0).Resource().Attributes().AsRaw()
+	assert.Equal(t, map[string]interface{}{
+		"service.name":        "test",
+		"service.instance.id": "localhost:8080",
+		"net.host.name":       "localhost",
+		"net.host.port":       "8080",
+		"k8s_pod_name":        "my-pod",
+	}, attrs)
+	assert.Equal(t, "other:8080", md.ResourceMetrics().At(1).Resource().Attributes().AsRaw()["service.instance.id"])
+
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, metrics.Len())
+	assert.Equal(t, "gauge_test", metrics.At(0).Name())
+}
+
+func TestFromWriteRequestMetricTypes(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *prompb.WriteRequest
+		want func() pmetric.MetricSlice
+	}{
+		{
+			name: "gauge",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{series("gauge_test", 42, "foo", "bar")},
+			},
+			want: func() pmetric.MetricSlice {
+				ms := pmetric.NewMetricSlice()
+				m := ms.AppendEmpty()
+				m.SetName("gauge_test")
+				dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+				dp.SetTimestamp(timestampFromMs(ts))
+				dp.SetDoubleValue(42)
+				dp.Attributes().PutStr("foo", "bar")
+				return ms
+			},
+		},
+		{
+			name: "stale-gauge",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{series("gauge_test", math.Float64frombits(value.StaleNaN))},
+			},
+			want: func() pmetric.MetricSlice {
+				ms := pmetric.NewMetricSlice()
+				m := ms.AppendEmpty()
+				m.SetName("gauge_test")
+				dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+				dp.SetTimestamp(timestampFromMs(ts))
+				dp.SetFlags(pmetric.DefaultDataPointFlags.WithNoRecordedValue(true))
+				return ms
+			},
+		},
+		{
+			name: "counter-from-suffix",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{series("requests_total", 10)},
+			},
+			want: func() pmetric.MetricSlice {
+				ms := pmetric.NewMetricSlice()
+				m := ms.AppendEmpty()
+				m.SetName("requests_total")
+				sum := m.SetEmptySum()
+				sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				sum.SetIsMonotonic(true)
+				dp := sum.DataPoints().AppendEmpty()
+				dp.SetTimestamp(timestampFromMs(ts))
+				dp.SetDoubleValue(10)
+				return ms
+			},
+		},
+		{
+			name: "openmetrics-counter-from-metadata",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{series("requests_total", 10)},
+				Metadata: []prompb.MetricMetadata{
+					{Type: prompb.MetricMetadata_COUNTER, MetricFamilyName: "requests", Help: "Requests served.", Unit: "1"},
+				},
+			},
+			want: func() pmetric.MetricSlice {
+				ms := pmetric.NewMetricSlice()
+				m := ms.AppendEmpty()
+				m.SetName("requests")
+				m.SetDescription("Requests served.")
+				m.SetUnit("1")
+				sum := m.SetEmptySum()
+				sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				sum.SetIsMonotonic(true)
+				dp := sum.DataPoints().AppendEmpty()
+				dp.SetTimestamp(timestampFromMs(ts))
+				dp.SetDoubleValue(10)
+				return ms
+			},
+		},
+		{
+			name: "gauge-from-metadata",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{series("queue_size_total", 3)},
+				Metadata: []prompb.MetricMetadata{
+					{Type: prompb.MetricMetadata_GAUGE, MetricFamilyName: "queue_size_total"},
+				},
+			},
+			want: func() pmetric.MetricSlice {
+				ms := pmetric.NewMetricSlice()
+				m := ms.AppendEmpty()
+				m.SetName("queue_size_total")
+				dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+				dp.SetTimestamp(timestampFromMs(ts))
+				dp.SetDoubleValue(3)
+				return ms
+			},
+		},
+		{
+			name: "histogram-from-suffixes",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					series("latency_bucket", 10, "le", "+Inf", "path", "/"),
+					series("latency_bucket", 2, "le", "0.1", "path", "/"),
+					series("latency_bucket", 7, "le", "1", "path", "/"),
+					series("latency_count", 10, "path", "/"),
+					series("latency_sum", 12.5, "path", "/"),
+				},
+			},
+			want: func() pmetric.MetricSlice {
+				ms := pmetric.NewMetricSlice()
+				m := ms.AppendEmpty()
+				m.SetName("latency")
+				h := m.SetEmptyHistogram()
+				h.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				dp := h.DataPoints().AppendEmpty()
+				dp.SetTimestamp(timestampFromMs(ts))
+				dp.SetCount(10)
+				dp.SetSum(12.5)
+				dp.ExplicitBounds().FromRaw([]float64{0.1, 1})
+				dp.BucketCounts().FromRaw([]uint64{2, 5, 3})
+				dp.Attributes().PutStr("path", "/")
+				return ms
+			},
+		},
+		{
+			name: "summary-from-metadata",
+			req: &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{
+					series("rpc_duration", 0.5, "quantile", "0.5"),
+					series("rpc_duration", 0.9, "quantile", "0.9"),
+					series("rpc_duration_count", 100),
+					series("rpc_duration_sum", 55),
+				},
+				Metadata: []prompb.MetricMetadata{
+					{Type: prompb.MetricMetadata_SUMMARY, MetricFamilyName: "rpc_duration"},
+				},
+			},
+			want: func() pmetric.MetricSlice {
+				ms := pmetric.NewMetricSlice()
+				m := ms.AppendEmpty()
+				m.SetName("rpc_duration")
+				dp := m.SetEmptySummary().DataPoints().AppendEmpty()
+				dp.SetTimestamp(timestampFromMs(ts))
+				dp.SetCount(100)
+				dp.SetSum(55)
+				q := dp.QuantileValues().AppendEmpty()
+				q.SetQuantile(0.5)
+				q.SetValue(0.5)
+				q = dp.QuantileValues().AppendEmpty()
+				q.SetQuantile(0.9)
+				q.SetValue(0.9)
+				return ms
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			md := fromWriteRequest(tt.req)
+			require.Equal(t, 1, md.ResourceMetrics().Len())
+			assert.Equal(t, tt.want(), md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics())
+		})
+	}
+}
+
+func TestFromWriteRequestEmpty(t *testing.T) {
+	md := fromWriteRequest(&prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			{Labels: []prompb.Label{{Name: "job", Value: "no-name"}}, Samples: []prompb.Sample{{Value: 1, Timestamp: ts}}},
+		},
+	})
+	assert.Equal(t, 0, md.DataPointCount())
+}
@@ -0,0 +1,205 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+const (
+	// writePath is the path remote write requests are accepted on, the same as the Prometheus server.
+	writePath = "/api/v1/write"
+
+	dataFormat = "prometheus_remote_write"
+)
+
+// remoteWriteReceiver receives metrics pushed with the Prometheus remote write protocol over HTTP.
+type remoteWriteReceiver struct {
+	settings component.TelemetrySettings
+	config   *Config
+
+	server     *http.Server
+	shutdownWG sync.WaitGroup
+
+	nextConsumer consumer.Metrics
+	obsrecv      *obsreport.Receiver
+}
+
+// newReceiver creates a remoteWriteReceiver that receives remote write requests over http.
+func newReceiver(params component.ReceiverCreateSettings, config *Config, nextConsumer consumer.Metrics) (*remoteWriteReceiver, error) {
+	if nextConsumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+	transport := "http"
+	if config.TLSSetting != nil {
+		transport = "https"
+	}
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             config.ID(),
+		Transport:              transport,
+		ReceiverCreateSettings: params,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &remoteWriteReceiver{
+		settings:     params.TelemetrySettings,
+		config:       config,
+		nextConsumer: nextConsumer,
+		obsrecv:      obsrecv,
+	}, nil
+}
+
+// Start starts the server accepting remote write requests.
+func (r *remoteWriteReceiver) Start(_ context.Context, host component.Host) error {
+	ln, err := r.config.HTTPServerSettings.ToListener()
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", r.config.Endpoint, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(writePath, r.handleWrite)
+
+	r.server, err = r.config.HTTPServerSettings.ToServer(host, r.settings, mux)
+	if err != nil {
+		return err
+	}
+
+	r.shutdownWG.Add(1)
+	go func() {
+		defer r.shutdownWG.Done()
+		if errHTTP := r.server.Serve(ln); !errors.Is(errHTTP, http.ErrServerClosed) && errHTTP != nil {
+			host.ReportFatalError(errHTTP)
+		}
+	}()
+	return nil
+}
+
+// Shutdown stops the server accepting remote write requests.
+func (r *remoteWriteReceiver) Shutdown(context.Context) error {
+	if r.server == nil {
+		return nil
+	}
+	err := r.server.Close()
+	r.shutdownWG.Wait()
+	return err
+}
+
+func (r *remoteWriteReceiver) handleWrite(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "only POST requests are supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	maxBodySize := r.config.MaxRequestBodySize
+	if maxBodySize <= 0 {
+		maxBodySize = defaultMaxRequestBodySize
+	}
+	if req.ContentLength > maxBodySize {
+		http.Error(w, "request body too large, the limit is "+strconv.FormatInt(maxBodySize, 10)+" bytes", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	wr, err := decodeWriteRequest(http.MaxBytesReader(w, req.Body, maxBodySize), maxBodySize)
+	if err != nil {
+		r.settings.Logger.Debug("Failed to decode remote write request", zap.Error(err))
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	r.dropNativeHistograms(req.Context(), wr)
+
+	md := fromWriteRequest(wr)
+	dataPointCount := md.DataPointCount()
+	if dataPointCount == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	ctx := r.obsrecv.StartMetricsOp(req.Context())
+	err = r.nextConsumer.ConsumeMetrics(ctx, md)
+	r.obsrecv.EndMetricsOp(ctx, dataFormat, dataPointCount, err)
+	if err != nil {
+		// Prometheus senders retry on 5xx responses, but drop the data on 4xx ones.
+		status := http.StatusServiceUnavailable
+		if consumererror.IsPermanent(err) {
+			status = http.StatusBadRequest
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// dropNativeHistograms logs and counts the native histogram samples of the request, which have no conversion yet.
+func (r *remoteWriteReceiver) dropNativeHistograms(ctx context.Context, wr *prompb.WriteRequest) {
+	dropped := 0
+	for i := range wr.Timeseries {
+		dropped += len(wr.Timeseries[i].Histograms)
+	}
+	if dropped == 0 {
+		return
+	}
+	r.settings.Logger.Warn("Dropping the native histogram samples of the remote write request, they are not supported",
+		zap.Int("dropped_samples", dropped))
+	_ = stats.RecordWithTags(ctx,
+		[]tag.Mutator{tag.Upsert(tagInstanceName, r.config.ID().String())},
+		statDroppedNativeHistograms.M(int64(dropped)))
+}
+
+// decodeWriteRequest reads a snappy compressed, protobuf encoded remote write request, of at most maxSize bytes
+// once decompressed.
+func decodeWriteRequest(r io.Reader, maxSize int64) (*prompb.WriteRequest, error) {
+	compressed, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	size, err := snappy.DecodedLen(compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress request body: %w", err)
+	}
+	if int64(size) > maxSize {
+		return nil, fmt.Errorf("decompressed request body of %d bytes is over the limit of %d bytes", size, maxSize)
+	}
+
+	buf, err := snappy.Decode(nil, compressed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress request body: %w", err)
+	}
+
+	var wr prompb.WriteRequest
+	if err := wr.Unmarshal(buf); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal write request: %w", err)
+	}
+	return &wr, nil
+}
@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/snappy"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+func startReceiver(t *testing.T, next consumer.Metrics) string {
+	return startReceiverWithConfig(t, createDefaultConfig().(*Config), next)
+}
+
+func startReceiverWithConfig(t *testing.T, cfg *Config, next consumer.Metrics) string {
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+
+	r, err := newReceiver(componenttest.NewNopReceiverCreateSettings(), cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, r.Shutdown(context.Background()))
+	})
+	return fmt.Sprintf("http://%s%s", cfg.Endpoint, writePath)
+}
+
+func postWriteRequest(t *testing.T, url string, wr *prompb.WriteRequest) *http.Response {
+	buf, err := wr.Marshal()
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(snappy.Encode(nil, buf)))
+	require.NoError(t, err)
+	req.Header.Set("Content-Encoding", "snappy")
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	return resp
+}
+
+func TestReceiveWriteRequest(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	url := startReceiver(t, sink)
+
+	resp := postWriteRequest(t, url, &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{
+			series("requests_total", 10, "code", "200"),
+			series("requests_total", 2, "code", "500"),
+		},
+	})
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	mds := sink.AllMetrics()
+	require.Len(t, mds, 1)
+	assert.Equal(t, 2, mds[0].DataPointCount())
+	metric := mds[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "requests_total", metric.Name())
+	assert.Equal(t, pmetric.MetricTypeSum, metric.Type())
+}
+
+func TestReceiveInvalidRequests(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	url := startReceiver(t, sink)
+
+	resp, err := http.Post(url, "application/x-protobuf", bytes.NewReader([]byte("not snappy"))) // nolint:gosec
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Get(url) // nolint:gosec
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	assert.Len(t, sink.AllMetrics(), 0)
+}
+
+func TestReceiveTooLargeRequests(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxRequestBodySize = 1024
+	url := startReceiverWithConfig(t, cfg, sink)
+
+	resp := postWriteRequest(t, url, &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{series("gauge_test", 1, "padding", strings.Repeat("a", 2048))},
+	})
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err := http.Post(url, "application/x-protobuf", bytes.NewReader(make([]byte, 2048))) // nolint:gosec
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+
+	assert.Len(t, sink.AllMetrics(), 0)
+}
+
+func TestReceiveNativeHistograms(t *testing.T) {
+	views := MetricViews()
+	view.Unregister(views...)
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	sink := new(consumertest.MetricsSink)
+	url := startReceiver(t, sink)
+
+	withHistograms := series("requests_total", 10)
+	withHistograms.Histograms = []prompb.Histogram{{Timestamp: 1}, {Timestamp: 2}}
+	resp := postWriteRequest(t, url, &prompb.WriteRequest{
+		Timeseries: []prompb.TimeSeries{withHistograms},
+	})
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	mds := sink.AllMetrics()
+	require.Len(t, mds, 1)
+	assert.Equal(t, 1, mds[0].DataPointCount())
+
+	rows, err := view.RetrieveData(statDroppedNativeHistograms.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, []tag.Tag{{Key: tagInstanceName, Value: typeStr}}, rows[0].Tags)
+	assert.Equal(t, float64(2), rows[0].Data.(*view.SumData).Value)
+}
+
+func TestReceiveConsumerErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{
+			name:       "retryable",
+			err:        fmt.Errorf("temporarily unavailable"),
+			wantStatus: http.StatusServiceUnavailable,
+		},
+		{
+			name:       "permanent",
+			err:        consumererror.NewPermanent(fmt.Errorf("invalid data")),
+			wantStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			url := startReceiver(t, consumertest.NewErr(tt.err))
+			resp := postWriteRequest(t, url, &prompb.WriteRequest{
+				Timeseries: []prompb.TimeSeries{series("gauge_test", 1)},
+			})
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
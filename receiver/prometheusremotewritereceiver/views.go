@@ -0,0 +1,41 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusremotewritereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusremotewritereceiver"
+
+import (
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+var (
+	tagInstanceName, _ = tag.NewKey("name")
+
+	statDroppedNativeHistograms = stats.Int64("prometheus_remote_write_receiver_dropped_native_histograms",
+		"Number of native histogram samples dropped because they can't be converted", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metric views of the Prometheus remote write receiver.
+func MetricViews() []*view.View {
+	return []*view.View{
+		{
+			Name:        statDroppedNativeHistograms.Name(),
+			Measure:     statDroppedNativeHistograms,
+			Description: statDroppedNativeHistograms.Description(),
+			TagKeys:     []tag.Key{tagInstanceName},
+			Aggregation: view.Sum(),
+		},
+	}
+}
@@ -15,6 +15,7 @@
 package receivercreator // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/receivercreator"
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/spf13/cast"
@@ -91,6 +92,28 @@ type Config struct {
 	// ResourceAttributes is a map of default resource attributes to add to each resource
 	// object received by this receiver from dynamically created receivers.
 	ResourceAttributes resourceAttributes `mapstructure:"resource_attributes"`
+	// Discovery configures the receivers started from the annotations of the discovered pods.
+	Discovery DiscoveryConfig `mapstructure:"discovery"`
+}
+
+// DiscoveryConfig configures the receivers started from the annotations of the discovered pods,
+// in addition to the receivers of the templates.
+type DiscoveryConfig struct {
+	// Enabled starts a receiver for each port endpoint whose pod has the io.opentelemetry.discovery.metrics
+	// annotations. `false` by default.
+	Enabled bool `mapstructure:"enabled"`
+	// AllowedReceivers are the types of receivers that can be started from annotations.
+	AllowedReceivers []component.Type `mapstructure:"allowed_receivers"`
+}
+
+var errNoAllowedReceivers = errors.New("discovery requires at least one of allowed_receivers")
+
+// Validate checks if the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Discovery.Enabled && len(cfg.Discovery.AllowedReceivers) == 0 {
+		return errNoAllowedReceivers
+	}
+	return nil
 }
 
 func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
@@ -105,7 +128,8 @@ func (cfg *Config) Unmarshal(componentParser *confmap.Conf) error {
 
 	for endpointType := range cfg.ResourceAttributes {
 		switch endpointType {
-		case observer.ContainerType, observer.HostPortType, observer.K8sNodeType, observer.PodType, observer.PortType:
+		case observer.ContainerType, observer.HostPortType, observer.K8sNodeType, observer.PodType, observer.PortType,
+			observer.K8sServiceType, observer.K8sIngressType:
 		default:
 			return fmt.Errorf("resource attributes for unsupported endpoint type %q", endpointType)
 		}
@@ -105,14 +105,28 @@ func TestLoadConfig(t *testing.T) {
 					component.NewIDWithName("mock_observer", "with_name"),
 				},
 				ResourceAttributes: map[observer.EndpointType]map[string]string{
-					observer.ContainerType: {"container.key": "container.value"},
-					observer.PodType:       {"pod.key": "pod.value"},
-					observer.PortType:      {"port.key": "port.value"},
-					observer.HostPortType:  {"hostport.key": "hostport.value"},
-					observer.K8sNodeType:   {"k8s.node.key": "k8s.node.value"},
+					observer.ContainerType:  {"container.key": "container.value"},
+					observer.PodType:        {"pod.key": "pod.value"},
+					observer.PortType:       {"port.key": "port.value"},
+					observer.HostPortType:   {"hostport.key": "hostport.value"},
+					observer.K8sNodeType:    {"k8s.node.key": "k8s.node.value"},
+					observer.K8sServiceType: {"k8s.service.key": "k8s.service.value"},
+					observer.K8sIngressType: {"k8s.ingress.key": "k8s.ingress.value"},
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "discovery"),
+			expected: func() component.ReceiverConfig {
+				cfg := createDefaultConfig().(*Config)
+				cfg.WatchObservers = []component.ID{component.NewID("mock_observer")}
+				cfg.Discovery = DiscoveryConfig{
+					Enabled:          true,
+					AllowedReceivers: []component.Type{"redis", "postgresql"},
+				}
+				return cfg
+			}(),
+		},
 	}
 
 	for _, tt := range tests {
@@ -130,6 +144,17 @@ func TestLoadConfig(t *testing.T) {
 	}
 }
 
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Discovery.Enabled = true
+	assert.ErrorIs(t, cfg.Validate(), errNoAllowedReceivers)
+
+	cfg.Discovery.AllowedReceivers = []component.Type{"redis"}
+	assert.NoError(t, cfg.Validate())
+}
+
 func TestInvalidResourceAttributeEndpointType(t *testing.T) {
 	factories, err := componenttest.NopFactories()
 	require.Nil(t, err)
@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/receivercreator"
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"gopkg.in/yaml.v3"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+const (
+	// discoveryAnnotationPrefix is the prefix of the pod annotations configuring a receiver, optionally
+	// followed by .<port> to only apply to a container port.
+	discoveryAnnotationPrefix = "io.opentelemetry.discovery.metrics"
+	// discoveryScraperAnnotation is the name of the annotation holding the type of the receiver.
+	discoveryScraperAnnotation = "scraper"
+	// discoveryConfigAnnotation is the name of the annotation holding the YAML configuration of the receiver.
+	discoveryConfigAnnotation = "config"
+
+	// minAnnotationCollectionInterval is the shortest collection_interval the annotations can set.
+	minAnnotationCollectionInterval = 10 * time.Second
+)
+
+// deniedAnnotationConfigKeys are the configuration keys, at any depth, the annotations can't set,
+// as they would let a pod point the receiver at another target than its own port.
+var deniedAnnotationConfigKeys = map[string]struct{}{
+	"endpoints":      {},
+	"url":            {},
+	"urls":           {},
+	"uri":            {},
+	"host":           {},
+	"hosts":          {},
+	"hostname":       {},
+	"address":        {},
+	"addresses":      {},
+	"addr":           {},
+	"addrs":          {},
+	"servers":        {},
+	"nodes":          {},
+	"targets":        {},
+	"datasource":     {},
+	"data_source":    {},
+	"dsn":            {},
+	"scrape_configs": {},
+	"config":         {},
+}
+
+// receiverFromAnnotations returns the receiver configured by the annotations of the pod of a port endpoint,
+// and whether the pod has such annotations. The annotations of the port take precedence over the
+// annotations of all the ports of the pod.
+func (d *DiscoveryConfig) receiverFromAnnotations(e observer.Endpoint) (receiverConfig, bool, error) {
+	port, ok := e.Details.(*observer.Port)
+	if !ok {
+		return receiverConfig{}, false, nil
+	}
+
+	annotations := port.Pod.Annotations
+	prefix := fmt.Sprintf("%s.%d", discoveryAnnotationPrefix, port.Port)
+	scraper, ok := annotations[prefix+"/"+discoveryScraperAnnotation]
+	if !ok {
+		prefix = discoveryAnnotationPrefix
+		scraper = annotations[prefix+"/"+discoveryScraperAnnotation]
+	}
+	scraper = strings.TrimSpace(scraper)
+	if scraper == "" {
+		return receiverConfig{}, false, nil
+	}
+
+	receiverType := component.Type(scraper)
+	if !d.allowed(receiverType) {
+		return receiverConfig{}, true, fmt.Errorf("receiver %q isn't in allowed_receivers", receiverType)
+	}
+
+	cfg := userConfigMap{}
+	if err := yaml.Unmarshal([]byte(annotations[prefix+"/"+discoveryConfigAnnotation]), &cfg); err != nil {
+		return receiverConfig{}, true, fmt.Errorf("invalid %s/%s annotation: %w", prefix, discoveryConfigAnnotation, err)
+	}
+	// The receiver can only connect to the discovered endpoint.
+	delete(cfg, endpointConfigKey)
+	if err := checkAnnotationConfig(cfg); err != nil {
+		return receiverConfig{}, true, fmt.Errorf("invalid %s/%s annotation: %w", prefix, discoveryConfigAnnotation, err)
+	}
+
+	return receiverConfig{
+		id:         component.NewID(receiverType),
+		config:     cfg,
+		endpointID: e.ID,
+	}, true, nil
+}
+
+// checkAnnotationConfig rejects the configuration set by annotations that could make the receiver
+// connect to other targets, read the files of the Collector, e.g. its TLS keys, or scrape too often.
+func checkAnnotationConfig(cfg userConfigMap) error {
+	if err := checkAnnotationConfigKeys("", cfg); err != nil {
+		return err
+	}
+	if interval, ok := cfg["collection_interval"]; ok {
+		d, err := time.ParseDuration(fmt.Sprint(interval))
+		if err == nil && d < minAnnotationCollectionInterval {
+			return fmt.Errorf("collection_interval must be at least %s", minAnnotationCollectionInterval)
+		}
+	}
+	return nil
+}
+
+func checkAnnotationConfigKeys(path string, cfg userConfigMap) error {
+	keys := make([]string, 0, len(cfg))
+	for key := range cfg {
+		keys = append(keys, key)
+	}
+	// Report the same key on every call.
+	sort.Strings(keys)
+	for _, key := range keys {
+		name := path + key
+		if _, ok := deniedAnnotationConfigKeys[key]; ok || key == endpointConfigKey || strings.HasSuffix(key, "_file") {
+			return fmt.Errorf("%s can't be set by annotations", name)
+		}
+		// Nested maps are decoded with the type of the map they are decoded into.
+		nested, ok := cfg[key].(userConfigMap)
+		if !ok {
+			nested, ok = cfg[key].(map[string]interface{})
+		}
+		if ok {
+			if err := checkAnnotationConfigKeys(name+".", nested); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (d *DiscoveryConfig) allowed(receiverType component.Type) bool {
+	for _, allowed := range d.AllowedReceivers {
+		if allowed == receiverType {
+			return true
+		}
+	}
+	return false
+}
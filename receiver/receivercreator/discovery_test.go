@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package receivercreator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func annotatedPortEndpoint(annotations map[string]string) observer.Endpoint {
+	annotatedPod := pod
+	annotatedPod.Annotations = annotations
+	return observer.Endpoint{
+		ID:     "port-1",
+		Target: "localhost:1234",
+		Details: &observer.Port{
+			Name:      "http",
+			Pod:       annotatedPod,
+			Port:      1234,
+			Transport: observer.ProtocolTCP,
+		},
+	}
+}
+
+func TestReceiverFromAnnotations(t *testing.T) {
+	discovery := DiscoveryConfig{Enabled: true, AllowedReceivers: []component.Type{"redis", "postgresql"}}
+
+	tests := []struct {
+		name        string
+		endpoint    observer.Endpoint
+		expected    receiverConfig
+		found       bool
+		expectedErr string
+	}{
+		{
+			name:     "not a port",
+			endpoint: podEndpoint,
+		},
+		{
+			name:     "no annotations",
+			endpoint: annotatedPortEndpoint(nil),
+		},
+		{
+			name: "pod annotations",
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics/scraper": "redis",
+				"io.opentelemetry.discovery.metrics/config":  "collection_interval: 30s\npassword: secret\n",
+			}),
+			expected: receiverConfig{
+				id:         component.NewID("redis"),
+				config:     userConfigMap{"collection_interval": "30s", "password": "secret"},
+				endpointID: "port-1",
+			},
+			found: true,
+		},
+		{
+			name: "port annotations take precedence",
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics/scraper":      "redis",
+				"io.opentelemetry.discovery.metrics.1234/scraper": "postgresql",
+				"io.opentelemetry.discovery.metrics.1234/config":  "username: otel",
+			}),
+			expected: receiverConfig{
+				id:         component.NewID("postgresql"),
+				config:     userConfigMap{"username": "otel"},
+				endpointID: "port-1",
+			},
+			found: true,
+		},
+		{
+			name: "annotations of another port",
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics.5432/scraper": "postgresql",
+			}),
+		},
+		{
+			name: "endpoint is ignored",
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics/scraper": "redis",
+				"io.opentelemetry.discovery.metrics/config":  "endpoint: metadata.google.internal:80",
+			}),
+			expected: receiverConfig{
+				id:         component.NewID("redis"),
+				config:     userConfigMap{},
+				endpointID: "port-1",
+			},
+			found: true,
+		},
+		{
+			name: "target key",
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics/scraper": "postgresql",
+				"io.opentelemetry.discovery.metrics/config":  "username: otel\ndatasource: host=10.0.0.1\n",
+			}),
+			found:       true,
+			expectedErr: "datasource can't be set by annotations",
+		},
+		{
+			name: "file key",
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics/scraper": "redis",
+				"io.opentelemetry.discovery.metrics/config":  "tls:\n  insecure: false\n  key_file: /etc/otel/tls.key\n",
+			}),
+			found:       true,
+			expectedErr: "tls.key_file can't be set by annotations",
+		},
+		{
+			name: "short collection interval",
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics/scraper": "redis",
+				"io.opentelemetry.discovery.metrics/config":  "collection_interval: 10ms",
+			}),
+			found:       true,
+			expectedErr: "collection_interval must be at least 10s",
+		},
+		{
+			name: "receiver not allowed",
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics/scraper": "filelog",
+			}),
+			found:       true,
+			expectedErr: `receiver "filelog" isn't in allowed_receivers`,
+		},
+		{
+			name: "invalid config",
+			endpoint: annotatedPortEndpoint(map[string]string{
+				"io.opentelemetry.discovery.metrics/scraper": "redis",
+				"io.opentelemetry.discovery.metrics/config":  "- not a map",
+			}),
+			found:       true,
+			expectedErr: "invalid io.opentelemetry.discovery.metrics/config annotation",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rcvrCfg, found, err := discovery.receiverFromAnnotations(tt.endpoint)
+			assert.Equal(t, tt.found, found)
+			if tt.expectedErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, rcvrCfg)
+		})
+	}
+}
@@ -103,6 +103,40 @@ var k8sNodeEndpoint = observer.Endpoint{
 	},
 }
 
+var k8sServiceEndpoint = observer.Endpoint{
+	ID:     "k8s.service-1",
+	Target: "redis.default.svc",
+	Details: &observer.K8sService{
+		Name:        "redis",
+		UID:         "uid-2",
+		Namespace:   "default",
+		ServiceType: "ClusterIP",
+		ClusterIP:   "10.0.0.1",
+		Labels: map[string]string{
+			"app": "redis",
+		},
+		Ports: []observer.K8sServicePort{
+			{Name: "redis", Port: 6379, Transport: observer.ProtocolTCP},
+		},
+	},
+}
+
+var k8sIngressEndpoint = observer.Endpoint{
+	ID:     "k8s.ingress-1",
+	Target: "https://example.com/api",
+	Details: &observer.K8sIngress{
+		Name:      "api",
+		UID:       "uid-3",
+		Namespace: "default",
+		Scheme:    "https",
+		Host:      "example.com",
+		Path:      "/api",
+		Annotations: map[string]string{
+			"monitoring/probe": "true",
+		},
+	},
+}
+
 var unsupportedEndpoint = observer.Endpoint{
 	ID:      "endpoint-1",
 	Target:  "localhost:1234",
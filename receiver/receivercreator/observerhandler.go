@@ -157,7 +157,48 @@ func (obs *observerHandler) OnAdd(added []observer.Endpoint) {
 
 			obs.receiversByEndpointID.Put(e.ID, rcvr)
 		}
+
+		if obs.config.Discovery.Enabled {
+			obs.startDiscoveredReceiver(e, env)
+		}
+	}
+}
+
+// startDiscoveredReceiver starts the receiver configured by the annotations of the endpoint, if any.
+func (obs *observerHandler) startDiscoveredReceiver(e observer.Endpoint, env observer.EndpointEnv) {
+	rcvrCfg, found, err := obs.config.Discovery.receiverFromAnnotations(e)
+	if err != nil {
+		obs.logger.Error("invalid receiver annotations", zap.String("endpoint_id", string(e.ID)), zap.Error(err))
+		return
+	}
+	if !found {
+		return
 	}
+
+	obs.logger.Info("starting receiver from annotations",
+		zap.String("name", rcvrCfg.id.String()),
+		zap.String("endpoint", e.Target),
+		zap.String("endpoint_id", string(e.ID)))
+
+	resourceEnhancer, err := newResourceEnhancer(
+		obs.config.ResourceAttributes,
+		map[string]string{},
+		env,
+		e,
+		obs.nextConsumer,
+	)
+	if err != nil {
+		obs.logger.Error("failed creating resource enhancer", zap.String("receiver", rcvrCfg.id.String()), zap.Error(err))
+		return
+	}
+
+	rcvr, err := obs.runner.start(rcvrCfg, userConfigMap{endpointConfigKey: e.Target}, resourceEnhancer)
+	if err != nil {
+		obs.logger.Error("failed to start receiver", zap.String("receiver", rcvrCfg.id.String()), zap.Error(err))
+		return
+	}
+
+	obs.receiversByEndpointID.Put(e.ID, rcvr)
 }
 
 // OnRemove responds to endpoint removal notifications.
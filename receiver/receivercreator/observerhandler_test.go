@@ -77,6 +77,46 @@ func TestOnAdd(t *testing.T) {
 	assert.Equal(t, 1, handler.receiversByEndpointID.Size())
 }
 
+func TestOnAddDiscovery(t *testing.T) {
+	runner := &mockRunner{}
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Discovery = DiscoveryConfig{Enabled: true, AllowedReceivers: []component.Type{"redis"}}
+	handler := &observerHandler{
+		config:                cfg,
+		logger:                zap.NewNop(),
+		receiversByEndpointID: receiverMap{},
+		runner:                runner,
+	}
+
+	runner.On(
+		"start",
+		receiverConfig{id: component.NewID("redis"), config: userConfigMap{"password": "secret"}, endpointID: "port-1"},
+		userConfigMap{endpointConfigKey: "localhost:1234"},
+		mock.IsType(&resourceEnhancer{}),
+	).Return(&nopWithEndpointReceiver{}, nil)
+
+	handler.OnAdd([]observer.Endpoint{
+		annotatedPortEndpoint(map[string]string{
+			"io.opentelemetry.discovery.metrics/scraper": "redis",
+			"io.opentelemetry.discovery.metrics/config":  "password: secret",
+		}),
+		podEndpoint,
+	})
+
+	runner.AssertExpectations(t)
+	assert.Equal(t, 1, handler.receiversByEndpointID.Size())
+
+	// Annotations are ignored when discovery isn't enabled.
+	cfg.Discovery.Enabled = false
+	handler.OnAdd([]observer.Endpoint{
+		annotatedPortEndpoint(map[string]string{
+			"io.opentelemetry.discovery.metrics/scraper": "redis",
+		}),
+	})
+	runner.AssertNumberOfCalls(t, "start", 1)
+}
+
 func TestOnRemove(t *testing.T) {
 	runner := &mockRunner{}
 	rcvr := &nopWithEndpointReceiver{}
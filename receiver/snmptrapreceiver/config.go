@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmptrapreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver"
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"go.opentelemetry.io/collector/config"
+	"go.uber.org/multierr"
+)
+
+const (
+	defaultEndpoint = "0.0.0.0:162"
+
+	securityLevelNoAuthNoPriv = "no_auth_no_priv"
+	securityLevelAuthNoPriv   = "auth_no_priv"
+	securityLevelAuthPriv     = "auth_priv"
+)
+
+var (
+	errEmptyEndpoint        = errors.New("endpoint must be specified")
+	errBadEngineID          = errors.New("engine_id must be a hex encoded string of 5 to 32 bytes")
+	errEmptyUser            = errors.New("user must be specified")
+	errBadSecurityLevel     = errors.New("security_level must be either no_auth_no_priv, auth_no_priv, or auth_priv")
+	errBadAuthType          = errors.New("auth_type must be either MD5, SHA, SHA224, SHA256, SHA384, SHA512")
+	errEmptyAuthPassword    = errors.New("auth_password must be specified when security_level is auth_no_priv or auth_priv")
+	errBadPrivacyType       = errors.New("privacy_type must be either DES, AES, AES192, AES192C, AES256, AES256C")
+	errEmptyPrivacyPassword = errors.New("privacy_password must be specified when security_level is auth_priv")
+)
+
+// Config defines the configuration for the SNMP trap receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"`
+
+	// Endpoint is the UDP address traps and informs are received on.
+	// Default: 0.0.0.0:162
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Communities restricts the v1 and v2c traps and informs accepted to the ones sent with
+	// one of these community strings. All community strings are accepted when empty.
+	Communities []string `mapstructure:"communities"`
+
+	// EngineID is the hex encoded SNMPv3 engine ID of the receiver. Senders of v3 informs
+	// discover it, or can be configured with it. A random engine ID is used when empty.
+	EngineID string `mapstructure:"engine_id"`
+
+	// Users are the SNMPv3 USM users traps and informs are accepted from.
+	// v3 messages are dropped when no user is configured.
+	Users []UserConfig `mapstructure:"users"`
+
+	// OIDNames maps OIDs to names, typically derived from MIBs. Varbinds and trap OIDs are named
+	// after the longest matching OID prefix, followed by the remaining OID suffix.
+	OIDNames map[string]string `mapstructure:"oid_names"`
+
+	// OIDNamesFile is the path of a YAML file holding more OID to name mappings, in the same format
+	// as OIDNames. The entries of OIDNames take precedence.
+	OIDNamesFile string `mapstructure:"oid_names_file"`
+}
+
+// UserConfig is an SNMPv3 user of the User-based Security Model.
+type UserConfig struct {
+	// User is the name of the user.
+	User string `mapstructure:"user"`
+
+	// SecurityLevel is the security level of the messages sent by this user, messages
+	// with a different security level are dropped.
+	// Valid options: “no_auth_no_priv”, “auth_no_priv”, “auth_priv”
+	// Default: "no_auth_no_priv"
+	SecurityLevel string `mapstructure:"security_level"`
+
+	// AuthType is the authentication protocol of the user.
+	// Required if SecurityLevel is "auth_no_priv" or "auth_priv".
+	// Valid options: “MD5”, “SHA”, “SHA224”, “SHA256”, “SHA384”, “SHA512”
+	AuthType string `mapstructure:"auth_type"`
+
+	// AuthPassword is the authentication password of the user.
+	// Required if SecurityLevel is "auth_no_priv" or "auth_priv".
+	AuthPassword string `mapstructure:"auth_password"`
+
+	// PrivacyType is the privacy protocol of the user.
+	// Required if SecurityLevel is "auth_priv".
+	// Valid options: “DES”, “AES”, “AES192”, “AES192C”, “AES256”, “AES256C”
+	PrivacyType string `mapstructure:"privacy_type"`
+
+	// PrivacyPassword is the privacy password of the user.
+	// Required if SecurityLevel is "auth_priv".
+	PrivacyPassword string `mapstructure:"privacy_password"`
+}
+
+// Validate validates the given config, returning an error specifying any issues with the config.
+func (cfg *Config) Validate() error {
+	var combinedErr error
+
+	if cfg.Endpoint == "" {
+		combinedErr = multierr.Append(combinedErr, errEmptyEndpoint)
+	} else if _, err := net.ResolveUDPAddr("udp", cfg.Endpoint); err != nil {
+		combinedErr = multierr.Append(combinedErr, fmt.Errorf("invalid endpoint %q: %w", cfg.Endpoint, err))
+	}
+
+	if cfg.EngineID != "" {
+		if engineID, err := hex.DecodeString(cfg.EngineID); err != nil || len(engineID) < 5 || len(engineID) > 32 {
+			combinedErr = multierr.Append(combinedErr, errBadEngineID)
+		}
+	}
+
+	users := make(map[string]struct{}, len(cfg.Users))
+	for _, user := range cfg.Users {
+		if _, ok := users[user.User]; ok {
+			combinedErr = multierr.Append(combinedErr, fmt.Errorf("user %q is defined more than once", user.User))
+		}
+		users[user.User] = struct{}{}
+		combinedErr = multierr.Append(combinedErr, user.validate())
+	}
+
+	return combinedErr
+}
+
+func (u *UserConfig) validate() error {
+	if u.User == "" {
+		return errEmptyUser
+	}
+
+	var combinedErr error
+	switch strings.ToLower(u.SecurityLevel) {
+	case "", securityLevelNoAuthNoPriv:
+		return nil
+	case securityLevelAuthNoPriv:
+	case securityLevelAuthPriv:
+		if _, ok := privacyProtocols[strings.ToUpper(u.PrivacyType)]; !ok {
+			combinedErr = multierr.Append(combinedErr, errBadPrivacyType)
+		}
+		if u.PrivacyPassword == "" {
+			combinedErr = multierr.Append(combinedErr, errEmptyPrivacyPassword)
+		}
+	default:
+		return fmt.Errorf("user %q: %w", u.User, errBadSecurityLevel)
+	}
+
+	if _, ok := authProtocols[strings.ToUpper(u.AuthType)]; !ok {
+		combinedErr = multierr.Append(combinedErr, errBadAuthType)
+	}
+	if u.AuthPassword == "" {
+		combinedErr = multierr.Append(combinedErr, errEmptyAuthPassword)
+	}
+	if combinedErr != nil {
+		return fmt.Errorf("user %q: %w", u.User, combinedErr)
+	}
+	return nil
+}
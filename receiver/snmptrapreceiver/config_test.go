@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmptrapreceiver
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id          component.ID
+		expected    component.ReceiverConfig
+		errorString string
+	}{
+		{
+			id:       component.NewIDWithName(typeStr, ""),
+			expected: createDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(typeStr, "customname"),
+			expected: &Config{
+				ReceiverSettings: config.NewReceiverSettings(component.NewID(typeStr)),
+				Endpoint:         "0.0.0.0:1162",
+				Communities:      []string{"private"},
+				EngineID:         "80000000057465737472",
+				Users: []UserConfig{
+					{
+						User:            "monitoring",
+						SecurityLevel:   "auth_priv",
+						AuthType:        "SHA256",
+						AuthPassword:    "authpassword",
+						PrivacyType:     "AES",
+						PrivacyPassword: "privpassword",
+					},
+					{
+						User:          "legacy",
+						SecurityLevel: "auth_no_priv",
+						AuthType:      "MD5",
+						AuthPassword:  "legacypassword",
+					},
+				},
+				OIDNames: map[string]string{
+					"1.3.6.1.6.3.1.1.5.3": "linkDown",
+					"1.3.6.1.2.1.2.2.1.1": "ifIndex",
+				},
+				OIDNamesFile: "testdata/oid_names.yaml",
+			},
+		},
+		{
+			id: component.NewIDWithName(typeStr, "bad_user"),
+			errorString: `user "monitoring": privacy_password must be specified when security_level is auth_priv; ` +
+				`auth_type must be either MD5, SHA, SHA224, SHA256, SHA384, SHA512; ` +
+				`auth_password must be specified when security_level is auth_no_priv or auth_priv`,
+		},
+		{
+			id:          component.NewIDWithName(typeStr, "bad_engine_id"),
+			errorString: errBadEngineID.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalReceiverConfig(sub, cfg))
+
+			if tt.errorString != "" {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.errorString)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidateUsers(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Users = []UserConfig{{User: "monitoring"}, {User: "monitoring", SecurityLevel: "auth"}, {}}
+	assert.EqualError(t, cfg.Validate(), `user "monitoring" is defined more than once; `+
+		`user "monitoring": security_level must be either no_auth_no_priv, auth_no_priv, or auth_priv; `+
+		`user must be specified`)
+}
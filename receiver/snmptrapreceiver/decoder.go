@@ -0,0 +1,313 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmptrapreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver"
+
+import (
+	"crypto/rand"
+	"encoding/asn1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+)
+
+// usmStatsUnknownEngineIDs is the counter reported to senders discovering the engine ID, RFC 3414 section 3.2.3.
+const usmStatsUnknownEngineIDs = ".1.3.6.1.6.3.15.1.1.4.0"
+
+var (
+	errUnsupportedVersion = errors.New("unsupported SNMP version")
+	errUnexpectedPDU      = errors.New("message is neither a trap nor an inform")
+	errUnknownCommunity   = errors.New("unknown community")
+	errUnknownUser        = errors.New("unknown user")
+	errSecurityLevel      = errors.New("security level does not match the one of the user")
+	errNotAuthentic       = errors.New("message is not authentic")
+)
+
+var authProtocols = map[string]gosnmp.SnmpV3AuthProtocol{
+	"MD5":    gosnmp.MD5,
+	"SHA":    gosnmp.SHA,
+	"SHA224": gosnmp.SHA224,
+	"SHA256": gosnmp.SHA256,
+	"SHA384": gosnmp.SHA384,
+	"SHA512": gosnmp.SHA512,
+}
+
+// authParamsLength is the length of the truncated digest carried in the messages, RFC 3414 and RFC 7860.
+var authParamsLength = map[gosnmp.SnmpV3AuthProtocol]int{
+	gosnmp.MD5:    12,
+	gosnmp.SHA:    12,
+	gosnmp.SHA224: 16,
+	gosnmp.SHA256: 24,
+	gosnmp.SHA384: 32,
+	gosnmp.SHA512: 48,
+}
+
+var privacyProtocols = map[string]gosnmp.SnmpV3PrivProtocol{
+	"DES":     gosnmp.DES,
+	"AES":     gosnmp.AES,
+	"AES192":  gosnmp.AES192,
+	"AES192C": gosnmp.AES192C,
+	"AES256":  gosnmp.AES256,
+	"AES256C": gosnmp.AES256C,
+}
+
+// messageVersion is the start of every SNMP message, used to find out the version before decoding it.
+type messageVersion struct {
+	Version int
+	Rest    asn1.RawValue
+}
+
+// v3Message is the SNMPv3 message header, RFC 3412 section 6.
+type v3Message struct {
+	Version    int
+	GlobalData struct {
+		MsgID         int
+		MsgMaxSize    int
+		MsgFlags      []byte
+		SecurityModel int
+	}
+	SecurityParameters []byte
+	Data               asn1.RawValue
+}
+
+// usmSecurityParameters are the security parameters of the User-based Security Model, RFC 3414 section 2.4.
+type usmSecurityParameters struct {
+	AuthoritativeEngineID    []byte
+	AuthoritativeEngineBoots int
+	AuthoritativeEngineTime  int
+	UserName                 []byte
+	AuthenticationParameters []byte
+	PrivacyParameters        []byte
+}
+
+// usmUser decodes the messages of one SNMPv3 user.
+type usmUser struct {
+	snmp     *gosnmp.GoSNMP
+	msgFlags gosnmp.SnmpV3MsgFlags
+}
+
+// trapDecoder decodes and authenticates the traps and informs received.
+type trapDecoder struct {
+	communities map[string]struct{}
+	// community decodes v1 and v2c messages.
+	community *gosnmp.GoSNMP
+	users     map[string]*usmUser
+	// discovery decodes the unauthenticated v3 messages sent to discover the engine ID.
+	discovery *gosnmp.GoSNMP
+
+	engineID         string
+	startTime        time.Time
+	unknownEngineIDs uint32
+}
+
+func newTrapDecoder(cfg *Config) (*trapDecoder, error) {
+	engineID, err := hex.DecodeString(cfg.EngineID)
+	if err != nil {
+		return nil, err
+	}
+	if len(engineID) == 0 {
+		if engineID, err = randomEngineID(); err != nil {
+			return nil, err
+		}
+	}
+
+	d := &trapDecoder{
+		community: &gosnmp.GoSNMP{Version: gosnmp.Version2c},
+		users:     make(map[string]*usmUser, len(cfg.Users)),
+		discovery: &gosnmp.GoSNMP{
+			Version:            gosnmp.Version3,
+			SecurityModel:      gosnmp.UserSecurityModel,
+			MsgFlags:           gosnmp.NoAuthNoPriv,
+			SecurityParameters: &gosnmp.UsmSecurityParameters{},
+		},
+		engineID:  string(engineID),
+		startTime: time.Now(),
+	}
+	if len(cfg.Communities) != 0 {
+		d.communities = make(map[string]struct{}, len(cfg.Communities))
+		for _, community := range cfg.Communities {
+			d.communities[community] = struct{}{}
+		}
+	}
+
+	for _, user := range cfg.Users {
+		securityParams := &gosnmp.UsmSecurityParameters{
+			UserName:              user.User,
+			AuthoritativeEngineID: d.engineID,
+		}
+		msgFlags := gosnmp.NoAuthNoPriv
+		switch strings.ToLower(user.SecurityLevel) {
+		case securityLevelAuthPriv:
+			msgFlags = gosnmp.AuthPriv
+			securityParams.PrivacyProtocol = privacyProtocols[strings.ToUpper(user.PrivacyType)]
+			securityParams.PrivacyPassphrase = user.PrivacyPassword
+			fallthrough
+		case securityLevelAuthNoPriv:
+			msgFlags |= gosnmp.AuthNoPriv
+			securityParams.AuthenticationProtocol = authProtocols[strings.ToUpper(user.AuthType)]
+			securityParams.AuthenticationPassphrase = user.AuthPassword
+		}
+		d.users[user.User] = &usmUser{
+			snmp: &gosnmp.GoSNMP{
+				Version:            gosnmp.Version3,
+				SecurityModel:      gosnmp.UserSecurityModel,
+				MsgFlags:           msgFlags,
+				SecurityParameters: securityParams,
+			},
+			msgFlags: msgFlags,
+		}
+	}
+	return d, nil
+}
+
+// decode returns the trap or inform held by the message. Messages discovering the engine ID
+// are answered with the report returned instead.
+func (d *trapDecoder) decode(msg []byte) (packet *gosnmp.SnmpPacket, report *gosnmp.SnmpPacket, err error) {
+	var header messageVersion
+	if _, err = asn1.Unmarshal(msg, &header); err != nil {
+		return nil, nil, fmt.Errorf("invalid SNMP message: %w", err)
+	}
+
+	switch gosnmp.SnmpVersion(header.Version) {
+	case gosnmp.Version1, gosnmp.Version2c:
+		packet, err = d.community.UnmarshalTrap(msg, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		if d.communities != nil {
+			if _, ok := d.communities[packet.Community]; !ok {
+				return nil, nil, errUnknownCommunity
+			}
+		}
+	case gosnmp.Version3:
+		return d.decodeV3(msg)
+	default:
+		return nil, nil, fmt.Errorf("%w: %d", errUnsupportedVersion, header.Version)
+	}
+
+	if err = checkPDUType(packet); err != nil {
+		return nil, nil, err
+	}
+	return packet, nil, nil
+}
+
+func (d *trapDecoder) decodeV3(msg []byte) (*gosnmp.SnmpPacket, *gosnmp.SnmpPacket, error) {
+	var header v3Message
+	if _, err := asn1.Unmarshal(msg, &header); err != nil {
+		return nil, nil, fmt.Errorf("invalid SNMPv3 message: %w", err)
+	}
+	if gosnmp.SnmpV3SecurityModel(header.GlobalData.SecurityModel) != gosnmp.UserSecurityModel || len(header.GlobalData.MsgFlags) != 1 {
+		return nil, nil, errors.New("unsupported SNMPv3 security model")
+	}
+	var usm usmSecurityParameters
+	if _, err := asn1.Unmarshal(header.SecurityParameters, &usm); err != nil {
+		return nil, nil, fmt.Errorf("invalid SNMPv3 security parameters: %w", err)
+	}
+	msgFlags := gosnmp.SnmpV3MsgFlags(header.GlobalData.MsgFlags[0])
+
+	// RFC 3411 section 5, engine IDs are 5 to 32 octets long: anything shorter is a discovery.
+	if len(usm.AuthoritativeEngineID) < 5 {
+		if msgFlags&gosnmp.Reportable == 0 {
+			return nil, nil, errors.New("unknown engine ID")
+		}
+		report, err := d.discoveryReport(msg)
+		return nil, report, err
+	}
+
+	user, ok := d.users[string(usm.UserName)]
+	if !ok {
+		return nil, nil, fmt.Errorf("%w %q", errUnknownUser, usm.UserName)
+	}
+	if msgFlags&gosnmp.AuthPriv != user.msgFlags {
+		return nil, nil, fmt.Errorf("%w %q", errSecurityLevel, usm.UserName)
+	}
+	// gosnmp only compares the digest bytes present in the message.
+	if user.msgFlags&gosnmp.AuthNoPriv != 0 {
+		securityParams := user.snmp.SecurityParameters.(*gosnmp.UsmSecurityParameters)
+		if len(usm.AuthenticationParameters) != authParamsLength[securityParams.AuthenticationProtocol] {
+			return nil, nil, errNotAuthentic
+		}
+	}
+
+	packet, err := user.snmp.UnmarshalTrap(msg, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err = checkPDUType(packet); err != nil {
+		return nil, nil, err
+	}
+	return packet, nil, nil
+}
+
+// discoveryReport returns the report telling the engine ID of the receiver, RFC 3414 section 4.
+func (d *trapDecoder) discoveryReport(msg []byte) (*gosnmp.SnmpPacket, error) {
+	request, err := d.discovery.UnmarshalTrap(msg, false)
+	if err != nil {
+		return nil, err
+	}
+	return &gosnmp.SnmpPacket{
+		Version:       gosnmp.Version3,
+		MsgFlags:      gosnmp.NoAuthNoPriv,
+		SecurityModel: gosnmp.UserSecurityModel,
+		SecurityParameters: &gosnmp.UsmSecurityParameters{
+			AuthoritativeEngineID:    d.engineID,
+			AuthoritativeEngineBoots: 1,
+			AuthoritativeEngineTime:  uint32(time.Since(d.startTime).Seconds()),
+		},
+		ContextEngineID: d.engineID,
+		ContextName:     request.ContextName,
+		PDUType:         gosnmp.Report,
+		MsgID:           request.MsgID,
+		RequestID:       request.RequestID,
+		MsgMaxSize:      request.MsgMaxSize,
+		Variables: []gosnmp.SnmpPDU{{
+			Name:  usmStatsUnknownEngineIDs,
+			Type:  gosnmp.Counter32,
+			Value: atomic.AddUint32(&d.unknownEngineIDs, 1),
+		}},
+	}, nil
+}
+
+func checkPDUType(packet *gosnmp.SnmpPacket) error {
+	switch packet.PDUType {
+	case gosnmp.Trap, gosnmp.SNMPv2Trap, gosnmp.InformRequest:
+		return nil
+	default:
+		return fmt.Errorf("%w: %v", errUnexpectedPDU, packet.PDUType)
+	}
+}
+
+// acknowledgement returns the response to the inform, RFC 3416 section 4.2.7.
+func acknowledgement(inform *gosnmp.SnmpPacket) *gosnmp.SnmpPacket {
+	response := *inform
+	response.PDUType = gosnmp.GetResponse
+	response.MsgFlags &^= gosnmp.Reportable
+	response.Error = gosnmp.NoError
+	response.ErrorIndex = 0
+	return &response
+}
+
+// randomEngineID returns an engine ID in the RFC 3411 format of administratively assigned octets.
+func randomEngineID() ([]byte, error) {
+	engineID := []byte{0x80, 0x00, 0x00, 0x00, 0x05, 0, 0, 0, 0, 0, 0, 0, 0}
+	if _, err := rand.Read(engineID[5:]); err != nil {
+		return nil, err
+	}
+	return engineID, nil
+}
@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmptrapreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver"
+
+import (
+	"encoding/hex"
+	"net"
+	"strconv"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gosnmp/gosnmp"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+const (
+	attributeVersion      = "snmp.version"
+	attributePDUType      = "snmp.pdu_type"
+	attributeTrapOID      = "snmp.trap_oid"
+	attributeTrapName     = "snmp.trap_name"
+	attributeUptime       = "snmp.uptime"
+	attributeUser         = "snmp.user"
+	attributeEngineID     = "snmp.engine_id"
+	attributeContextName  = "snmp.context_name"
+	attributeEnterprise   = "snmp.enterprise"
+	attributeAgentAddress = "snmp.agent_address"
+	attributeGenericTrap  = "snmp.generic_trap"
+	attributeSpecificTrap = "snmp.specific_trap"
+)
+
+const (
+	// sysUpTimeOID and snmpTrapOID are the first two varbinds of v2c and v3 traps, RFC 3416 section 4.2.6.
+	sysUpTimeOID   = "1.3.6.1.2.1.1.3.0"
+	snmpTrapOID    = "1.3.6.1.6.3.1.1.4.1.0"
+	snmpTrapsOID   = "1.3.6.1.6.3.1.1.5"
+	enterpriseTrap = 6
+)
+
+// toLogs converts the trap or inform to a log record. The body is the trap name, or the trap
+// OID when it is unknown, and the varbinds are recorded as attributes.
+func toLogs(packet *gosnmp.SnmpPacket, addr net.Addr, names oidNames, now time.Time) plog.Logs {
+	logs := plog.NewLogs()
+	record := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	record.SetTimestamp(pcommon.NewTimestampFromTime(now))
+	record.SetObservedTimestamp(pcommon.NewTimestampFromTime(now))
+
+	attrs := record.Attributes()
+	attrs.PutStr(attributeVersion, "v"+packet.Version.String())
+	if packet.PDUType == gosnmp.InformRequest {
+		attrs.PutStr(attributePDUType, "inform")
+	} else {
+		attrs.PutStr(attributePDUType, "trap")
+	}
+	if udpAddr, ok := addr.(*net.UDPAddr); ok {
+		attrs.PutStr(conventions.AttributeNetPeerIP, udpAddr.IP.String())
+		attrs.PutInt(conventions.AttributeNetPeerPort, int64(udpAddr.Port))
+	}
+
+	if securityParams, ok := packet.SecurityParameters.(*gosnmp.UsmSecurityParameters); ok {
+		attrs.PutStr(attributeUser, securityParams.UserName)
+		attrs.PutStr(attributeEngineID, hex.EncodeToString([]byte(securityParams.AuthoritativeEngineID)))
+		if packet.ContextName != "" {
+			attrs.PutStr(attributeContextName, packet.ContextName)
+		}
+	}
+
+	var trapOID string
+	if packet.PDUType == gosnmp.Trap {
+		// v1 traps are identified by their header, RFC 3584 section 3.1.
+		enterprise := normalizeOID(packet.Enterprise)
+		attrs.PutStr(attributeEnterprise, enterprise)
+		attrs.PutStr(attributeAgentAddress, packet.AgentAddress)
+		attrs.PutInt(attributeGenericTrap, int64(packet.GenericTrap))
+		attrs.PutInt(attributeSpecificTrap, int64(packet.SpecificTrap))
+		attrs.PutInt(attributeUptime, int64(packet.Timestamp))
+		if packet.GenericTrap == enterpriseTrap {
+			trapOID = enterprise + ".0." + strconv.Itoa(packet.SpecificTrap)
+		} else {
+			trapOID = snmpTrapsOID + "." + strconv.Itoa(packet.GenericTrap+1)
+		}
+	}
+
+	for _, variable := range packet.Variables {
+		oid := normalizeOID(variable.Name)
+		switch {
+		case oid == sysUpTimeOID && packet.PDUType != gosnmp.Trap:
+			putValue(attrs, attributeUptime, variable, names)
+			continue
+		case oid == snmpTrapOID && variable.Type == gosnmp.ObjectIdentifier:
+			if value, ok := variable.Value.(string); ok {
+				trapOID = normalizeOID(value)
+				continue
+			}
+		}
+		name, _ := names.resolve(oid)
+		putValue(attrs, name, variable, names)
+	}
+
+	if trapOID != "" {
+		attrs.PutStr(attributeTrapOID, trapOID)
+		if name, ok := names.resolve(trapOID); ok {
+			attrs.PutStr(attributeTrapName, name)
+			record.Body().SetStr(name)
+		} else {
+			record.Body().SetStr(trapOID)
+		}
+	}
+	return logs
+}
+
+// putValue records the value of the varbind. Octet strings are recorded as strings when
+// printable and as bytes otherwise, OIDs are resolved to names.
+func putValue(attrs pcommon.Map, key string, variable gosnmp.SnmpPDU, names oidNames) {
+	switch value := variable.Value.(type) {
+	case int:
+		attrs.PutInt(key, int64(value))
+	case uint:
+		attrs.PutInt(key, int64(value))
+	case uint32:
+		attrs.PutInt(key, int64(value))
+	case uint64:
+		// Counter64 values above the int64 range wrap around.
+		attrs.PutInt(key, int64(value))
+	case float32:
+		attrs.PutDouble(key, float64(value))
+	case float64:
+		attrs.PutDouble(key, value)
+	case []byte:
+		if isPrintable(value) {
+			attrs.PutStr(key, string(value))
+		} else {
+			attrs.PutEmptyBytes(key).FromRaw(value)
+		}
+	case string:
+		if variable.Type == gosnmp.ObjectIdentifier {
+			value, _ = names.resolve(value)
+		}
+		attrs.PutStr(key, value)
+	default:
+		// Null, noSuchObject, noSuchInstance and endOfMibView carry no value.
+		attrs.PutEmpty(key)
+	}
+}
+
+func isPrintable(value []byte) bool {
+	if !utf8.Valid(value) {
+		return false
+	}
+	for _, r := range string(value) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmptrapreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver"
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// oidNames resolves OIDs to the names of their longest known prefix.
+type oidNames map[string]string
+
+// loadOIDNames returns the OID names of the file merged with the ones of the configuration.
+func loadOIDNames(cfg *Config) (oidNames, error) {
+	names := oidNames{}
+	if cfg.OIDNamesFile != "" {
+		content, err := os.ReadFile(filepath.Clean(cfg.OIDNamesFile))
+		if err != nil {
+			return nil, err
+		}
+		fileNames := map[string]string{}
+		if err = yaml.Unmarshal(content, &fileNames); err != nil {
+			return nil, fmt.Errorf("failed to parse %q: %w", cfg.OIDNamesFile, err)
+		}
+		for oid, name := range fileNames {
+			names[normalizeOID(oid)] = name
+		}
+	}
+	for oid, name := range cfg.OIDNames {
+		names[normalizeOID(oid)] = name
+	}
+	return names, nil
+}
+
+// resolve returns the name of the longest known prefix of the OID, followed by the rest of the OID,
+// for instance ifIndex.3 for 1.3.6.1.2.1.2.2.1.1.3. The OID is returned as is when no prefix is known.
+func (n oidNames) resolve(oid string) (string, bool) {
+	oid = normalizeOID(oid)
+	for prefix := oid; prefix != ""; {
+		if name, ok := n[prefix]; ok {
+			return name + oid[len(prefix):], true
+		}
+		i := strings.LastIndexByte(prefix, '.')
+		if i < 0 {
+			break
+		}
+		prefix = prefix[:i]
+	}
+	return oid, false
+}
+
+// normalizeOID removes the leading dot of the OIDs decoded by gosnmp.
+func normalizeOID(oid string) string {
+	return strings.TrimPrefix(oid, ".")
+}
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmptrapreceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadOIDNames(t *testing.T) {
+	names, err := loadOIDNames(&Config{
+		OIDNames: map[string]string{
+			"1.3.6.1.2.1.2.2.1.1":  "ifIndex",
+			".1.3.6.1.6.3.1.1.5.3": "linkDown",
+		},
+		OIDNamesFile: "testdata/oid_names.yaml",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, oidNames{
+		"1.3.6.1.2.1.2.2.1.1": "ifIndex",
+		"1.3.6.1.2.1.2.2.1.2": "ifDescr",
+		"1.3.6.1.6.3.1.1.5.3": "linkDown",
+		"1.3.6.1.6.3.1.1.5.4": "linkUp",
+	}, names)
+
+	_, err = loadOIDNames(&Config{OIDNamesFile: "testdata/missing.yaml"})
+	assert.Error(t, err)
+}
+
+func TestResolveOID(t *testing.T) {
+	names := oidNames{
+		"1.3.6.1.2.1.2.2.1.1": "ifIndex",
+		"1.3.6.1.2.1.2.2.1":   "ifEntry",
+		"1.3.6.1.2.1.2.2.1.2": "ifDescr",
+	}
+	tests := []struct {
+		oid      string
+		expected string
+		resolved bool
+	}{
+		{oid: ".1.3.6.1.2.1.2.2.1.1", expected: "ifIndex", resolved: true},
+		{oid: "1.3.6.1.2.1.2.2.1.1.12", expected: "ifIndex.12", resolved: true},
+		{oid: "1.3.6.1.2.1.2.2.1.7.3", expected: "ifEntry.7.3", resolved: true},
+		// prefixes match whole sub-identifiers only
+		{oid: "1.3.6.1.2.1.2.2.1.10", expected: "ifEntry.10", resolved: true},
+		{oid: "1.3.6.1.4.1.99999.1", expected: "1.3.6.1.4.1.99999.1"},
+	}
+	for _, tt := range tests {
+		name, resolved := names.resolve(tt.oid)
+		assert.Equal(t, tt.expected, name, tt.oid)
+		assert.Equal(t, tt.resolved, resolved, tt.oid)
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmptrapreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/snmptrapreceiver"
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+const (
+	dataFormat = "snmp"
+
+	// maxMessageSize is the largest UDP payload.
+	maxMessageSize = 65535
+)
+
+// trapReceiver receives SNMP traps and informs over UDP.
+type trapReceiver struct {
+	settings component.TelemetrySettings
+	config   *Config
+
+	decoder    *trapDecoder
+	names      oidNames
+	conn       net.PacketConn
+	shutdownWG sync.WaitGroup
+
+	nextConsumer consumer.Logs
+	obsrecv      *obsreport.Receiver
+}
+
+func newTrapReceiver(params component.ReceiverCreateSettings, config *Config, nextConsumer consumer.Logs) (*trapReceiver, error) {
+	if nextConsumer == nil {
+		return nil, component.ErrNilNextConsumer
+	}
+	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             config.ID(),
+		Transport:              "udp",
+		ReceiverCreateSettings: params,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &trapReceiver{
+		settings:     params.TelemetrySettings,
+		config:       config,
+		nextConsumer: nextConsumer,
+		obsrecv:      obsrecv,
+	}, nil
+}
+
+// Start loads the OID names and starts listening for traps and informs.
+func (r *trapReceiver) Start(_ context.Context, _ component.Host) error {
+	var err error
+	if r.names, err = loadOIDNames(r.config); err != nil {
+		return err
+	}
+	if r.decoder, err = newTrapDecoder(r.config); err != nil {
+		return err
+	}
+	if r.conn, err = net.ListenPacket("udp", r.config.Endpoint); err != nil {
+		return err
+	}
+
+	r.shutdownWG.Add(1)
+	go func() {
+		defer r.shutdownWG.Done()
+		r.serve()
+	}()
+	return nil
+}
+
+func (r *trapReceiver) serve() {
+	buf := make([]byte, maxMessageSize)
+	for {
+		n, addr, err := r.conn.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			r.settings.Logger.Warn("Failed to read SNMP message", zap.Error(err))
+			continue
+		}
+		// The decoded values reference the message, which is also decrypted in place.
+		msg := make([]byte, n)
+		copy(msg, buf[:n])
+		r.handle(msg, addr)
+	}
+}
+
+func (r *trapReceiver) handle(msg []byte, addr net.Addr) {
+	packet, report, err := r.decoder.decode(msg)
+	if err != nil {
+		r.settings.Logger.Debug("Dropping SNMP message", zap.Stringer("peer", addr), zap.Error(err))
+		return
+	}
+	if report != nil {
+		r.respond(report, addr)
+		return
+	}
+
+	ctx := r.obsrecv.StartLogsOp(context.Background())
+	err = r.nextConsumer.ConsumeLogs(ctx, toLogs(packet, addr, r.names, time.Now()))
+	r.obsrecv.EndLogsOp(ctx, dataFormat, 1, err)
+	if err != nil {
+		// The inform is not acknowledged so that it gets sent again.
+		return
+	}
+	if packet.PDUType == gosnmp.InformRequest {
+		r.respond(acknowledgement(packet), addr)
+	}
+}
+
+func (r *trapReceiver) respond(packet *gosnmp.SnmpPacket, addr net.Addr) {
+	msg, err := packet.MarshalMsg()
+	if err != nil {
+		r.settings.Logger.Warn("Failed to encode SNMP response", zap.Error(err))
+		return
+	}
+	if _, err = r.conn.WriteTo(msg, addr); err != nil {
+		r.settings.Logger.Warn("Failed to send SNMP response", zap.Stringer("peer", addr), zap.Error(err))
+	}
+}
+
+// Shutdown stops listening for traps and informs.
+func (r *trapReceiver) Shutdown(context.Context) error {
+	if r.conn == nil {
+		return nil
+	}
+	err := r.conn.Close()
+	r.shutdownWG.Wait()
+	return err
+}
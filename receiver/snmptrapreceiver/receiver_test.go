@@ -0,0 +1,344 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package snmptrapreceiver
+
+import (
+	"context"
+	"encoding/hex"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+const (
+	testEngineID       = "8000000005746573747265636569766572"
+	testSenderEngineID = "80000000057465737473656e646572"
+)
+
+var linkDownVariables = []gosnmp.SnmpPDU{
+	{Name: ".1.3.6.1.6.3.1.1.4.1.0", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.6.3.1.1.5.3"},
+	{Name: ".1.3.6.1.2.1.2.2.1.1.2", Type: gosnmp.Integer, Value: 2},
+	{Name: ".1.3.6.1.2.1.2.2.1.2.2", Type: gosnmp.OctetString, Value: "eth0"},
+	{Name: ".1.3.6.1.2.1.2.2.1.6.2", Type: gosnmp.OctetString, Value: string([]byte{0x00, 0x1a, 0x2b, 0x3c, 0x4d, 0x5e})},
+	{Name: ".1.3.6.1.4.1.99999.1", Type: gosnmp.Counter64, Value: uint64(42)},
+}
+
+func startReceiver(t *testing.T, cfg *Config) (string, *consumertest.LogsSink) {
+	cfg.Endpoint = testutil.GetAvailableLocalNetworkAddress(t, "udp")
+	if cfg.EngineID == "" {
+		cfg.EngineID = testEngineID
+	}
+	cfg.OIDNames = map[string]string{
+		"1.3.6.1.6.3.1.1.5.3":    "linkDown",
+		"1.3.6.1.2.1.2.2.1.1":    "ifIndex",
+		"1.3.6.1.2.1.2.2.1.2":    "ifDescr",
+		"1.3.6.1.2.1.2.2.1.6":    "ifPhysAddress",
+		".1.3.6.1.4.1.99999.0.7": "myEnterpriseTrap",
+	}
+	require.NoError(t, cfg.Validate())
+
+	sink := new(consumertest.LogsSink)
+	r, err := newTrapReceiver(componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, r.Shutdown(context.Background()))
+	})
+	return cfg.Endpoint, sink
+}
+
+func newSender(t *testing.T, endpoint string, snmp *gosnmp.GoSNMP) *gosnmp.GoSNMP {
+	host, port, err := net.SplitHostPort(endpoint)
+	require.NoError(t, err)
+	portNumber, err := strconv.Atoi(port)
+	require.NoError(t, err)
+	snmp.Target = host
+	snmp.Port = uint16(portNumber)
+	snmp.Timeout = time.Second
+	snmp.Retries = 1
+	require.NoError(t, snmp.Connect())
+	t.Cleanup(func() {
+		assert.NoError(t, snmp.Conn.Close())
+	})
+	return snmp
+}
+
+func waitForRecord(t *testing.T, sink *consumertest.LogsSink) plog.LogRecord {
+	require.Eventually(t, func() bool {
+		return sink.LogRecordCount() > 0
+	}, 10*time.Second, 10*time.Millisecond)
+	return sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+}
+
+func assertLinkDown(t *testing.T, record plog.LogRecord) {
+	assert.Equal(t, "linkDown", record.Body().Str())
+	attrs := record.Attributes().AsRaw()
+	assert.Equal(t, "1.3.6.1.6.3.1.1.5.3", attrs[attributeTrapOID])
+	assert.Equal(t, "linkDown", attrs[attributeTrapName])
+	assert.Equal(t, int64(2), attrs["ifIndex.2"])
+	assert.Equal(t, "eth0", attrs["ifDescr.2"])
+	assert.Equal(t, []byte{0x00, 0x1a, 0x2b, 0x3c, 0x4d, 0x5e}, attrs["ifPhysAddress.2"])
+	assert.Equal(t, int64(42), attrs["1.3.6.1.4.1.99999.1"])
+	assert.Equal(t, "127.0.0.1", attrs["net.peer.ip"])
+	assert.Contains(t, attrs, "net.peer.port")
+	assert.Contains(t, attrs, attributeUptime)
+}
+
+func TestReceiveV1Trap(t *testing.T) {
+	endpoint, sink := startReceiver(t, createDefaultConfig().(*Config))
+	sender := newSender(t, endpoint, &gosnmp.GoSNMP{Version: gosnmp.Version1, Community: "public"})
+
+	_, err := sender.SendTrap(gosnmp.SnmpTrap{
+		Variables:    linkDownVariables[1:3],
+		Enterprise:   ".1.3.6.1.4.1.99999",
+		AgentAddress: "192.168.1.10",
+		GenericTrap:  6,
+		SpecificTrap: 7,
+		Timestamp:    300,
+	})
+	require.NoError(t, err)
+
+	record := waitForRecord(t, sink)
+	assert.Equal(t, "myEnterpriseTrap", record.Body().Str())
+	attrs := record.Attributes().AsRaw()
+	assert.Equal(t, "v1", attrs[attributeVersion])
+	assert.Equal(t, "trap", attrs[attributePDUType])
+	assert.Equal(t, "1.3.6.1.4.1.99999.0.7", attrs[attributeTrapOID])
+	assert.Equal(t, "1.3.6.1.4.1.99999", attrs[attributeEnterprise])
+	assert.Equal(t, "192.168.1.10", attrs[attributeAgentAddress])
+	assert.Equal(t, int64(6), attrs[attributeGenericTrap])
+	assert.Equal(t, int64(7), attrs[attributeSpecificTrap])
+	assert.Equal(t, int64(300), attrs[attributeUptime])
+	assert.Equal(t, int64(2), attrs["ifIndex.2"])
+	assert.Equal(t, "eth0", attrs["ifDescr.2"])
+}
+
+func TestReceiveV2cTrap(t *testing.T) {
+	endpoint, sink := startReceiver(t, createDefaultConfig().(*Config))
+	sender := newSender(t, endpoint, &gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "public"})
+
+	_, err := sender.SendTrap(gosnmp.SnmpTrap{Variables: linkDownVariables})
+	require.NoError(t, err)
+
+	record := waitForRecord(t, sink)
+	assertLinkDown(t, record)
+	assert.Equal(t, "v2c", record.Attributes().AsRaw()[attributeVersion])
+	assert.Equal(t, "trap", record.Attributes().AsRaw()[attributePDUType])
+}
+
+func TestReceiveV2cInform(t *testing.T) {
+	endpoint, sink := startReceiver(t, createDefaultConfig().(*Config))
+	sender := newSender(t, endpoint, &gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "public"})
+
+	response, err := sender.SendTrap(gosnmp.SnmpTrap{Variables: linkDownVariables, IsInform: true})
+	require.NoError(t, err)
+	assert.Equal(t, gosnmp.GetResponse, response.PDUType)
+
+	record := waitForRecord(t, sink)
+	assertLinkDown(t, record)
+	assert.Equal(t, "inform", record.Attributes().AsRaw()[attributePDUType])
+}
+
+func TestInformNotAcknowledgedOnConsumerError(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalNetworkAddress(t, "udp")
+	r, err := newTrapReceiver(componenttest.NewNopReceiverCreateSettings(), cfg, consumertest.NewErr(assert.AnError))
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		require.NoError(t, r.Shutdown(context.Background()))
+	})
+	sender := newSender(t, cfg.Endpoint, &gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "public"})
+	sender.Retries = 0
+	sender.Timeout = 200 * time.Millisecond
+
+	_, err = sender.SendTrap(gosnmp.SnmpTrap{Variables: linkDownVariables, IsInform: true})
+	assert.Error(t, err)
+}
+
+func TestUnknownCommunityDropped(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Communities = []string{"private"}
+	endpoint, sink := startReceiver(t, cfg)
+
+	_, err := newSender(t, endpoint, &gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "public"}).
+		SendTrap(gosnmp.SnmpTrap{Variables: linkDownVariables})
+	require.NoError(t, err)
+	_, err = newSender(t, endpoint, &gosnmp.GoSNMP{Version: gosnmp.Version2c, Community: "private"}).
+		SendTrap(gosnmp.SnmpTrap{Variables: linkDownVariables[:1]})
+	require.NoError(t, err)
+
+	waitForRecord(t, sink)
+	// only the trap sent with the private community is received
+	time.Sleep(100 * time.Millisecond)
+	require.Equal(t, 1, sink.LogRecordCount())
+	assert.NotContains(t, sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().AsRaw(), "ifIndex.2")
+}
+
+func v3Config() *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Users = []UserConfig{
+		{
+			User:            "authpriv",
+			SecurityLevel:   securityLevelAuthPriv,
+			AuthType:        "SHA256",
+			AuthPassword:    "authpassword",
+			PrivacyType:     "AES",
+			PrivacyPassword: "privpassword",
+		},
+		{
+			User:          "noauth",
+			SecurityLevel: securityLevelNoAuthNoPriv,
+		},
+	}
+	return cfg
+}
+
+func v3Sender(user string, authPassword string, msgFlags gosnmp.SnmpV3MsgFlags, engineID string) *gosnmp.GoSNMP {
+	decodedEngineID, _ := hex.DecodeString(engineID)
+	securityParams := &gosnmp.UsmSecurityParameters{
+		UserName:              user,
+		AuthoritativeEngineID: string(decodedEngineID),
+	}
+	if msgFlags&gosnmp.AuthNoPriv != 0 {
+		securityParams.AuthenticationProtocol = gosnmp.SHA256
+		securityParams.AuthenticationPassphrase = authPassword
+	}
+	if msgFlags&gosnmp.AuthPriv == gosnmp.AuthPriv {
+		securityParams.PrivacyProtocol = gosnmp.AES
+		securityParams.PrivacyPassphrase = "privpassword"
+	}
+	return &gosnmp.GoSNMP{
+		Version:            gosnmp.Version3,
+		SecurityModel:      gosnmp.UserSecurityModel,
+		MsgFlags:           msgFlags,
+		SecurityParameters: securityParams,
+	}
+}
+
+func TestReceiveV3Trap(t *testing.T) {
+	endpoint, sink := startReceiver(t, v3Config())
+	sender := newSender(t, endpoint, v3Sender("authpriv", "authpassword", gosnmp.AuthPriv, testSenderEngineID))
+
+	_, err := sender.SendTrap(gosnmp.SnmpTrap{Variables: linkDownVariables})
+	require.NoError(t, err)
+
+	record := waitForRecord(t, sink)
+	assertLinkDown(t, record)
+	attrs := record.Attributes().AsRaw()
+	assert.Equal(t, "v3", attrs[attributeVersion])
+	assert.Equal(t, "authpriv", attrs[attributeUser])
+	assert.Equal(t, testSenderEngineID, attrs[attributeEngineID])
+}
+
+func TestReceiveV3InformWithDiscovery(t *testing.T) {
+	endpoint, sink := startReceiver(t, v3Config())
+	// the sender discovers the engine ID of the receiver before sending the inform
+	sender := newSender(t, endpoint, v3Sender("authpriv", "authpassword", gosnmp.AuthPriv, ""))
+
+	response, err := sender.SendTrap(gosnmp.SnmpTrap{Variables: linkDownVariables, IsInform: true})
+	require.NoError(t, err)
+	assert.Equal(t, gosnmp.GetResponse, response.PDUType)
+
+	record := waitForRecord(t, sink)
+	assertLinkDown(t, record)
+	attrs := record.Attributes().AsRaw()
+	assert.Equal(t, "inform", attrs[attributePDUType])
+	assert.Equal(t, testEngineID, attrs[attributeEngineID])
+}
+
+func TestV3TrapsDropped(t *testing.T) {
+	tests := []struct {
+		name   string
+		sender *gosnmp.GoSNMP
+	}{
+		{
+			name:   "wrong password",
+			sender: v3Sender("authpriv", "wrongpassword", gosnmp.AuthPriv, testSenderEngineID),
+		},
+		{
+			name:   "unknown user",
+			sender: v3Sender("unknown", "authpassword", gosnmp.AuthPriv, testSenderEngineID),
+		},
+		{
+			name:   "lower security level",
+			sender: v3Sender("authpriv", "authpassword", gosnmp.AuthNoPriv, testSenderEngineID),
+		},
+		{
+			name:   "no authentication",
+			sender: v3Sender("authpriv", "", gosnmp.NoAuthNoPriv, testSenderEngineID),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint, sink := startReceiver(t, v3Config())
+			_, err := newSender(t, endpoint, tt.sender).SendTrap(gosnmp.SnmpTrap{Variables: linkDownVariables})
+			require.NoError(t, err)
+
+			// a trap of an accepted user is received after the dropped one
+			_, err = newSender(t, endpoint, v3Sender("noauth", "", gosnmp.NoAuthNoPriv, testSenderEngineID)).
+				SendTrap(gosnmp.SnmpTrap{Variables: linkDownVariables})
+			require.NoError(t, err)
+			record := waitForRecord(t, sink)
+			assert.Equal(t, "noauth", record.Attributes().AsRaw()[attributeUser])
+			time.Sleep(50 * time.Millisecond)
+			assert.Equal(t, 1, sink.LogRecordCount())
+		})
+	}
+}
+
+func TestVarbindValues(t *testing.T) {
+	logs := toLogs(&gosnmp.SnmpPacket{
+		Version: gosnmp.Version2c,
+		PDUType: gosnmp.SNMPv2Trap,
+		Variables: []gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(1234)},
+			{Name: ".1.3.6.1.6.3.1.1.4.1.0", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.4.1.99999.0.1"},
+			{Name: ".1.3.6.1.4.1.99999.1", Type: gosnmp.Gauge32, Value: uint(7)},
+			{Name: ".1.3.6.1.4.1.99999.2", Type: gosnmp.IPAddress, Value: "10.0.0.1"},
+			{Name: ".1.3.6.1.4.1.99999.3", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.2.1.2.2.1.1.4"},
+			{Name: ".1.3.6.1.4.1.99999.4", Type: gosnmp.OpaqueDouble, Value: 1.5},
+			{Name: ".1.3.6.1.4.1.99999.5", Type: gosnmp.Null, Value: nil},
+		},
+	}, &net.UDPAddr{IP: net.IPv4(10, 0, 0, 2), Port: 1162}, oidNames{"1.3.6.1.2.1.2.2.1.1": "ifIndex"}, time.Unix(10, 0))
+
+	record := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(10, 0)), record.Timestamp())
+	assert.Equal(t, "1.3.6.1.4.1.99999.0.1", record.Body().Str())
+	assert.Equal(t, map[string]interface{}{
+		attributeVersion:      "v2c",
+		attributePDUType:      "trap",
+		attributeUptime:       int64(1234),
+		attributeTrapOID:      "1.3.6.1.4.1.99999.0.1",
+		"net.peer.ip":         "10.0.0.2",
+		"net.peer.port":       int64(1162),
+		"1.3.6.1.4.1.99999.1": int64(7),
+		"1.3.6.1.4.1.99999.2": "10.0.0.1",
+		"1.3.6.1.4.1.99999.3": "ifIndex.4",
+		"1.3.6.1.4.1.99999.4": 1.5,
+		"1.3.6.1.4.1.99999.5": nil,
+	}, record.Attributes().AsRaw())
+}
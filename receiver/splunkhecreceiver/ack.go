@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunkhecreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/splunkhecreceiver"
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// maxAcksPerChannel bounds the number of ackIds kept for a channel whose
+	// acknowledgements are never queried.
+	maxAcksPerChannel = 10000
+	// maxAckChannels bounds the number of channels kept. The least recently used
+	// channel is forgotten when a new channel exceeds it.
+	maxAckChannels = 1000
+	// ackChannelIdleTimeout is the time after which a channel that is not used is
+	// forgotten, as the max_idle_time of the channels of Splunk.
+	ackChannelIdleTimeout = 10 * time.Minute
+)
+
+// ackRegistry keeps the ackIds of the requests of each channel until they are queried.
+// The events of a request are consumed before its response is written, so an ackId
+// is indexed as soon as it's returned.
+type ackRegistry struct {
+	mu       sync.Mutex
+	channels map[string]*ackChannel
+	now      func() time.Time
+}
+
+type ackChannel struct {
+	nextID   uint64
+	indexed  map[uint64]struct{}
+	lastUsed time.Time
+}
+
+func newAckRegistry() *ackRegistry {
+	return &ackRegistry{channels: map[string]*ackChannel{}, now: time.Now}
+}
+
+// add returns the ackId of a request of the channel whose events were consumed.
+func (a *ackRegistry) add(channel string) uint64 {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := a.now()
+	c, ok := a.channels[channel]
+	if !ok {
+		a.evictChannels(now)
+		c = &ackChannel{indexed: map[uint64]struct{}{}}
+		a.channels[channel] = c
+	}
+	c.lastUsed = now
+
+	id := c.nextID
+	c.nextID++
+	c.indexed[id] = struct{}{}
+	if id >= maxAcksPerChannel {
+		delete(c.indexed, id-maxAcksPerChannel)
+	}
+	return id
+}
+
+// query reports for each ackId whether it is indexed. As in Splunk, an indexed ackId
+// is reported once, and is then forgotten.
+func (a *ackRegistry) query(channel string, ackIDs []uint64) map[uint64]bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	acks := make(map[uint64]bool, len(ackIDs))
+	c := a.channels[channel]
+	if c != nil {
+		c.lastUsed = a.now()
+	}
+	for _, id := range ackIDs {
+		if c == nil {
+			acks[id] = false
+			continue
+		}
+		_, acks[id] = c.indexed[id]
+		delete(c.indexed, id)
+	}
+	return acks
+}
+
+// evictChannels forgets the idle channels, and the least recently used channel
+// when there is no room left for a new channel.
+func (a *ackRegistry) evictChannels(now time.Time) {
+	var lruName string
+	var lru *ackChannel
+	for name, c := range a.channels {
+		if now.Sub(c.lastUsed) > ackChannelIdleTimeout {
+			delete(a.channels, name)
+			continue
+		}
+		if lru == nil || c.lastUsed.Before(lru.lastUsed) {
+			lruName, lru = name, c
+		}
+	}
+	if len(a.channels) >= maxAckChannels {
+		delete(a.channels, lruName)
+	}
+}
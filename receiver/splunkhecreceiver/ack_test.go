@@ -0,0 +1,75 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package splunkhecreceiver
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAckRegistry(t *testing.T) {
+	acks := newAckRegistry()
+	assert.Equal(t, uint64(0), acks.add("a"))
+	assert.Equal(t, uint64(1), acks.add("a"))
+	assert.Equal(t, uint64(0), acks.add("b"))
+
+	assert.Equal(t, map[uint64]bool{0: true, 2: false}, acks.query("a", []uint64{0, 2}))
+	// indexed ackIds are reported once
+	assert.Equal(t, map[uint64]bool{0: false, 1: true}, acks.query("a", []uint64{0, 1}))
+	assert.Equal(t, map[uint64]bool{0: true}, acks.query("b", []uint64{0}))
+	assert.Equal(t, map[uint64]bool{0: false}, acks.query("unknown", []uint64{0}))
+}
+
+func TestAckRegistryBounded(t *testing.T) {
+	acks := newAckRegistry()
+	for i := 0; i < maxAcksPerChannel+10; i++ {
+		acks.add("a")
+	}
+	assert.Len(t, acks.channels["a"].indexed, maxAcksPerChannel)
+	assert.Equal(t, map[uint64]bool{9: false, 10: true}, acks.query("a", []uint64{9, 10}))
+}
+
+func TestAckRegistryEvictsChannels(t *testing.T) {
+	now := time.Now()
+	acks := newAckRegistry()
+	acks.now = func() time.Time { return now }
+
+	acks.add("idle")
+	now = now.Add(ackChannelIdleTimeout / 2)
+	acks.add("used")
+	now = now.Add(ackChannelIdleTimeout / 2)
+	acks.query("used", []uint64{1})
+	now = now.Add(time.Second)
+
+	// idle channels are forgotten when a new channel is used
+	acks.add("new")
+	assert.NotContains(t, acks.channels, "idle")
+	assert.Contains(t, acks.channels, "used")
+
+	// the least recently used channel is forgotten when there are too many channels
+	for i := len(acks.channels); i < maxAckChannels; i++ {
+		now = now.Add(time.Millisecond)
+		acks.add(fmt.Sprintf("channel-%d", i))
+	}
+	assert.Len(t, acks.channels, maxAckChannels)
+	acks.add("last")
+	assert.Len(t, acks.channels, maxAckChannels)
+	assert.NotContains(t, acks.channels, "used")
+	assert.Contains(t, acks.channels, "new")
+	assert.Contains(t, acks.channels, "last")
+}
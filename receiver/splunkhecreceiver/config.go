@@ -35,4 +35,15 @@ type Config struct {
 	HealthPath string `mapstructure:"health_path"`
 	// HecToOtelAttrs creates a mapping from HEC metadata to attributes.
 	HecToOtelAttrs splunk.HecToOtelAttrs `mapstructure:"hec_metadata_to_otel_attrs"`
+	// Ack defines the indexer acknowledgement settings.
+	Ack AckSettings `mapstructure:"ack"`
+}
+
+// AckSettings defines the indexer acknowledgement settings, see https://docs.splunk.com/Documentation/Splunk/latest/Data/AboutHECIDXAck.
+type AckSettings struct {
+	// Enabled requires a channel on each request, answers the requests with an ackId, and serves the
+	// indexer acknowledgement endpoint.
+	Enabled bool `mapstructure:"enabled"`
+	// Path for the indexer acknowledgement endpoint, default is '/services/collector/ack'
+	Path string `mapstructure:"path"`
 }
@@ -61,6 +61,10 @@ func TestLoadConfig(t *testing.T) {
 					Index:      "myindex",
 					Host:       "myhostfield",
 				},
+				Ack: AckSettings{
+					Enabled: true,
+					Path:    "/ack",
+				},
 			},
 		},
 		{
@@ -87,6 +91,9 @@ func TestLoadConfig(t *testing.T) {
 					Index:      "com.splunk.index",
 					Host:       "host.name",
 				},
+				Ack: AckSettings{
+					Path: "/services/collector/ack",
+				},
 			},
 		},
 	}
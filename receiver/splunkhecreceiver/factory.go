@@ -64,6 +64,9 @@ func createDefaultConfig() component.ReceiverConfig {
 		},
 		RawPath:    splunk.DefaultRawPath,
 		HealthPath: splunk.DefaultHealthPath,
+		Ack: AckSettings{
+			Path: splunk.DefaultAckPath,
+		},
 	}
 }
 
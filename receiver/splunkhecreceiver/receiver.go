@@ -50,6 +50,8 @@ const (
 	responseErrInternalServerError    = "Internal Server Error"
 	responseErrUnsupportedMetricEvent = "Unsupported metric event"
 	responseErrUnsupportedLogEvent    = "Unsupported log event"
+	responseErrDataChannelMissing     = "Data channel is missing"
+	responseSuccess                   = "Success"
 
 	// Centralizing some HTTP and related string constants.
 	gzipEncoding              = "gzip"
@@ -62,15 +64,17 @@ var (
 	errEmptyEndpoint          = errors.New("empty endpoint")
 	errInvalidMethod          = errors.New("invalid http method")
 	errInvalidEncoding        = errors.New("invalid encoding")
-
-	okRespBody                = initJSONResponse(responseOK)
-	invalidMethodRespBody     = initJSONResponse(responseInvalidMethod)
-	invalidEncodingRespBody   = initJSONResponse(responseInvalidEncoding)
-	errGzipReaderRespBody     = initJSONResponse(responseErrGzipReader)
-	errUnmarshalBodyRespBody  = initJSONResponse(responseErrUnmarshalBody)
-	errInternalServerError    = initJSONResponse(responseErrInternalServerError)
-	errUnsupportedMetricEvent = initJSONResponse(responseErrUnsupportedMetricEvent)
-	errUnsupportedLogEvent    = initJSONResponse(responseErrUnsupportedLogEvent)
+	errDataChannelMissing     = errors.New("missing data channel")
+
+	okRespBody                    = initJSONResponse(responseOK)
+	invalidMethodRespBody         = initJSONResponse(responseInvalidMethod)
+	invalidEncodingRespBody       = initJSONResponse(responseInvalidEncoding)
+	errGzipReaderRespBody         = initJSONResponse(responseErrGzipReader)
+	errUnmarshalBodyRespBody      = initJSONResponse(responseErrUnmarshalBody)
+	errInternalServerError        = initJSONResponse(responseErrInternalServerError)
+	errUnsupportedMetricEvent     = initJSONResponse(responseErrUnsupportedMetricEvent)
+	errUnsupportedLogEvent        = initJSONResponse(responseErrUnsupportedLogEvent)
+	errDataChannelMissingRespBody = initJSONResponse(responseErrDataChannelMissing)
 )
 
 // splunkReceiver implements the component.MetricsReceiver for Splunk HEC metric protocol.
@@ -83,6 +87,8 @@ type splunkReceiver struct {
 	shutdownWG      sync.WaitGroup
 	obsrecv         *obsreport.Receiver
 	gzipReaderPool  *sync.Pool
+	// acks is nil when indexer acknowledgement is disabled
+	acks *ackRegistry
 }
 
 var _ component.MetricsReceiver = (*splunkReceiver)(nil)
@@ -129,6 +135,10 @@ func newMetricsReceiver(
 		gzipReaderPool: &sync.Pool{New: func() interface{} { return new(gzip.Reader) }},
 	}
 
+	if config.Ack.Enabled {
+		r.acks = newAckRegistry()
+	}
+
 	return r, nil
 }
 
@@ -174,6 +184,10 @@ func newLogsReceiver(
 		obsrecv:        obsrecv,
 	}
 
+	if config.Ack.Enabled {
+		r.acks = newAckRegistry()
+	}
+
 	return r, nil
 }
 
@@ -195,6 +209,9 @@ func (r *splunkReceiver) Start(_ context.Context, host component.Host) error {
 
 	mx := mux.NewRouter()
 	mx.NewRoute().Path(r.config.HealthPath).HandlerFunc(r.handleHealthReq)
+	if r.acks != nil {
+		mx.NewRoute().Path(r.config.Ack.Path).HandlerFunc(r.handleAckReq)
+	}
 	if r.logsConsumer != nil {
 		mx.NewRoute().Path(r.config.RawPath).HandlerFunc(r.handleRawReq)
 	}
@@ -244,6 +261,11 @@ func (r *splunkReceiver) handleRawReq(resp http.ResponseWriter, req *http.Reques
 		return
 	}
 
+	if r.acks != nil && channelFromRequest(req) == "" {
+		r.failRequest(ctx, resp, http.StatusBadRequest, errDataChannelMissingRespBody, 0, errDataChannelMissing)
+		return
+	}
+
 	if req.ContentLength == 0 {
 		r.obsrecv.EndLogsOp(ctx, typeStr, 0, nil)
 		return
@@ -287,6 +309,11 @@ func (r *splunkReceiver) handleRawReq(resp http.ResponseWriter, req *http.Reques
 		r.failRequest(ctx, resp, http.StatusInternalServerError, errInternalServerError, sl.LogRecords().Len(), consumerErr)
 	} else {
 		resp.WriteHeader(http.StatusOK)
+		if r.acks != nil {
+			if _, err := resp.Write(r.ackRespBody(req)); err != nil {
+				r.settings.Logger.Warn("Error writing HTTP response message", zap.Error(err))
+			}
+		}
 		r.obsrecv.EndLogsOp(ctx, typeStr, sl.LogRecords().Len(), nil)
 	}
 }
@@ -310,6 +337,11 @@ func (r *splunkReceiver) handleReq(resp http.ResponseWriter, req *http.Request)
 		return
 	}
 
+	if r.acks != nil && channelFromRequest(req) == "" {
+		r.failRequest(ctx, resp, http.StatusBadRequest, errDataChannelMissingRespBody, 0, errDataChannelMissing)
+		return
+	}
+
 	bodyReader := req.Body
 	if encoding == gzipEncoding {
 		reader := r.gzipReaderPool.Get().(*gzip.Reader)
@@ -370,7 +402,7 @@ func (r *splunkReceiver) consumeMetrics(ctx context.Context, events []*splunk.Ev
 		r.failRequest(ctx, resp, http.StatusInternalServerError, errInternalServerError, len(events), decodeErr)
 	} else {
 		resp.WriteHeader(http.StatusOK)
-		_, err := resp.Write(okRespBody)
+		_, err := resp.Write(r.successRespBody(req))
 		if err != nil {
 			r.failRequest(ctx, resp, http.StatusInternalServerError, errInternalServerError, len(events), err)
 		}
@@ -391,7 +423,7 @@ func (r *splunkReceiver) consumeLogs(ctx context.Context, events []*splunk.Event
 		r.failRequest(ctx, resp, http.StatusInternalServerError, errInternalServerError, len(events), decodeErr)
 	} else {
 		resp.WriteHeader(http.StatusOK)
-		if _, err := resp.Write(okRespBody); err != nil {
+		if _, err := resp.Write(r.successRespBody(req)); err != nil {
 			r.failRequest(ctx, resp, http.StatusInternalServerError, errInternalServerError, len(events), err)
 		}
 	}
@@ -445,6 +477,66 @@ func (r *splunkReceiver) failRequest(
 	}
 }
 
+// successRespBody returns the response of a request whose events were consumed.
+func (r *splunkReceiver) successRespBody(req *http.Request) []byte {
+	if r.acks == nil {
+		return okRespBody
+	}
+	return r.ackRespBody(req)
+}
+
+// ackRespBody registers the ackId of a request whose events were consumed, and returns the response holding it.
+func (r *splunkReceiver) ackRespBody(req *http.Request) []byte {
+	ackID := r.acks.add(channelFromRequest(req))
+	respBody, err := jsoniter.Marshal(splunk.EventResponse{Text: responseSuccess, AckID: &ackID})
+	if err != nil {
+		// marshaling this struct can't fail.
+		panic(err)
+	}
+	return respBody
+}
+
+func (r *splunkReceiver) handleAckReq(resp http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		resp.WriteHeader(http.StatusBadRequest)
+		_, _ = resp.Write(invalidMethodRespBody)
+		return
+	}
+
+	channel := channelFromRequest(req)
+	if channel == "" {
+		resp.WriteHeader(http.StatusBadRequest)
+		_, _ = resp.Write(errDataChannelMissingRespBody)
+		return
+	}
+
+	var ackReq splunk.AckRequest
+	if err := jsoniter.NewDecoder(req.Body).Decode(&ackReq); err != nil {
+		resp.WriteHeader(http.StatusBadRequest)
+		_, _ = resp.Write(errUnmarshalBodyRespBody)
+		return
+	}
+
+	respBody, err := jsoniter.Marshal(splunk.AckResponse{Acks: r.acks.query(channel, ackReq.Acks)})
+	if err != nil {
+		resp.WriteHeader(http.StatusInternalServerError)
+		_, _ = resp.Write(errInternalServerError)
+		return
+	}
+	resp.Header().Set("Content-Type", "application/json")
+	if _, err = resp.Write(respBody); err != nil {
+		r.settings.Logger.Warn("Error writing HTTP response message", zap.Error(err))
+	}
+}
+
+// channelFromRequest returns the channel of a request, set either as a header or as a query parameter.
+func channelFromRequest(req *http.Request) string {
+	if channel := req.Header.Get(splunk.HTTPSplunkChannelHeader); channel != "" {
+		return channel
+	}
+	return req.URL.Query().Get("channel")
+}
+
 func (r *splunkReceiver) handleHealthReq(writer http.ResponseWriter, _ *http.Request) {
 	writer.WriteHeader(200)
 }
@@ -1032,3 +1032,65 @@ func BenchmarkHandleReq(b *testing.B) {
 		assert.NoError(b, err)
 	}
 }
+
+func Test_splunkhecReceiver_Ack(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	config := createDefaultConfig().(*Config)
+	config.Endpoint = addr
+	config.Ack.Enabled = true
+	sink := new(consumertest.LogsSink)
+	rcv, err := newLogsReceiver(componenttest.NewNopReceiverCreateSettings(), *config, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, rcv.Shutdown(context.Background()))
+	}()
+
+	t.Run("missing channel", func(t *testing.T) {
+		resp, err := http.Post(fmt.Sprintf("http://%s/services/collector", addr), "application/json", strings.NewReader(`{"event":"foo"}`))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Equal(t, `"Data channel is missing"`, string(body))
+	})
+
+	t.Run("raw request", func(t *testing.T) {
+		resp, err := http.Post(fmt.Sprintf("http://%s/services/collector/raw?channel=raw", addr), "text/plain", strings.NewReader("foo\nbar\n"))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		var eventResp splunk.EventResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&eventResp))
+		assert.Equal(t, uint64(0), *eventResp.AckID)
+
+		resp, err = http.Post(fmt.Sprintf("http://%s/services/collector/ack?channel=raw", addr), "application/json", strings.NewReader(`{"acks":[0,1]}`))
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"acks":{"0":true,"1":false}}`, string(body))
+	})
+
+	t.Run("exporter with indexer acknowledgement", func(t *testing.T) {
+		sink.Reset()
+		exporterConfig := splunkhecexporter.NewFactory().CreateDefaultConfig().(*splunkhecexporter.Config)
+		exporterConfig.Endpoint = fmt.Sprintf("http://%s", addr)
+		exporterConfig.Token = "1234"
+		exporterConfig.QueueSettings.Enabled = false
+		exporterConfig.Ack.Enabled = true
+		exporterConfig.Ack.PollInterval = 10 * time.Millisecond
+
+		exp, err := splunkhecexporter.NewFactory().CreateLogsExporter(context.Background(), componenttest.NewNopExporterCreateSettings(), exporterConfig)
+		require.NoError(t, err)
+		require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+		defer func() {
+			require.NoError(t, exp.Shutdown(context.Background()))
+		}()
+
+		logs := plog.NewLogs()
+		logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("foo")
+		require.NoError(t, exp.ConsumeLogs(context.Background(), logs))
+		assert.Equal(t, 1, sink.LogRecordCount())
+	})
+}
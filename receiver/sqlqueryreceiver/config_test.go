@@ -30,6 +30,7 @@ import (
 func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
+	storageID := component.NewID("file_storage")
 	tests := []struct {
 		fname        string
 		id           component.ID
@@ -65,6 +66,32 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id:    component.NewIDWithName(typeStr, ""),
+			fname: "config-logs.yaml",
+			expected: &Config{
+				ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+					ReceiverSettings:   config.NewReceiverSettings(component.NewID(typeStr)),
+					CollectionInterval: 10 * time.Second,
+				},
+				Driver:     "mydriver",
+				DataSource: "host=localhost port=5432 user=me password=s3cr3t sslmode=disable",
+				StorageID:  &storageID,
+				Queries: []Query{
+					{
+						SQL:                "select id, body, severity from audit where id > $1 order by id",
+						TrackingColumn:     "id",
+						TrackingStartValue: "0",
+						Logs: []LogsCfg{
+							{
+								BodyColumn:       "body",
+								AttributeColumns: []string{"severity"},
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			fname:        "config-invalid-datatype.yaml",
 			id:           component.NewIDWithName(typeStr, ""),
@@ -108,7 +135,22 @@ func TestLoadConfig(t *testing.T) {
 		{
 			fname:        "config-invalid-missing-metrics.yaml",
 			id:           component.NewIDWithName(typeStr, ""),
-			errorMessage: "'query.metrics' cannot be empty",
+			errorMessage: "'query.metrics' and 'query.logs' cannot both be empty",
+		},
+		{
+			fname:        "config-invalid-missing-bodycolumn.yaml",
+			id:           component.NewIDWithName(typeStr, ""),
+			errorMessage: "'body_column' cannot be empty",
+		},
+		{
+			fname:        "config-invalid-tracking-column-metrics.yaml",
+			id:           component.NewIDWithName(typeStr, ""),
+			errorMessage: "'query.tracking_column' is only supported for queries with 'logs' and no 'metrics'",
+		},
+		{
+			fname:        "config-invalid-tracking-start-value.yaml",
+			id:           component.NewIDWithName(typeStr, ""),
+			errorMessage: "'query.tracking_column' requires 'query.tracking_start_value'",
 		},
 		{
 			fname:        "config-invalid-missing-datasource.yaml",
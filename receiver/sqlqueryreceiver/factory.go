@@ -21,8 +21,9 @@ import (
 )
 
 const (
-	typeStr   = "sqlquery"
-	stability = component.StabilityLevelUndefined
+	typeStr       = "sqlquery"
+	stability     = component.StabilityLevelUndefined
+	logsStability = component.StabilityLevelDevelopment
 )
 
 func NewFactory() component.ReceiverFactory {
@@ -30,5 +31,6 @@ func NewFactory() component.ReceiverFactory {
 		typeStr,
 		createDefaultConfig,
 		component.WithMetricsReceiver(createReceiverFunc(sql.Open, newDbClient), stability),
+		component.WithLogsReceiver(createLogsReceiverFunc(sql.Open, newDbClient), logsStability),
 	)
 }
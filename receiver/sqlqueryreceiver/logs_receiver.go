@@ -0,0 +1,270 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver"
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+const dataFormat = "sql"
+
+type logsReceiver struct {
+	config             *Config
+	settings           component.ReceiverCreateSettings
+	nextConsumer       consumer.Logs
+	obsrecv            *obsreport.Receiver
+	sqlOpenerFunc      sqlOpenerFunc
+	clientProviderFunc clientProviderFunc
+
+	db            *sql.DB
+	storageClient storage.Client
+	queries       []*logsQuery
+	cancel        context.CancelFunc
+	shutdownWG    sync.WaitGroup
+}
+
+// logsQuery runs a single configured query and remembers the last value seen
+// in its tracking column, if any.
+type logsQuery struct {
+	index         int
+	query         Query
+	client        dbClient
+	trackingValue string
+}
+
+func createLogsReceiverFunc(sqlOpenerFunc sqlOpenerFunc, clientProviderFunc clientProviderFunc) component.CreateLogsReceiverFunc {
+	return func(
+		_ context.Context,
+		settings component.ReceiverCreateSettings,
+		cfg component.ReceiverConfig,
+		nextConsumer consumer.Logs,
+	) (component.LogsReceiver, error) {
+		if nextConsumer == nil {
+			return nil, component.ErrNilNextConsumer
+		}
+		sqlCfg := cfg.(*Config)
+		obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
+			ReceiverID:             sqlCfg.ID(),
+			ReceiverCreateSettings: settings,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &logsReceiver{
+			config:             sqlCfg,
+			settings:           settings,
+			nextConsumer:       nextConsumer,
+			obsrecv:            obsrecv,
+			sqlOpenerFunc:      sqlOpenerFunc,
+			clientProviderFunc: clientProviderFunc,
+		}, nil
+	}
+}
+
+func (r *logsReceiver) Start(ctx context.Context, host component.Host) error {
+	var err error
+	r.db, err = r.sqlOpenerFunc(r.config.Driver, r.config.DataSource)
+	if err != nil {
+		return fmt.Errorf("failed to open db connection: %w", err)
+	}
+	r.storageClient, err = getStorageClient(ctx, host, r.config.StorageID, r.config.ID())
+	if err != nil {
+		return fmt.Errorf("failed to get storage client: %w", err)
+	}
+
+	for i, query := range r.config.Queries {
+		if len(query.Logs) == 0 {
+			continue
+		}
+		q := &logsQuery{
+			index:         i,
+			query:         query,
+			client:        r.clientProviderFunc(r.db, query.SQL, r.settings.Logger),
+			trackingValue: query.TrackingStartValue,
+		}
+		if query.TrackingColumn != "" {
+			if !strings.Contains(strings.ToLower(query.SQL), "order by") {
+				r.settings.Logger.Warn("The query has a tracking_column but no ORDER BY clause, rows may be skipped",
+					zap.Int("query", i))
+			}
+			stored, err := r.storageClient.Get(ctx, q.storageKey())
+			if err != nil {
+				return fmt.Errorf("failed to read tracking value of query %d: %w", i, err)
+			}
+			if stored != nil {
+				q.trackingValue = string(stored)
+			}
+		}
+		r.queries = append(r.queries, q)
+	}
+
+	collectCtx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+	r.shutdownWG.Add(1)
+	go r.run(collectCtx)
+	return nil
+}
+
+func (r *logsReceiver) run(ctx context.Context) {
+	defer r.shutdownWG.Done()
+	ticker := time.NewTicker(r.config.CollectionInterval)
+	defer ticker.Stop()
+	r.collect(ctx)
+	for {
+		select {
+		case <-ticker.C:
+			r.collect(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *logsReceiver) collect(ctx context.Context) {
+	for _, q := range r.queries {
+		if err := r.collectQuery(ctx, q); err != nil {
+			r.settings.Logger.Error("Failed to collect logs", zap.Int("query", q.index), zap.Error(err))
+		}
+	}
+}
+
+func (r *logsReceiver) collectQuery(ctx context.Context, q *logsQuery) error {
+	var args []interface{}
+	if q.query.TrackingColumn != "" {
+		args = append(args, q.trackingValue)
+	}
+	rows, err := q.client.queryRows(ctx, args...)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	obsCtx := r.obsrecv.StartLogsOp(ctx)
+	logs, errs := rowsToLogs(rows, q.query.Logs, pcommon.NewTimestampFromTime(time.Now()))
+	numRecords := logs.LogRecordCount()
+	if numRecords > 0 {
+		err = r.nextConsumer.ConsumeLogs(obsCtx, logs)
+	}
+	r.obsrecv.EndLogsOp(obsCtx, dataFormat, numRecords, err)
+	if err != nil {
+		// Keep the previous tracking value so the rows are fetched again.
+		return multierr.Append(errs, err)
+	}
+
+	if q.query.TrackingColumn != "" {
+		value, found := rows[len(rows)-1][q.query.TrackingColumn]
+		if !found {
+			return multierr.Append(errs, fmt.Errorf("tracking_column '%s' not found in result set", q.query.TrackingColumn))
+		}
+		q.trackingValue = value
+		if err = r.storageClient.Set(ctx, q.storageKey(), []byte(value)); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("failed to store tracking value: %w", err))
+		}
+	}
+	return errs
+}
+
+// storageKey identifies the tracking value of the query by its statement and tracking column, so that
+// reordering the queries or changing them doesn't resume a query from the value of another one.
+func (q *logsQuery) storageKey() string {
+	sum := sha256.Sum256([]byte(q.query.SQL + "\x00" + q.query.TrackingColumn))
+	return fmt.Sprintf("query-%s.tracking_value", hex.EncodeToString(sum[:]))
+}
+
+func rowsToLogs(rows []stringMap, logsCfgs []LogsCfg, observedTimestamp pcommon.Timestamp) (plog.Logs, error) {
+	out := plog.NewLogs()
+	lrs := out.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	var errs error
+	for _, logsCfg := range logsCfgs {
+		for i, row := range rows {
+			lr := plog.NewLogRecord()
+			if err := rowToLog(row, logsCfg, lr, observedTimestamp); err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("row %d: %w", i, err))
+				continue
+			}
+			lr.MoveTo(lrs.AppendEmpty())
+		}
+	}
+	return out, errs
+}
+
+func rowToLog(row stringMap, cfg LogsCfg, dest plog.LogRecord, observedTimestamp pcommon.Timestamp) error {
+	body, found := row[cfg.BodyColumn]
+	if !found {
+		return fmt.Errorf("rowToLog: body_column '%s' not found in result set", cfg.BodyColumn)
+	}
+	attrs := dest.Attributes()
+	for _, column := range cfg.AttributeColumns {
+		value, found := row[column]
+		if !found {
+			return fmt.Errorf("rowToLog: attribute_column '%s' not found in result set", column)
+		}
+		attrs.PutStr(column, value)
+	}
+	dest.SetObservedTimestamp(observedTimestamp)
+	dest.Body().SetStr(body)
+	return nil
+}
+
+func (r *logsReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.shutdownWG.Wait()
+	var errs error
+	if r.storageClient != nil {
+		errs = multierr.Append(errs, r.storageClient.Close(ctx))
+	}
+	if r.db != nil {
+		errs = multierr.Append(errs, r.db.Close())
+	}
+	return errs
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, componentID component.ID) (storage.Client, error) {
+	if storageID == nil {
+		return storage.NewNopClient(), nil
+	}
+
+	extension, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExtension, ok := extension.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExtension.GetClient(ctx, component.KindReceiver, componentID, "")
+}
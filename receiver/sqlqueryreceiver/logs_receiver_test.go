@@ -0,0 +1,158 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlqueryreceiver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+func newTestLogsConfig(storageID *component.ID) *Config {
+	return &Config{
+		ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+			ReceiverSettings:   config.NewReceiverSettings(component.NewID(typeStr)),
+			CollectionInterval: time.Hour,
+		},
+		Driver:     "mydriver",
+		DataSource: "my-datasource",
+		StorageID:  storageID,
+		Queries: []Query{{
+			SQL:                "select id, body, severity from audit where id > $1 order by id",
+			TrackingColumn:     "id",
+			TrackingStartValue: "0",
+			Logs: []LogsCfg{{
+				BodyColumn:       "body",
+				AttributeColumns: []string{"severity"},
+			}},
+		}},
+	}
+}
+
+func newTestLogsReceiver(t *testing.T, cfg *Config, client dbClient, next consumer.Logs) *logsReceiver {
+	createReceiver := createLogsReceiverFunc(fakeDBConnect, func(*sql.DB, string, *zap.Logger) dbClient {
+		return client
+	})
+	rcvr, err := createReceiver(context.Background(), componenttest.NewNopReceiverCreateSettings(), cfg, next)
+	require.NoError(t, err)
+	return rcvr.(*logsReceiver)
+}
+
+func TestLogsReceiverTrackingColumn(t *testing.T) {
+	ctx := context.Background()
+	ext := storagetest.NewFileBackedStorageExtension("test", t.TempDir())
+	extID := ext.ID()
+	host := storagetest.NewStorageHost().WithExtension(extID, ext)
+	cfg := newTestLogsConfig(&extID)
+	sink := new(consumertest.LogsSink)
+
+	client := &fakeDBClient{responses: [][]stringMap{{
+		{"id": "1", "body": "user created", "severity": "INFO"},
+		{"id": "2", "body": "user deleted", "severity": "WARN"},
+	}}}
+	rcvr := newTestLogsReceiver(t, cfg, client, sink)
+	require.NoError(t, rcvr.Start(ctx, host))
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 2 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, rcvr.Shutdown(ctx))
+	assert.Equal(t, [][]interface{}{{"0"}}, client.args)
+
+	lr := sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1)
+	assert.Equal(t, "user deleted", lr.Body().Str())
+	assert.Equal(t, map[string]interface{}{"severity": "WARN"}, lr.Attributes().AsRaw())
+	assert.NotZero(t, lr.ObservedTimestamp())
+
+	// After a restart only the rows newer than the stored tracking value are requested.
+	client = &fakeDBClient{responses: [][]stringMap{{
+		{"id": "3", "body": "user restored", "severity": "INFO"},
+	}}}
+	rcvr = newTestLogsReceiver(t, cfg, client, sink)
+	require.NoError(t, rcvr.Start(ctx, host))
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 3 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, rcvr.Shutdown(ctx))
+	assert.Equal(t, [][]interface{}{{"2"}}, client.args)
+}
+
+func TestLogsQueryStorageKey(t *testing.T) {
+	audit := &logsQuery{index: 0, query: Query{SQL: "select id, body from audit where id > $1 order by id", TrackingColumn: "id"}}
+	moved := &logsQuery{index: 1, query: audit.query}
+	events := &logsQuery{index: 0, query: Query{SQL: "select id, body from events where id > $1 order by id", TrackingColumn: "id"}}
+	byDate := &logsQuery{index: 0, query: Query{SQL: audit.query.SQL, TrackingColumn: "date"}}
+
+	assert.Equal(t, audit.storageKey(), moved.storageKey())
+	assert.NotEqual(t, audit.storageKey(), events.storageKey())
+	assert.NotEqual(t, audit.storageKey(), byDate.storageKey())
+}
+
+func TestLogsReceiverConsumeErrorKeepsTrackingValue(t *testing.T) {
+	ctx := context.Background()
+	client := &fakeDBClient{responses: [][]stringMap{
+		{{"id": "1", "body": "first", "severity": "INFO"}},
+		{{"id": "1", "body": "first", "severity": "INFO"}},
+	}}
+	rcvr := newTestLogsReceiver(t, newTestLogsConfig(nil), client, consumertest.NewErr(errors.New("downstream unavailable")))
+	rcvr.storageClient = storage.NewNopClient()
+	q := &logsQuery{query: rcvr.config.Queries[0], client: client, trackingValue: "0"}
+
+	require.Error(t, rcvr.collectQuery(ctx, q))
+	assert.Equal(t, "0", q.trackingValue)
+	require.Error(t, rcvr.collectQuery(ctx, q))
+	assert.Equal(t, [][]interface{}{{"0"}, {"0"}}, client.args)
+}
+
+func TestLogsReceiverWithoutTrackingColumn(t *testing.T) {
+	ctx := context.Background()
+	cfg := newTestLogsConfig(nil)
+	cfg.Queries[0].TrackingColumn = ""
+	cfg.Queries[0].TrackingStartValue = ""
+	client := &fakeDBClient{responses: [][]stringMap{{{"id": "1", "body": "first", "severity": "INFO"}}}}
+	sink := new(consumertest.LogsSink)
+	rcvr := newTestLogsReceiver(t, cfg, client, sink)
+	rcvr.storageClient = storage.NewNopClient()
+	q := &logsQuery{query: cfg.Queries[0], client: client}
+
+	require.NoError(t, rcvr.collectQuery(ctx, q))
+	assert.Equal(t, 1, sink.LogRecordCount())
+	assert.Equal(t, [][]interface{}{nil}, client.args)
+}
+
+func TestRowsToLogs(t *testing.T) {
+	rows := []stringMap{
+		{"id": "1", "msg": "first", "level": "INFO"},
+		{"id": "2", "level": "WARN"},
+	}
+	logs, err := rowsToLogs(rows, []LogsCfg{{BodyColumn: "msg", AttributeColumns: []string{"level"}}}, pcommon.Timestamp(1))
+	assert.EqualError(t, err, "row 1: rowToLog: body_column 'msg' not found in result set")
+	require.Equal(t, 1, logs.LogRecordCount())
+	lr := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, "first", lr.Body().Str())
+	assert.Equal(t, pcommon.Timestamp(1), lr.ObservedTimestamp())
+	assert.Equal(t, map[string]interface{}{"level": "INFO"}, lr.Attributes().AsRaw())
+}